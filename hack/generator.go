@@ -9,6 +9,16 @@ import (
 )
 
 func main() {
+	if os.Args[1] == "schema" {
+		apiDir := os.Args[2]
+		gen := generators.SchemaGenerator{
+			APIDir:    apiDir,
+			OutputDir: apiDir,
+		}
+		_ = gen.Generate()
+		return
+	}
+
 	configDir := os.Args[1]
 	outputDir := os.Args[2]
 	gen := generators.InstallGenerator{
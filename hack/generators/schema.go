@@ -0,0 +1,309 @@
+package generators
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/util/jsonschema"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SchemaFile is the name of the generated manifest schema.
+const SchemaFile = "manifest.schema.json"
+
+// SchemaGenerator is used to generate the JSON schema of the client manifest.
+type SchemaGenerator struct {
+	// APIDir is the directory of the API package.  The field comments are read from
+	// its sources and used as descriptions.
+	APIDir    string
+	OutputDir string
+}
+
+// Required fields are the ones that the manifest's UnmarshalYAML functions reject when
+// they are missing.  The +required markers aren't used because they describe the
+// resources in the cluster, where some of the fields are set by the client.
+//
+// nolint:gochecknoglobals
+var required = map[reflect.Type][]string{
+	reflect.TypeOf(v1beta1.Manifest{}):               {"name"},
+	reflect.TypeOf(v1beta1.ManifestDependency{}):     {"name", "path"},
+	reflect.TypeOf(v1beta1.ManifestWaitCondition{}):  {"kind", "name"},
+	reflect.TypeOf(v1beta1.ManifestLiveUpdateSync{}): {"src", "dest"},
+	reflect.TypeOf(v1beta1.ManifestLiveUpdateRun{}):  {"command"},
+}
+
+// nolint:gochecknoglobals
+var enums = map[reflect.Type][]string{
+	reflect.TypeOf(v1beta1.EnvironmentBuildEngine("")): {
+		string(v1beta1.EnvironmentBuildEngineKaniko),
+		string(v1beta1.EnvironmentBuildEngineBuildKit),
+		string(v1beta1.EnvironmentBuildEngineBuildpacks),
+	},
+	reflect.TypeOf(v1beta1.DependencyType("")): {"kustomize"},
+	reflect.TypeOf(corev1.Protocol("")): {
+		string(corev1.ProtocolTCP),
+		string(corev1.ProtocolUDP),
+		string(corev1.ProtocolSCTP),
+	},
+	reflect.TypeOf(corev1.ServiceType("")): {
+		string(corev1.ServiceTypeClusterIP),
+		string(corev1.ServiceTypeNodePort),
+		string(corev1.ServiceTypeLoadBalancer),
+		string(corev1.ServiceTypeExternalName),
+	},
+}
+
+// Types that decode differently than their Go type suggests.
+//
+// nolint:gochecknoglobals
+var overrides = map[reflect.Type]func() *jsonschema.Schema{
+	// Quantities are parsed from strings, which numbers decode into as well.
+	reflect.TypeOf(v1beta1.EnvironmentResources{}): func() *jsonschema.Schema {
+		return &jsonschema.Schema{
+			Type: jsonschema.Types{jsonschema.TypeObject},
+			AdditionalProperties: &jsonschema.Schema{
+				Type: jsonschema.Types{jsonschema.TypeString, jsonschema.TypeNumber},
+			},
+		}
+	},
+	// The YAML decoder parses durations like 30s, and takes integers as nanoseconds.
+	reflect.TypeOf(time.Duration(0)): func() *jsonschema.Schema {
+		return &jsonschema.Schema{
+			Type: jsonschema.Types{jsonschema.TypeString, jsonschema.TypeInteger},
+		}
+	},
+}
+
+// Generate creates the JSON schema of the manifest.  The schema follows the rules of
+// the YAML decoder rather than the JSON tags, so fields without a yaml tag, like the
+// ones in the Kubernetes types, use their lower cased name.  Objects don't allow
+// unknown fields since the decoder would silently ignore them.
+func (g *SchemaGenerator) Generate() error {
+	docs, err := readDocs(g.APIDir)
+	assertNoError(err)
+
+	r := &reflector{
+		docs: docs,
+		defs: make(map[string]*jsonschema.Schema),
+		pkg:  reflect.TypeOf(v1beta1.Manifest{}).PkgPath(),
+	}
+
+	root := r.schema(reflect.TypeOf(v1beta1.Manifest{}))
+	root.Schema = jsonschema.Draft
+	root.Title = "Seaway manifest"
+	root.Defs = r.defs
+
+	out, err := json.MarshalIndent(root, "", "  ")
+	assertNoError(err)
+
+	file := path.Join(g.OutputDir, SchemaFile)
+	err = os.WriteFile(file, append(out, '\n'), 0644) // nolint:gosec
+	assertNoError(err)
+
+	return nil
+}
+
+type reflector struct {
+	// docs are the comments of the API types and their fields, keyed by the type
+	// name and by the type and field name joined with a dot.
+	docs map[string]string
+	defs map[string]*jsonschema.Schema
+	pkg  string
+}
+
+// schema returns the schema of the type.  Named structs are added to the definitions
+// and referenced.
+func (r *reflector) schema(t reflect.Type) *jsonschema.Schema {
+	if override, ok := overrides[t]; ok {
+		return override()
+	}
+
+	if values, ok := enums[t]; ok {
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}, Enum: values}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return r.schema(t.Elem())
+	case reflect.String:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+	case reflect.Bool:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeNumber}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+		}
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeArray}, Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeObject}, AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+
+		name := r.defName(t)
+		if _, ok := r.defs[name]; !ok {
+			// Added before the fields are walked so recursive types terminate.
+			r.defs[name] = &jsonschema.Schema{}
+			*r.defs[name] = *r.object(t)
+		}
+		return jsonschema.Ref(name)
+	}
+
+	// Interfaces and anything else can't be described.
+	return &jsonschema.Schema{}
+}
+
+// object returns the schema of the struct's fields.
+func (r *reflector) object(t reflect.Type) *jsonschema.Schema {
+	s := &jsonschema.Schema{
+		Type:                 jsonschema.Types{jsonschema.TypeObject},
+		Description:          r.doc(t, ""),
+		Properties:           make(map[string]*jsonschema.Schema),
+		Required:             required[t],
+		AdditionalProperties: jsonschema.False(),
+	}
+	r.fields(t, s)
+
+	return s
+}
+
+// fields adds the struct's fields to the schema the way the YAML decoder maps them.
+func (r *reflector) fields(t reflect.Type, s *jsonschema.Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		if strings.Contains(","+flags+",", ",inline,") {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			r.fields(ft, s)
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		prop := r.schema(field.Type)
+		prop.Description = r.doc(t, field.Name)
+		s.Properties[name] = prop
+	}
+}
+
+// defName returns the name of the definition for the type.  Types from other packages
+// are qualified with their package path.
+func (r *reflector) defName(t reflect.Type) string {
+	if t.PkgPath() == r.pkg {
+		return t.Name()
+	}
+
+	return strings.ReplaceAll(t.PkgPath(), "/", ".") + "." + t.Name()
+}
+
+func (r *reflector) doc(t reflect.Type, field string) string {
+	if t.PkgPath() != r.pkg {
+		return ""
+	}
+
+	if field == "" {
+		return r.docs[t.Name()]
+	}
+
+	return r.docs[t.Name()+"."+field]
+}
+
+// readDocs returns the comments of the types and fields in the package.  Marker lines
+// are dropped and the lines are joined.
+func readDocs(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range file.Decls {
+			decl, ok := d.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range decl.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				group := ts.Doc
+				if group == nil && len(decl.Specs) == 1 {
+					group = decl.Doc
+				}
+				docs[ts.Name.Name] = cleanDoc(group)
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				for _, field := range st.Fields.List {
+					for _, name := range field.Names {
+						docs[ts.Name.Name+"."+name.Name] = cleanDoc(field.Doc)
+					}
+				}
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+func cleanDoc(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(group.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "+") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, " ")
+}
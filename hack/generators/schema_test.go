@@ -0,0 +1,30 @@
+package generators
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The committed schema has to match the manifest types, run `make schemagen` after
+// changing them.
+func TestSchemaUpToDate(t *testing.T) {
+	apiDir := path.Join("..", "..", "pkg", "apis", "seaway.ctx.sh", "v1beta1")
+	outputDir := t.TempDir()
+
+	gen := SchemaGenerator{
+		APIDir:    apiDir,
+		OutputDir: outputDir,
+	}
+	require.NoError(t, gen.Generate())
+
+	expected, err := os.ReadFile(path.Join(apiDir, SchemaFile))
+	require.NoError(t, err)
+	actual, err := os.ReadFile(path.Join(outputDir, SchemaFile))
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -27,21 +27,30 @@ const (
 	DefaultStorageRegion         = "us-east-1"
 	DefaultStorageForcePathStyle = true
 	DefaultStoragePrefix         = "artifacts"
-	DefaultStorageCredentials    = "secret"
+	DefaultStorageCredentials    = "storage-credentials"
 	DefaultStorageEndpoint       = "http://localstack.seaway-system.svc.cluster.local:4566"
+	DefaultStorageType           = EnvironmentStorageTypeS3
+	DefaultStoragePath           = "/var/lib/seaway"
+	DefaultStorageRetention      = 5
 	DefaultRegistryURL           = "http://registry.seaway-system.svc.cluster.local:5000"
 	DefaultRegistryNodeport      = 31555
+	DefaultBuildEngine           = EnvironmentBuildEngineKaniko
 	DefaultBuildImage            = "gcr.io/kaniko-project/executor:latest"
+	DefaultBuildKitImage         = "moby/buildkit:v0.16.0-rootless"
+	DefaultBuildpacksImage       = "paketobuildpacks/builder-jammy-base:latest"
 	DefaultDockerfile            = "Dockerfile"
 	DefaultPlatform              = runtime.GOOS + "/" + runtime.GOARCH
 	DefaultControllerNamespace   = "seaway-system"
 	DefaultConfigName            = "default"
+	DefaultRevisionHistoryLimit  = 10
 )
 
 func Defaulted(obj client.Object) {
 	switch v := obj.(type) { //nolint:gocritic
 	case *Environment:
 		defaultEnvironment(v)
+	case *EnvironmentConfig:
+		defaultEnvironmentConfig(v)
 	}
 }
 
@@ -58,10 +67,6 @@ func defaultEnvironmentSpec(obj *EnvironmentSpec) {
 		obj.Args = []string{}
 	}
 
-	if obj.Config == "" {
-		obj.Config = DefaultConfigName
-	}
-
 	if obj.Resources == nil {
 		obj.Resources = EnvironmentResources{}
 	}
@@ -97,10 +102,20 @@ func defaultEnvironmentBuild(obj *EnvironmentBuild) *EnvironmentBuild {
 		obj = new(EnvironmentBuild)
 	}
 
-	// RegistryRef is required.
+	if obj.Engine == "" {
+		obj.Engine = DefaultBuildEngine
+	}
+
 	if obj.Image == nil {
 		obj.Image = new(string)
-		*obj.Image = DefaultBuildImage
+		switch obj.Engine {
+		case EnvironmentBuildEngineBuildKit:
+			*obj.Image = DefaultBuildKitImage
+		case EnvironmentBuildEngineBuildpacks:
+			*obj.Image = DefaultBuildpacksImage
+		default:
+			*obj.Image = DefaultBuildImage
+		}
 	}
 
 	if obj.Dockerfile == nil {
@@ -204,3 +219,29 @@ func defaultEnvironmentIngress(obj *EnvironmentIngress, defaultPort int32) *Envi
 
 	return obj
 }
+
+func defaultEnvironmentConfig(obj *EnvironmentConfig) {
+	defaultEnvironmentConfigStorage(&obj.Spec.Storage)
+}
+
+func defaultEnvironmentConfigStorage(obj *EnvironmentConfigStorage) {
+	if obj.Type == "" {
+		obj.Type = DefaultStorageType
+	}
+
+	if obj.Type == EnvironmentStorageTypeFilesystem && obj.Path == "" {
+		obj.Path = DefaultStoragePath
+	}
+
+	if obj.Credentials == "" {
+		obj.Credentials = DefaultStorageCredentials
+	}
+
+	if obj.Prefix == "" {
+		obj.Prefix = DefaultStoragePrefix
+	}
+
+	if obj.Retention == 0 {
+		obj.Retention = DefaultStorageRetention
+	}
+}
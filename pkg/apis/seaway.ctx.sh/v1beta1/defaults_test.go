@@ -18,6 +18,7 @@ func TestDefaulted(t *testing.T) {
 		Spec: EnvironmentSpec{
 			Args: []string{},
 			Build: &EnvironmentBuild{
+				Engine:     DefaultBuildEngine,
 				Dockerfile: ptr.To(DefaultDockerfile),
 				Image:      ptr.To(DefaultBuildImage),
 				Platform:   ptr.To(DefaultPlatform),
@@ -25,7 +26,7 @@ func TestDefaulted(t *testing.T) {
 				Exclude:    []string{},
 			},
 			Command:       nil,
-			Config:        DefaultConfigName,
+			Config:        "",
 			Lifecycle:     nil,
 			LivenessProbe: nil,
 			Network: &EnvironmentNetwork{
@@ -136,3 +137,78 @@ func TestDefaulted_IngressEnabled(t *testing.T) {
 	Defaulted(obj)
 	assert.Equal(t, expected, obj.Spec.Network)
 }
+
+func TestDefaulted_EnvironmentConfig(t *testing.T) {
+	obj := &EnvironmentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: DefaultControllerNamespace,
+		},
+		Spec: EnvironmentConfigSpec{
+			Storage: EnvironmentConfigStorage{
+				Bucket:   "seaway",
+				Endpoint: "http://minio.seaway-system.svc.cluster.local:80",
+				Region:   "us-east-1",
+			},
+		},
+	}
+
+	expected := EnvironmentConfigStorage{
+		Type:        EnvironmentStorageTypeS3,
+		Bucket:      "seaway",
+		Credentials: DefaultStorageCredentials,
+		Endpoint:    "http://minio.seaway-system.svc.cluster.local:80",
+		Prefix:      DefaultStoragePrefix,
+		Region:      "us-east-1",
+		Retention:   DefaultStorageRetention,
+	}
+
+	Defaulted(obj)
+	assert.Equal(t, expected, obj.Spec.Storage)
+}
+
+func TestDefaulted_EnvironmentConfigFilesystem(t *testing.T) {
+	obj := &EnvironmentConfig{
+		Spec: EnvironmentConfigSpec{
+			Storage: EnvironmentConfigStorage{
+				Type:        EnvironmentStorageTypeFilesystem,
+				Bucket:      "seaway",
+				VolumeClaim: "seaway-storage",
+			},
+		},
+	}
+
+	Defaulted(obj)
+	assert.Equal(t, DefaultStoragePath, obj.Spec.Storage.Path)
+	assert.False(t, obj.Spec.Storage.RequiresCredentials())
+	assert.NoError(t, obj.Spec.Storage.Validate())
+
+	// The claim can't be defaulted, so the config is rejected without one.
+	obj.Spec.Storage.VolumeClaim = ""
+	assert.Error(t, obj.Spec.Storage.Validate())
+}
+
+func TestDefaulted_BuildEngine(t *testing.T) {
+	tests := []struct {
+		engine   EnvironmentBuildEngine
+		expected string
+	}{
+		{"", DefaultBuildImage},
+		{EnvironmentBuildEngineKaniko, DefaultBuildImage},
+		{EnvironmentBuildEngineBuildKit, DefaultBuildKitImage},
+		{EnvironmentBuildEngineBuildpacks, DefaultBuildpacksImage},
+	}
+
+	for _, tt := range tests {
+		obj := &Environment{
+			Spec: EnvironmentSpec{
+				Build: &EnvironmentBuild{
+					Engine: tt.engine,
+				},
+			},
+		}
+
+		Defaulted(obj)
+		assert.Equal(t, tt.expected, *obj.Spec.Build.Image, string(tt.engine))
+	}
+}
@@ -15,15 +15,40 @@
 package v1beta1
 
 import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+const (
+	// RollbackAnnotation is set by the client to the revision that is being rolled
+	// back to.  It's only honored while it matches the spec revision.
+	RollbackAnnotation = "seaway.ctx.sh/rollback"
+	// SyncedByAnnotation is set by the client to the user that last changed the
+	// revision of the environment.
+	SyncedByAnnotation = "seaway.ctx.sh/synced-by"
+	// SyncedAtAnnotation is set by the client to the time the revision was last
+	// changed, formatted as RFC 3339.
+	SyncedAtAnnotation = "seaway.ctx.sh/synced-at"
+	// EnvironmentFinalizer blocks the removal of the environment until the build job,
+	// source archive and images have been cleaned up.
+	EnvironmentFinalizer = "seaway.ctx.sh/finalizer"
+)
+
 // GetRevision returns the configured revision of the environment.
 func (e *Environment) GetRevision() string {
 	return e.Spec.Revision
 }
 
+// ImageRepository returns the registry repository of the environment's images.  The
+// namespace is part of the repository so environments with the same name in different
+// namespaces never share, or delete, each other's images.
+func (e *Environment) ImageRepository() string {
+	return e.GetNamespace() + "/" + e.GetName()
+}
+
 // HasFailed returns true if the environment has failed to build or deploy.
 func (e *Environment) HasFailed() bool {
 	return e.Status.Stage == EnvironmentStageBuildImageFailed ||
@@ -44,6 +69,40 @@ func (e *Environment) IsDeployed() bool {
 	return e.Status.DeployedRevision == e.GetRevision()
 }
 
+// IsRollback returns true if the current revision is a rollback to an image that has
+// already been built.
+func (e *Environment) IsRollback() bool {
+	return e.GetAnnotations()[RollbackAnnotation] == e.GetRevision()
+}
+
+// SetSyncedBy records the user that changed the revision and when.
+func (e *Environment) SetSyncedBy(user string, at time.Time) {
+	annotations := e.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[SyncedByAnnotation] = user
+	annotations[SyncedAtAnnotation] = at.UTC().Format(time.RFC3339)
+	e.SetAnnotations(annotations)
+}
+
+// GetSyncedBy returns the user that last changed the revision, or an empty string if
+// it wasn't recorded.
+func (e *Environment) GetSyncedBy() string {
+	return e.GetAnnotations()[SyncedByAnnotation]
+}
+
+// GetSyncedAt returns the time the revision was last changed, or the zero time if it
+// wasn't recorded.
+func (e *Environment) GetSyncedAt() time.Time {
+	at, err := time.Parse(time.RFC3339, e.GetAnnotations()[SyncedAtAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return at
+}
+
 // IsInitializing returns true if the environment is in the initialization stage.
 func (e *Environment) IsInitializing() bool {
 	return e.Status.Stage == EnvironmentStageInitialize
@@ -82,12 +141,17 @@ func (e *Environment) GetControllerReference() metav1.OwnerReference {
 	}
 }
 
-// GetStageString returns the string message for the EnvironmentStage.
+// GetStageString returns the string message for the EnvironmentStage.  Failed stages
+// include the reason for the failure when there is one.
 func (e *Environment) GetStageString() string {
 	if e.Status.Stage == EnvironmentStageInitialize {
 		return "Initializing"
 	}
 
+	if e.HasFailed() && e.Status.Reason != "" {
+		return string(e.Status.Stage) + ": " + e.Status.Reason
+	}
+
 	return string(e.Status.Stage)
 }
 
@@ -106,3 +170,70 @@ func (e *Environment) GetStatusString() string {
 		return "deploying"
 	}
 }
+
+// SetCondition adds or updates the condition on the status.  The condition is stamped
+// with the observed generation of the status, and the transition time is only updated
+// when the condition status changes.
+func (s *EnvironmentStatus) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&s.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: s.ObservedGeneration,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// GetCondition returns the condition with the given type or nil if it has not been set.
+func (s *EnvironmentStatus) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(s.Conditions, conditionType)
+}
+
+// RecordRevision adds the revision to the front of the history.  A pending revision that
+// is replaced before it finishes is marked as superseded, and the history is trimmed
+// to DefaultRevisionHistoryLimit entries.
+func (s *EnvironmentStatus) RecordRevision(rev EnvironmentRevision) {
+	if len(s.History) > 0 && s.History[0].Outcome == EnvironmentRevisionPending {
+		s.History[0].Outcome = EnvironmentRevisionSuperseded
+	}
+
+	s.History = append([]EnvironmentRevision{rev}, s.History...)
+	if len(s.History) > DefaultRevisionHistoryLimit {
+		s.History = s.History[:DefaultRevisionHistoryLimit]
+	}
+}
+
+// CurrentRevision returns the history entry for the expected revision or nil if it
+// has not been recorded.
+func (s *EnvironmentStatus) CurrentRevision() *EnvironmentRevision {
+	if len(s.History) == 0 || s.History[0].Revision != s.ExpectedRevision {
+		return nil
+	}
+
+	return &s.History[0]
+}
+
+// LastDeployed returns the most recent successfully deployed revision in the history,
+// excluding the given revision.
+func (s *EnvironmentStatus) LastDeployed(exclude string) *EnvironmentRevision {
+	for i := range s.History {
+		rev := &s.History[i]
+		if rev.Outcome == EnvironmentRevisionDeployed && rev.Revision != exclude {
+			return rev
+		}
+	}
+
+	return nil
+}
+
+// FindRevision returns the most recent history entry for the revision or nil if it
+// isn't in the history.
+func (s *EnvironmentStatus) FindRevision(revision string) *EnvironmentRevision {
+	for i := range s.History {
+		if s.History[i].Revision == revision {
+			return &s.History[i]
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,11 @@
 package v1beta1
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 func TestHasFailed(t *testing.T) {
 	var tests = []struct {
@@ -57,3 +62,171 @@ func TestHasDeviated(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCondition(t *testing.T) {
+	status := &EnvironmentStatus{
+		ObservedGeneration: 2,
+	}
+
+	status.SetCondition(EnvironmentConditionReady, metav1.ConditionFalse, EnvironmentReasonProgressing, "building")
+	condition := status.GetCondition(EnvironmentConditionReady)
+	if condition == nil {
+		t.Fatalf("GetCondition() = nil, want condition")
+	}
+
+	if condition.ObservedGeneration != 2 {
+		t.Errorf("ObservedGeneration = %v, want %v", condition.ObservedGeneration, 2)
+	}
+
+	status.ObservedGeneration = 3
+	status.SetCondition(EnvironmentConditionReady, metav1.ConditionTrue, EnvironmentReasonDeployed, "deployed")
+	if len(status.Conditions) != 1 {
+		t.Fatalf("len(Conditions) = %v, want %v", len(status.Conditions), 1)
+	}
+
+	condition = status.GetCondition(EnvironmentConditionReady)
+	if condition.Status != metav1.ConditionTrue || condition.Reason != EnvironmentReasonDeployed || condition.ObservedGeneration != 3 {
+		t.Errorf("GetCondition() = %v, want updated ready condition", condition)
+	}
+
+	if status.GetCondition(EnvironmentConditionDeployed) != nil {
+		t.Errorf("GetCondition() = %v, want nil", status.GetCondition(EnvironmentConditionDeployed))
+	}
+}
+
+func TestRecordRevision(t *testing.T) {
+	status := &EnvironmentStatus{}
+	status.RecordRevision(EnvironmentRevision{Revision: "1", Outcome: EnvironmentRevisionDeployed})
+	status.RecordRevision(EnvironmentRevision{Revision: "2", Outcome: EnvironmentRevisionPending})
+	status.RecordRevision(EnvironmentRevision{Revision: "3", Outcome: EnvironmentRevisionPending})
+
+	if status.History[0].Revision != "3" {
+		t.Errorf("History[0].Revision = %v, want %v", status.History[0].Revision, "3")
+	}
+
+	if status.History[1].Outcome != EnvironmentRevisionSuperseded {
+		t.Errorf("History[1].Outcome = %v, want %v", status.History[1].Outcome, EnvironmentRevisionSuperseded)
+	}
+
+	if rev := status.LastDeployed("3"); rev == nil || rev.Revision != "1" {
+		t.Errorf("LastDeployed() = %v, want revision %v", rev, "1")
+	}
+
+	if rev := status.LastDeployed("1"); rev != nil {
+		t.Errorf("LastDeployed() = %v, want nil", rev)
+	}
+
+	for i := 0; i < DefaultRevisionHistoryLimit; i++ {
+		status.RecordRevision(EnvironmentRevision{Revision: "next"})
+	}
+
+	if len(status.History) != DefaultRevisionHistoryLimit {
+		t.Errorf("len(History) = %v, want %v", len(status.History), DefaultRevisionHistoryLimit)
+	}
+}
+
+func TestIsRollback(t *testing.T) {
+	env := &Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				RollbackAnnotation: "1",
+			},
+		},
+		Spec: EnvironmentSpec{
+			Revision: "1",
+		},
+	}
+
+	if !env.IsRollback() {
+		t.Errorf("IsRollback() = false, want true")
+	}
+
+	env.Spec.Revision = "2"
+	if env.IsRollback() {
+		t.Errorf("IsRollback() = true, want false")
+	}
+}
+
+func TestSetSyncedBy(t *testing.T) {
+	env := &Environment{}
+
+	if user := env.GetSyncedBy(); user != "" {
+		t.Errorf("GetSyncedBy() = %v, want empty", user)
+	}
+
+	if at := env.GetSyncedAt(); !at.IsZero() {
+		t.Errorf("GetSyncedAt() = %v, want zero", at)
+	}
+
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("PDT", -7*60*60))
+	env.SetSyncedBy("alice@example.com", at)
+
+	if user := env.GetSyncedBy(); user != "alice@example.com" {
+		t.Errorf("GetSyncedBy() = %v, want %v", user, "alice@example.com")
+	}
+
+	if got := env.GetAnnotations()[SyncedAtAnnotation]; got != "2024-05-01T19:30:00Z" {
+		t.Errorf("SyncedAtAnnotation = %v, want %v", got, "2024-05-01T19:30:00Z")
+	}
+
+	if got := env.GetSyncedAt(); !got.Equal(at) {
+		t.Errorf("GetSyncedAt() = %v, want %v", got, at)
+	}
+}
+
+func TestGetStageString(t *testing.T) {
+	var tests = []struct {
+		name     string
+		stage    EnvironmentStage
+		reason   string
+		expected string
+	}{
+		{"Initializing", EnvironmentStageInitialize, "", "Initializing"},
+		{"Deploying", EnvironmentStageDeploy, "", string(EnvironmentStageDeploy)},
+		{"FailedWithoutReason", EnvironmentStageDeployFailed, "", string(EnvironmentStageDeployFailed)},
+		{"FailedWithReason", EnvironmentStageDeployFailed, "OOMKilled", string(EnvironmentStageDeployFailed) + ": OOMKilled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &Environment{
+				Status: EnvironmentStatus{
+					Stage:  tt.stage,
+					Reason: tt.reason,
+				},
+			}
+
+			if got := env.GetStageString(); got != tt.expected {
+				t.Errorf("GetStageString() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidate_Revision(t *testing.T) {
+	var tests = []struct {
+		revision string
+		valid    bool
+	}{
+		{"5d41402abc4b2a76b9719d911017c592", true},
+		{"v1", true},
+		{"..", false},
+		{".", false},
+		{"../other/app", false},
+		{`a\b`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.revision, func(t *testing.T) {
+			env := &Environment{
+				Spec: EnvironmentSpec{
+					Revision: tt.revision,
+				},
+			}
+
+			if _, err := env.Validate(); (err == nil) != tt.valid {
+				t.Errorf("Validate() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seaway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+var (
+	ErrNoToken      = errors.New("a bearer token is required")
+	ErrInvalidToken = errors.New("the bearer token is not valid")
+)
+
+// Reviewer authenticates callers and checks their access to environments.
+type Reviewer interface {
+	// Authenticate returns the user the token belongs to.
+	Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, error)
+	// Authorize returns whether the user is allowed to perform the action and the
+	// reason given by the authorizer.
+	Authorize(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, string, error)
+}
+
+// KubeReviewer uses TokenReview and SubjectAccessReview to check callers against the
+// cluster's own authentication and RBAC.
+type KubeReviewer struct {
+	client client.Client
+}
+
+// NewKubeReviewer returns a reviewer that uses the kubernetes API.
+func NewKubeReviewer(c client.Client) *KubeReviewer {
+	return &KubeReviewer{client: c}
+}
+
+// Authenticate validates the token with a TokenReview.
+func (r *KubeReviewer) Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+
+	if err := r.client.Create(ctx, review); err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return authenticationv1.UserInfo{}, fmt.Errorf("%w: %s", ErrInvalidToken, review.Status.Error)
+		}
+		return authenticationv1.UserInfo{}, ErrInvalidToken
+	}
+
+	return review.Status.User, nil
+}
+
+// Authorize checks the user's access with a SubjectAccessReview.
+func (r *KubeReviewer) Authorize(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, string, error) {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user.Username,
+			UID:                user.UID,
+			Groups:             user.Groups,
+			Extra:              extra,
+			ResourceAttributes: &attrs,
+		},
+	}
+
+	if err := r.client.Create(ctx, review); err != nil {
+		return false, "", err
+	}
+
+	return review.Status.Allowed, review.Status.Reason, nil
+}
+
+// target is the environment a request acts on and the verb that is checked.
+type target struct {
+	namespace string
+	name      string
+	verb      string
+}
+
+// targetOf returns the environment targeted by a request message.  Blobs are shared
+// between environments, but the caller still has to be allowed to update the
+// environment they're uploaded for.  Messages that aren't tied to an environment only
+// require the caller to be authenticated.
+func targetOf(msg any) (target, bool) {
+	switch m := msg.(type) {
+	case *seawayv1beta1.EnvironmentRequest:
+		return target{m.GetNamespace(), m.GetName(), "get"}, true
+	case *seawayv1beta1.MissingBlobsRequest:
+		return target{m.GetNamespace(), m.GetName(), "update"}, true
+	case *seawayv1beta1.UploadBlobRequest:
+		if info := m.GetBlobInfo(); info != nil {
+			return target{info.GetNamespace(), info.GetName(), "update"}, true
+		}
+	case *seawayv1beta1.UploadRequest:
+		if info := m.GetArtifactInfo(); info != nil {
+			return target{info.GetNamespace(), info.GetName(), "update"}, true
+		}
+	case *seawayv1beta1.AssembleRequest:
+		return target{m.GetNamespace(), m.GetName(), "update"}, true
+	case *seawayv1beta1.CreateUploadRequest:
+		return target{m.GetNamespace(), m.GetName(), "update"}, true
+	case *seawayv1beta1.UploadPartRequest:
+		if info := m.GetPartInfo(); info != nil {
+			return target{info.GetNamespace(), info.GetName(), "update"}, true
+		}
+	case *seawayv1beta1.CompleteUploadRequest:
+		return target{m.GetNamespace(), m.GetName(), "update"}, true
+	}
+
+	return target{}, false
+}
+
+// bearerToken returns the token from the authorization header.
+func bearerToken(header http.Header) (string, bool) {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
+
+// AuthInterceptor authenticates every call with the caller's bearer token and checks
+// that the caller has access to the environment before the handler acts on it.  The
+// target of a client stream is only known once its first message arrives, so the
+// check is done as messages are received.
+type AuthInterceptor struct {
+	reviewer Reviewer
+}
+
+// NewAuthInterceptor returns an interceptor that uses the reviewer to check callers.
+func NewAuthInterceptor(reviewer Reviewer) *AuthInterceptor {
+	return &AuthInterceptor{reviewer: reviewer}
+}
+
+func (a *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		user, err := a.authenticate(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+
+		ctx = withUser(ctx, user)
+		if t, ok := targetOf(req.Any()); ok {
+			if err := a.authorize(ctx, user, t); err != nil {
+				return nil, err
+			}
+		}
+
+		return next(ctx, req)
+	}
+}
+
+func (a *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (a *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		user, err := a.authenticate(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+
+		ctx = withUser(ctx, user)
+		return next(ctx, &authorizingConn{
+			StreamingHandlerConn: conn,
+			ctx:                  ctx,
+			interceptor:          a,
+			user:                 user,
+			allowed:              make(map[target]struct{}),
+		})
+	}
+}
+
+func (a *AuthInterceptor) authenticate(ctx context.Context, header http.Header) (authenticationv1.UserInfo, error) {
+	token, ok := bearerToken(header)
+	if !ok {
+		return authenticationv1.UserInfo{}, connect.NewError(connect.CodeUnauthenticated, ErrNoToken)
+	}
+
+	user, err := a.reviewer.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) {
+			return authenticationv1.UserInfo{}, connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		log.FromContext(ctx).Error(err, "unable to review token")
+		return authenticationv1.UserInfo{}, connect.NewError(connect.CodeUnavailable, errors.New("unable to authenticate the request"))
+	}
+
+	return user, nil
+}
+
+func (a *AuthInterceptor) authorize(ctx context.Context, user authenticationv1.UserInfo, t target) error {
+	if t.namespace == "" || t.name == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("the environment name and namespace are required"))
+	}
+
+	allowed, reason, err := a.reviewer.Authorize(ctx, user, authorizationv1.ResourceAttributes{
+		Namespace: t.namespace,
+		Name:      t.name,
+		Verb:      t.verb,
+		Group:     v1beta1.SchemeGroupVersion.Group,
+		Version:   v1beta1.SchemeGroupVersion.Version,
+		Resource:  "environments",
+	})
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to review access")
+		return connect.NewError(connect.CodeUnavailable, errors.New("unable to authorize the request"))
+	}
+
+	if !allowed {
+		msg := fmt.Sprintf("%s is not allowed to %s environment %s/%s", user.Username, t.verb, t.namespace, t.name)
+		if reason != "" {
+			msg += ": " + reason
+		}
+		return connect.NewError(connect.CodePermissionDenied, errors.New(msg))
+	}
+
+	return nil
+}
+
+// authorizingConn checks access for every environment a stream's messages target
+// before the handler sees them.
+type authorizingConn struct {
+	connect.StreamingHandlerConn
+	ctx         context.Context //nolint:containedctx
+	interceptor *AuthInterceptor
+	user        authenticationv1.UserInfo
+	allowed     map[target]struct{}
+}
+
+func (c *authorizingConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+
+	t, ok := targetOf(msg)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := c.allowed[t]; ok {
+		return nil
+	}
+
+	if err := c.interceptor.authorize(c.ctx, c.user, t); err != nil {
+		return err
+	}
+	c.allowed[t] = struct{}{}
+
+	return nil
+}
+
+// withUser adds the caller to the logger in the context.
+func withUser(ctx context.Context, user authenticationv1.UserInfo) context.Context {
+	return log.IntoContext(ctx, log.FromContext(ctx).WithValues("user", user.Username))
+}
+
+// streamError returns the error from receiving on a stream.  Errors that already
+// carry a code, like a failed authorization, are passed through unchanged.
+func streamError(err error) error {
+	var cerr *connect.Error
+	if errors.As(err, &cerr) {
+		return cerr
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.NewError(connect.CodeCanceled, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	}
+
+	return connect.NewError(connect.CodeUnknown, err)
+}
+
+// receiveError returns the error that ended a client stream.  A client that goes
+// away can look like a clean end of the stream, so a canceled request is an error.
+func receiveError(ctx context.Context, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,220 @@
+package seaway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+type fakeReviewer struct {
+	token   string
+	allowed map[string]bool
+	checked []authorizationv1.ResourceAttributes
+}
+
+func (f *fakeReviewer) Authenticate(_ context.Context, token string) (authenticationv1.UserInfo, error) {
+	if token != f.token {
+		return authenticationv1.UserInfo{}, ErrInvalidToken
+	}
+
+	return authenticationv1.UserInfo{Username: "dev"}, nil
+}
+
+func (f *fakeReviewer) Authorize(_ context.Context, _ authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, string, error) {
+	f.checked = append(f.checked, attrs)
+	return f.allowed[attrs.Namespace+"/"+attrs.Verb], "", nil
+}
+
+// authTestService answers environment requests without a tracker.  Everything else
+// is handled by the real service.
+type authTestService struct {
+	*Service
+}
+
+func (s *authTestService) Environment(_ context.Context, req *connect.Request[seawayv1beta1.EnvironmentRequest]) (*connect.Response[seawayv1beta1.EnvironmentResponse], error) {
+	return connect.NewResponse(&seawayv1beta1.EnvironmentResponse{Revision: req.Msg.GetName()}), nil
+}
+
+func newAuthTestClient(t *testing.T, reviewer Reviewer, token string) seawayv1beta1connect.SeawayServiceClient {
+	t.Helper()
+
+	svc := &authTestService{Service: &Service{options: &Options{}}}
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(svc, connect.WithInterceptors(NewAuthInterceptor(reviewer)))
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	var opts []connect.ClientOption
+	if token != "" {
+		opts = append(opts, connect.WithInterceptors(&tokenInterceptor{token: token}))
+	}
+
+	return seawayv1beta1connect.NewSeawayServiceClient(server.Client(), server.URL, opts...)
+}
+
+// tokenInterceptor adds the bearer token to every call.
+type tokenInterceptor struct {
+	token string
+}
+
+func (t *tokenInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+t.token)
+		return next(ctx, req)
+	}
+}
+
+func (t *tokenInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+t.token)
+		return conn
+	}
+}
+
+func (t *tokenInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		namespace string
+		code      connect.Code
+	}{
+		{"no token", "", "team-a", connect.CodeUnauthenticated},
+		{"invalid token", "wrong", "team-a", connect.CodeUnauthenticated},
+		{"denied", "secret", "team-b", connect.CodePermissionDenied},
+		{"allowed", "secret", "team-a", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewer := &fakeReviewer{
+				token:   "secret",
+				allowed: map[string]bool{"team-a/get": true},
+			}
+
+			sclient := newAuthTestClient(t, reviewer, tt.token)
+			resp, err := sclient.Environment(context.Background(), connect.NewRequest(&seawayv1beta1.EnvironmentRequest{
+				Name:      "app",
+				Namespace: tt.namespace,
+			}))
+
+			if tt.code != 0 {
+				assert.Equal(t, tt.code, connect.CodeOf(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "app", resp.Msg.GetRevision())
+			require.Len(t, reviewer.checked, 1)
+			assert.Equal(t, authorizationv1.ResourceAttributes{
+				Namespace: "team-a",
+				Name:      "app",
+				Verb:      "get",
+				Group:     "seaway.ctx.sh",
+				Version:   "v1beta1",
+				Resource:  "environments",
+			}, reviewer.checked[0])
+		})
+	}
+}
+
+func TestAuthInterceptor_ClientStream(t *testing.T) {
+	reviewer := &fakeReviewer{
+		token:   "secret",
+		allowed: map[string]bool{"team-a/get": true},
+	}
+
+	sclient := newAuthTestClient(t, reviewer, "secret")
+	stream := sclient.Upload(context.Background())
+	_ = stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_ArtifactInfo{
+			ArtifactInfo: &seawayv1beta1.ArtifactInfo{
+				Name:      "app",
+				Namespace: "team-a",
+			},
+		},
+	})
+
+	_, err := stream.CloseAndReceive()
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	require.Len(t, reviewer.checked, 1)
+	assert.Equal(t, "update", reviewer.checked[0].Verb)
+}
+
+func TestAuthInterceptor_Blobs(t *testing.T) {
+	reviewer := &fakeReviewer{
+		token:   "secret",
+		allowed: map[string]bool{"team-a/get": true},
+	}
+	sclient := newAuthTestClient(t, reviewer, "secret")
+
+	_, err := sclient.MissingBlobs(context.Background(), connect.NewRequest(&seawayv1beta1.MissingBlobsRequest{
+		Name:      "app",
+		Namespace: "team-a",
+	}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// The environment is required even though the blobs are shared.
+	_, err = sclient.MissingBlobs(context.Background(), connect.NewRequest(&seawayv1beta1.MissingBlobsRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	stream := sclient.UploadBlob(context.Background())
+	_ = stream.Send(&seawayv1beta1.UploadBlobRequest{
+		Payload: &seawayv1beta1.UploadBlobRequest_BlobInfo{
+			BlobInfo: &seawayv1beta1.BlobInfo{
+				Name:      "app",
+				Namespace: "team-a",
+			},
+		},
+	})
+
+	_, err = stream.CloseAndReceive()
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	require.Len(t, reviewer.checked, 2)
+	for _, attrs := range reviewer.checked {
+		assert.Equal(t, "update", attrs.Verb)
+		assert.Equal(t, "team-a", attrs.Namespace)
+		assert.Equal(t, "app", attrs.Name)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		token  string
+		ok     bool
+	}{
+		{"Bearer abc", "abc", true},
+		{"bearer abc", "abc", true},
+		{"Basic abc", "", false},
+		{"Bearer ", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set("Authorization", tt.header)
+		token, ok := bearerToken(h)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.token, token, tt.header)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seaway
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"connectrpc.com/connect"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/source"
+	seawaystorage "ctx.sh/seaway/pkg/storage"
+	"ctx.sh/seaway/pkg/util"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MissingBlobsConcurrency is the number of blobs that are checked at the same time.
+const MissingBlobsConcurrency = 16
+
+// MissingBlobs returns the digests from the request that aren't in the blob store.
+func (s *Service) MissingBlobs(ctx context.Context, req *connect.Request[seawayv1beta1.MissingBlobsRequest]) (*connect.Response[seawayv1beta1.MissingBlobsResponse], error) {
+	logger := log.FromContext(ctx)
+
+	for _, digest := range req.Msg.GetDigests() {
+		if !source.ValidDigest(digest) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %q", source.ErrInvalidDigest, digest))
+		}
+	}
+
+	storage, backend, err := s.storageBackend(ctx, req.Msg.GetConfig())
+	if err != nil {
+		logger.Error(err, "failed to resolve the environment config", "config", req.Msg.GetConfig())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	digests := req.Msg.GetDigests()
+	exists := make([]bool, len(digests))
+	errs := make([]error, len(digests))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, MissingBlobsConcurrency)
+	for i, digest := range digests {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, digest string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			exists[i], errs[i] = backend.Exists(ctx, util.BlobKey(storage.Prefix, digest))
+		}(i, digest)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	missing := make([]string, 0)
+	for i, digest := range digests {
+		if !exists[i] {
+			missing = append(missing, digest)
+		}
+	}
+
+	logger.V(4).Info("checked blobs", "total", len(digests), "missing", len(missing))
+	return connect.NewResponse(&seawayv1beta1.MissingBlobsResponse{
+		Digests: missing,
+	}), nil
+}
+
+// UploadBlob stores a single blob.  The contents are hashed as they are streamed.
+// Since blobs are shared, a new blob is written to an upload key and only moved to
+// its shared key once the digest matches, and a blob that is already stored is never
+// written again.
+func (s *Service) UploadBlob(ctx context.Context, stream *connect.ClientStream[seawayv1beta1.UploadBlobRequest]) (*connect.Response[seawayv1beta1.UploadBlobResponse], error) {
+	logger := log.FromContext(ctx)
+
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, streamError(err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expected blob info, got nothing"))
+	}
+
+	info := stream.Msg().GetBlobInfo()
+	if info == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected blob info, got %T", stream.Msg().GetPayload()))
+	}
+
+	if !source.ValidDigest(info.GetSha256()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %q", source.ErrInvalidDigest, info.GetSha256()))
+	}
+
+	storage, backend, err := s.storageBackend(ctx, info.GetConfig())
+	if err != nil {
+		logger.Error(err, "failed to resolve the environment config", "config", info.GetConfig())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := seawaystorage.EnsureBucket(ctx, backend); err != nil {
+		logger.Error(err, "failed to ensure bucket exists")
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.Join(errors.New("unable to find or create bucket"), err))
+	}
+
+	key := util.BlobKey(storage.Prefix, info.GetSha256())
+	exists, err := backend.Exists(ctx, key)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	var store *Store
+	var staged string
+	if !exists {
+		id, err := newUploadID()
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		staged = util.BlobUploadKey(storage.Prefix, info.GetSha256(), id)
+		store = NewStore(backend)
+		go store.Put(ctx, staged)
+	}
+
+	h := sha256.New()
+	var size int64
+	for stream.Receive() {
+		chunk, ok := stream.Msg().GetPayload().(*seawayv1beta1.UploadBlobRequest_Chunk)
+		if !ok {
+			discard(ctx, backend, store, staged)
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected chunk, got %T", stream.Msg().GetPayload()))
+		}
+
+		h.Write(chunk.Chunk)
+		size += int64(len(chunk.Chunk))
+		if store == nil {
+			continue
+		}
+
+		if err := store.Write(chunk.Chunk); err != nil {
+			discard(ctx, backend, store, staged)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := receiveError(ctx, stream.Err()); err != nil {
+		discard(ctx, backend, store, staged)
+		return nil, streamError(err)
+	}
+
+	if store != nil {
+		store.Close()
+		store.Wait()
+		if err := store.Err(); err != nil {
+			return nil, connect.NewError(connect.CodeUnknown, err)
+		}
+	}
+
+	if digest := hex.EncodeToString(h.Sum(nil)); digest != info.GetSha256() {
+		discard(ctx, backend, store, staged)
+		return nil, connect.NewError(connect.CodeDataLoss, fmt.Errorf("digest mismatch: expected %s, got %s", info.GetSha256(), digest))
+	}
+
+	if store != nil {
+		if err := promote(ctx, backend, staged, key, size); err != nil {
+			logger.Error(err, "unable to store blob", "key", key)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	logger.V(4).Info("blob uploaded", "key", key, "size", size, "existing", exists)
+	return connect.NewResponse(&seawayv1beta1.UploadBlobResponse{
+		Sha256: info.GetSha256(),
+		Size:   size,
+	}), nil
+}
+
+// promote copies a verified object from its upload key to its final key and removes
+// the upload key.  Blobs and archives are immutable, so an object that a concurrent
+// upload stored in the meantime is kept as is.
+func promote(ctx context.Context, backend seawaystorage.Storage, from, to string, size int64) error {
+	exists, err := backend.Exists(ctx, to)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		r, err := backend.Get(ctx, from)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+
+		if _, err := backend.Put(ctx, to, r, size); err != nil {
+			return err
+		}
+	}
+
+	if err := backend.Delete(ctx, from); err != nil {
+		log.FromContext(ctx).Error(err, "unable to remove the upload", "key", from)
+	}
+
+	return nil
+}
+
+// discard aborts the write of the object to its upload key and removes anything that
+// was written.  It's a no-op when the object was already stored.
+func discard(ctx context.Context, backend seawaystorage.Storage, store *Store, key string) {
+	if store == nil {
+		return
+	}
+
+	store.CloseWithError(errors.New("upload aborted"))
+	store.Wait()
+
+	if err := backend.Delete(ctx, key); err != nil && !isNotFound(err) {
+		log.FromContext(ctx).Error(err, "unable to remove the upload", "key", key)
+	}
+}
+
+// newUploadID returns a random ID that keeps concurrent uploads of the same object apart.
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// Assemble builds the build context archive for the environment from the blobs in
+// the manifest and stores it under the revision, which is the digest of the manifest.
+// Like uploads, the archive is written to an upload key and only moved into place
+// once it's complete, and a revision that's already stored is never replaced.
+func (s *Service) Assemble(ctx context.Context, req *connect.Request[seawayv1beta1.AssembleRequest]) (*connect.Response[seawayv1beta1.AssembleResponse], error) {
+	logger := log.FromContext(ctx)
+
+	files := req.Msg.GetFiles()
+	if len(files) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the manifest is empty"))
+	}
+
+	if err := source.Validate(files); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := source.ValidateManifest(files, s.options.ArchiveLimits, req.Msg.GetDockerfile()); err != nil {
+		return nil, validationError(err)
+	}
+
+	storage, backend, err := s.storageBackend(ctx, req.Msg.GetConfig())
+	if err != nil {
+		logger.Error(err, "failed to resolve the environment config", "config", req.Msg.GetConfig())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	store := NewStore(backend)
+	revision := source.Digest(files)
+	key := util.ArchiveKey(storage.Prefix, req.Msg.GetNamespace(), req.Msg.GetName(), revision)
+	staged := util.ArchiveUploadKey(storage.Prefix, req.Msg.GetNamespace(), req.Msg.GetName(), id)
+	go store.Put(ctx, staged)
+
+	err = source.WriteArchive(storeWriter{store}, files, func(digest string) (io.ReadCloser, error) {
+		return backend.Get(ctx, util.BlobKey(storage.Prefix, digest))
+	})
+	if err != nil {
+		discard(ctx, backend, store, staged)
+
+		if isNotFound(err) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		if errors.Is(err, source.ErrSizeMismatch) {
+			return nil, connect.NewError(connect.CodeDataLoss, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	store.Close()
+	store.Wait()
+	if err := store.Err(); err != nil {
+		return nil, connect.NewError(connect.CodeUnknown, err)
+	}
+
+	uploaded := store.Info()
+	if err := s.options.ArchiveLimits.CheckSize(uploaded.Size); err != nil {
+		discard(ctx, backend, store, staged)
+		return nil, validationError(err)
+	}
+
+	if err := promote(ctx, backend, staged, key, uploaded.Size); err != nil {
+		logger.Error(err, "unable to store archive", "key", key)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	logger.Info("archive assembled", "key", key, "size", uploaded.Size, "files", len(files), "revision", revision)
+
+	return connect.NewResponse(&seawayv1beta1.AssembleResponse{
+		Key:  key,
+		Etag: revision,
+		Size: uploaded.Size,
+	}), nil
+}
+
+// storeWriter adapts the store to an io.Writer.
+type storeWriter struct {
+	*Store
+}
+
+func (w storeWriter) Write(p []byte) (int, error) {
+	if err := w.Store.Write(p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, seawaystorage.ErrNotFound)
+}
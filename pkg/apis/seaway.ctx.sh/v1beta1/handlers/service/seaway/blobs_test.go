@@ -0,0 +1,190 @@
+package seaway
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256sum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func uploadBlob(ctx context.Context, sclient seawayv1beta1connect.SeawayServiceClient, digest string, chunks ...[]byte) (*connect.Response[seawayv1beta1.UploadBlobResponse], error) {
+	stream := sclient.UploadBlob(ctx)
+	_ = stream.Send(&seawayv1beta1.UploadBlobRequest{
+		Payload: &seawayv1beta1.UploadBlobRequest_BlobInfo{BlobInfo: &seawayv1beta1.BlobInfo{
+			Name:      "app",
+			Namespace: "default",
+			Sha256:    digest,
+		}},
+	})
+	for _, chunk := range chunks {
+		_ = stream.Send(&seawayv1beta1.UploadBlobRequest{
+			Payload: &seawayv1beta1.UploadBlobRequest_Chunk{Chunk: chunk},
+		})
+	}
+	return stream.CloseAndReceive()
+}
+
+// readArchive returns the contents of the files in a gzipped tar archive.
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+
+	return files
+}
+
+func TestBlobs_Filesystem(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	contents := map[string][]byte{
+		"Dockerfile": []byte("FROM scratch\n"),
+		"main.go":    []byte("package main\n"),
+		"copy.go":    []byte("package main\n"),
+	}
+
+	files := make([]*seawayv1beta1.FileEntry, 0, len(contents))
+	for name, data := range contents {
+		files = append(files, &seawayv1beta1.FileEntry{
+			Path:   name,
+			Sha256: sha256sum(data),
+			Size:   int64(len(data)),
+			Mode:   source.DefaultMode,
+		})
+	}
+
+	missing, err := sclient.MissingBlobs(ctx, connect.NewRequest(&seawayv1beta1.MissingBlobsRequest{
+		Name:      "app",
+		Namespace: "default",
+		Digests:   source.Digests(files),
+	}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{sha256sum(contents["Dockerfile"]), sha256sum(contents["main.go"])}, missing.Msg.GetDigests())
+
+	for _, data := range [][]byte{contents["Dockerfile"], contents["main.go"]} {
+		// Chunked to make sure the whole stream is stored.
+		resp, err := uploadBlob(ctx, sclient, sha256sum(data), data[:4], data[4:])
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), resp.Msg.GetSize())
+	}
+
+	missing, err = sclient.MissingBlobs(ctx, connect.NewRequest(&seawayv1beta1.MissingBlobsRequest{
+		Name:      "app",
+		Namespace: "default",
+		Digests:   source.Digests(files),
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, missing.Msg.GetDigests())
+
+	assembled, err := sclient.Assemble(ctx, connect.NewRequest(&seawayv1beta1.AssembleRequest{
+		Name:       "app",
+		Namespace:  "default",
+		Files:      files,
+		Dockerfile: "Dockerfile",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, source.Digest(files), assembled.Msg.GetEtag())
+
+	stored, err := os.ReadFile(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", source.Digest(files)+".tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"Dockerfile": "FROM scratch\n",
+		"main.go":    "package main\n",
+		"copy.go":    "package main\n",
+	}, readArchive(t, stored))
+	assert.Empty(t, stagedArchives(t, root))
+
+	// A manifest that can't be assembled doesn't touch the stored revision.
+	require.NoError(t, os.Remove(filepath.Join(root, "seaway", "artifacts", "blobs", "sha256", sha256sum(contents["main.go"]))))
+	_, err = sclient.Assemble(ctx, connect.NewRequest(&seawayv1beta1.AssembleRequest{
+		Name:       "app",
+		Namespace:  "default",
+		Files:      files,
+		Dockerfile: "Dockerfile",
+	}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	unchanged, err := os.ReadFile(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", source.Digest(files)+".tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, stored, unchanged)
+	assert.Empty(t, stagedArchives(t, root))
+
+	// The upload keys are removed once the blobs are stored.
+	uploads, err := os.ReadDir(filepath.Join(root, "seaway", "artifacts", "blobs", "uploads"))
+	if !os.IsNotExist(err) {
+		require.NoError(t, err)
+	}
+	assert.Empty(t, uploads)
+}
+
+func TestUploadBlob_Mismatch(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := []byte("package main\n")
+	digest := sha256sum(data)
+	blob := filepath.Join(root, "seaway", "artifacts", "blobs", "sha256", digest)
+
+	// A new blob that doesn't match its digest is never stored.
+	_, err := uploadBlob(ctx, sclient, digest, []byte("package evil\n"))
+	assert.Equal(t, connect.CodeDataLoss, connect.CodeOf(err))
+	_, err = os.Stat(blob)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = uploadBlob(ctx, sclient, digest, data)
+	require.NoError(t, err)
+
+	// A bad upload of a blob that is already stored doesn't replace or remove it.
+	_, err = uploadBlob(ctx, sclient, digest, []byte("package evil\n"))
+	assert.Equal(t, connect.CodeDataLoss, connect.CodeOf(err))
+
+	stored, err := os.ReadFile(blob)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// Uploading it again is accepted and leaves it unchanged.
+	resp, err := uploadBlob(ctx, sclient, digest, data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), resp.Msg.GetSize())
+
+	uploads, err := os.ReadDir(filepath.Join(root, "seaway", "artifacts", "blobs", "uploads"))
+	if !os.IsNotExist(err) {
+		require.NoError(t, err)
+	}
+	assert.Empty(t, uploads)
+}
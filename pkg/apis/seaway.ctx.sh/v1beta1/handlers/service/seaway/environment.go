@@ -3,11 +3,11 @@ package seaway
 import (
 	"context"
 	"errors"
-	"time"
 
 	"connectrpc.com/connect"
 	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
 	seawayv1beta1connect "ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/tracker"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -24,33 +24,32 @@ func (s *Service) Environment(ctx context.Context, req *connect.Request[seawayv1
 
 	track := s.options.Tracker
 	// Return the last environment status.  If it doesn't exist return notfound.
-	info, ok := track.Get(req.Msg.Namespace, req.Msg.Name)
+	info, ok := track.Get(ctx, req.Msg.Namespace, req.Msg.Name)
 	if !ok {
 		return nil, connect.NewError(connect.CodeNotFound, errors.New("resource not found"))
 	}
 
-	return connect.NewResponse(&seawayv1beta1.EnvironmentResponse{
-		Stage:  info.Stage,
-		Status: info.Status,
-	}), nil
+	return connect.NewResponse(response(info)), nil
 }
 
-// EnvironmentTracker streams changes in the environment deployment status back to a
-// client.
+// EnvironmentTracker streams every transition of the environment back to the client
+// in order.  Clients resume after a reconnect by passing the last sequence they
+// received.  If an etag is given, the stream only ends once that revision has been
+// deployed or has failed, so a stale state from a previous revision doesn't end it
+// early.  The stream ends with not found if the environment doesn't exist or is
+// deleted while it's being tracked.
 func (s *Service) EnvironmentTracker(ctx context.Context, req *connect.Request[seawayv1beta1.EnvironmentRequest], stream *connect.ServerStream[seawayv1beta1.EnvironmentResponse]) error {
 	logger := log.FromContext(ctx,
 		"service", seawayv1beta1connect.SeawayServiceName,
 		"path", seawayv1beta1connect.SeawayServiceEnvironmentTrackerProcedure,
 	)
 
-	stopCh := make(chan struct{})
-	// TODO: This is a temporary solution.  We need to subscribe to a channel
-	// 	or some sort of queue and send only when a new event comes in.  Currently
-	// 	we may miss changes in the stages that happen in less time than the interval.
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
+	sub, err := s.options.Tracker.Subscribe(ctx, req.Msg.Namespace, req.Msg.Name, req.Msg.After)
+	if err != nil {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	defer sub.Close()
 
-	// Loop until done.
 	for {
 		select {
 		case <-ctx.Done():
@@ -58,60 +57,36 @@ func (s *Service) EnvironmentTracker(ctx context.Context, req *connect.Request[s
 			// responsibility to reconnect.
 			logger.V(1).Info("Context cancelled")
 			return connect.NewError(connect.CodeCanceled, errors.New("shutting down"))
-		case <-stopCh:
-			logger.V(1).Info("Request finished")
-			return nil
-		case <-ticker.C:
-			logger.V(6).Info("Sending")
-			err := s.send(ctx, stopCh, req, stream)
-			if err != nil {
-				return connect.NewError(connect.CodeInternal, err)
+		case info, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), tracker.ErrDeleted) {
+					return connect.NewError(connect.CodeNotFound, sub.Err())
+				}
+				// We fell too far behind.  The client can resume from the last
+				// sequence it received.
+				return connect.NewError(connect.CodeResourceExhausted, tracker.ErrFellBehind)
 			}
-		}
-	}
-}
-
-func (s *Service) send(
-	ctx context.Context,
-	stopCh chan struct{},
-	req *connect.Request[seawayv1beta1.EnvironmentRequest],
-	stream *connect.ServerStream[seawayv1beta1.EnvironmentResponse],
-) error {
-	logger := log.FromContext(ctx, "req", req)
-	track := s.options.Tracker
-	name := req.Msg.Name
-	namespace := req.Msg.Namespace
 
-	info, ok := track.Get(namespace, name)
-	if !ok {
-		// If we can't find this, we probably haven't reconciled it yet.  This assumption
-		// is partially valid.  May want to expand later.
-		return nil
-	}
-	logger.V(6).Info("info", "info", info)
-
-	changed := track.HasChanged(namespace, name)
-	deployed := track.IsDeployed(namespace, name)
+			logger.V(6).Info("sending", "info", info)
+			if err := stream.Send(response(info)); err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
 
-	// TODO: clean up the initializing logic here.  it's not very intuitive what
-	//   this is doing and why it is needed.  For reference there was a state at the
-	//   beginning of a deployment stream where we would duplicate sending a status
-	//   update when in initializing.
-	if (changed || deployed) && info.Status != "initializing" {
-		logger.V(6).Info("sending", "info", info)
-		err := stream.Send(&seawayv1beta1.EnvironmentResponse{
-			Stage:  info.Stage,
-			Status: info.Status,
-		})
-		if err != nil {
-			return err
+			if info.IsDone() && (req.Msg.Etag == "" || req.Msg.Etag == info.Revision) {
+				logger.V(1).Info("Request finished")
+				return nil
+			}
 		}
 	}
+}
 
-	if info.Status == "deployed" || info.Status == "failed" {
-		logger.V(6).Info("stopping", "info", info)
-		close(stopCh)
+func response(info tracker.TrackingInfo) *seawayv1beta1.EnvironmentResponse {
+	return &seawayv1beta1.EnvironmentResponse{
+		Sequence: info.Sequence,
+		Revision: info.Revision,
+		Stage:    info.Stage,
+		Status:   info.Status,
+		Log:      info.Log,
+		Resync:   info.Resync,
 	}
-
-	return nil
 }
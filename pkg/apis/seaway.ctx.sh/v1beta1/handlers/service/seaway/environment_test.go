@@ -0,0 +1,76 @@
+package seaway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/tracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTrackerTestClient(t *testing.T, track *tracker.Tracker) seawayv1beta1connect.SeawayServiceClient {
+	t.Helper()
+
+	svc := &Service{options: &Options{Tracker: track}}
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(svc)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return seawayv1beta1connect.NewSeawayServiceClient(server.Client(), server.URL)
+}
+
+func TestEnvironmentTracker_NotFound(t *testing.T) {
+	ctx := context.Background()
+	track := tracker.New(nil)
+	sclient := newTrackerTestClient(t, track)
+
+	// Environments that don't exist are reported instead of waiting for them.
+	stream, err := sclient.EnvironmentTracker(ctx, connect.NewRequest(&seawayv1beta1.EnvironmentRequest{
+		Namespace: "default",
+		Name:      "missing",
+	}))
+	require.NoError(t, err)
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(stream.Err()))
+	require.NoError(t, stream.Close())
+
+	track.Track(ctx, &v1beta1.Environment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       v1beta1.EnvironmentSpec{Revision: "fake"},
+		Status: v1beta1.EnvironmentStatus{
+			Sequence:         1,
+			Stage:            v1beta1.EnvironmentStageBuildImage,
+			ExpectedRevision: "fake",
+		},
+	})
+
+	stream, err = sclient.EnvironmentTracker(ctx, connect.NewRequest(&seawayv1beta1.EnvironmentRequest{
+		Namespace: "default",
+		Name:      "app",
+	}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	require.True(t, stream.Receive())
+	assert.Equal(t, uint64(1), stream.Msg().GetSequence())
+
+	// Deleting the environment ends the stream as not found rather than as a
+	// subscriber that fell behind, so the client doesn't wait for it to come back.
+	track.Forget("default", "app")
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(stream.Err()))
+}
@@ -0,0 +1,505 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seaway
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/source"
+	seawaystorage "ctx.sh/seaway/pkg/storage"
+	"ctx.sh/seaway/pkg/util"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// MinPartSize is the smallest part the object store accepts for all but the last part.
+	MinPartSize = 5 * 1024 * 1024
+	// MaxPartSize is the largest part the service buffers before storing it.
+	MaxPartSize = 64 * 1024 * 1024
+	// DefaultPartSize is used when the client doesn't ask for a part size.
+	DefaultPartSize = 8 * 1024 * 1024
+	// MaxParts is the largest number of parts in a single upload.
+	MaxParts = 10000
+	// MaxChunkSize is the largest chunk accepted in a single message.
+	MaxChunkSize = 4 * 1024 * 1024
+)
+
+// multipartBackend is a storage backend that supports multipart uploads.
+type multipartBackend interface {
+	seawaystorage.Storage
+	seawaystorage.Multipart
+}
+
+// uploadState is stored next to the archive while a multipart upload is in progress
+// so the upload can be verified and resumed by any replica.
+type uploadState struct {
+	Sha256     string `json:"sha256"`
+	Size       int64  `json:"size"`
+	PartSize   int64  `json:"partSize"`
+	Dockerfile string `json:"dockerfile,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+	// Key is the upload key that the parts are joined into.  The archive is only
+	// moved to its revision key once it has been verified.
+	Key string `json:"key"`
+}
+
+// revision returns the revision the archive is stored under.  Uploads from clients
+// that don't send the manifest digest use the digest of the archive.
+func (u uploadState) revision() string {
+	if u.Revision != "" {
+		return u.Revision
+	}
+
+	return u.Sha256
+}
+
+// part returns the expected offset and size of the part.
+func (u uploadState) part(number int32) (int64, int64) {
+	offset := int64(number-1) * u.PartSize
+	return offset, min(u.PartSize, u.Size-offset)
+}
+
+// parts returns the number of parts in the upload.
+func (u uploadState) parts() int32 {
+	return int32((u.Size + u.PartSize - 1) / u.PartSize) //nolint:gosec
+}
+
+// partSize returns the part size to use for an archive.  The requested size is kept
+// within the limits of the object store and grown if the archive would otherwise
+// need too many parts.
+func partSize(requested, size int64) int64 {
+	if requested <= 0 {
+		requested = DefaultPartSize
+	}
+
+	ps := max(MinPartSize, min(requested, MaxPartSize))
+	if needed := (size + MaxParts - 1) / MaxParts; needed > ps {
+		ps = needed
+	}
+
+	return ps
+}
+
+// CreateUpload starts a multipart upload of the build context archive.  If an upload
+// id is given the upload is resumed and the parts that were already stored are
+// returned so the client only sends the rest.
+func (s *Service) CreateUpload(ctx context.Context, req *connect.Request[seawayv1beta1.CreateUploadRequest]) (*connect.Response[seawayv1beta1.CreateUploadResponse], error) {
+	logger := log.FromContext(ctx)
+	msg := req.Msg
+
+	if !source.ValidDigest(msg.GetSha256()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %q", source.ErrInvalidDigest, msg.GetSha256()))
+	}
+
+	if msg.GetRevision() != "" && !source.ValidDigest(msg.GetRevision()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %q", source.ErrInvalidDigest, msg.GetRevision()))
+	}
+
+	if msg.GetSize() <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("the archive is empty"))
+	}
+
+	if err := s.options.ArchiveLimits.CheckSize(msg.GetSize()); err != nil {
+		return nil, validationError(err)
+	}
+
+	storage, store, err := s.uploadStore(ctx, msg.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	state := uploadState{
+		Sha256:     msg.GetSha256(),
+		Size:       msg.GetSize(),
+		PartSize:   partSize(msg.GetPartSize(), msg.GetSize()),
+		Dockerfile: msg.GetDockerfile(),
+		Revision:   msg.GetRevision(),
+	}
+
+	if msg.GetUploadId() != "" {
+		return s.resumeUpload(ctx, store, storage, msg, state)
+	}
+
+	// The upload key has to be known before the upload is started, so it has its own
+	// ID rather than the one the backend returns.
+	staged, err := newUploadID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	state.Key = util.ArchiveUploadKey(storage.Prefix, msg.GetNamespace(), msg.GetName(), staged)
+
+	id, err := store.NewUpload(ctx, state.Key)
+	if err != nil {
+		logger.Error(err, "unable to start the upload", "key", state.Key)
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	stateKey := util.UploadKey(storage.Prefix, msg.GetNamespace(), msg.GetName(), id)
+	if _, err := store.Put(ctx, stateKey, bytes.NewReader(data), int64(len(data))); err != nil {
+		_ = store.AbortUpload(ctx, state.Key, id)
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	logger.V(4).Info("upload started", "key", state.Key, "id", id, "size", state.Size, "partSize", state.PartSize)
+	return connect.NewResponse(&seawayv1beta1.CreateUploadResponse{
+		UploadId: id,
+		PartSize: state.PartSize,
+		Parts:    []*seawayv1beta1.UploadPart{},
+	}), nil
+}
+
+func (s *Service) resumeUpload(
+	ctx context.Context,
+	store multipartBackend,
+	storage v1beta1.EnvironmentConfigStorage,
+	msg *seawayv1beta1.CreateUploadRequest,
+	requested uploadState,
+) (*connect.Response[seawayv1beta1.CreateUploadResponse], error) {
+	id := msg.GetUploadId()
+	state, err := s.uploadState(ctx, store, storage, msg.GetNamespace(), msg.GetName(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Sha256 != requested.Sha256 || state.Size != requested.Size || state.revision() != requested.revision() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("upload %s is for a different archive", id))
+	}
+
+	stored, err := store.Parts(ctx, state.Key, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("upload %s not found", id))
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	parts := make([]*seawayv1beta1.UploadPart, 0, len(stored))
+	for _, p := range stored {
+		offset, size := state.part(int32(p.Number)) //nolint:gosec
+		// Only parts that are complete are reported, anything else is sent again.
+		if p.Size != size {
+			continue
+		}
+		parts = append(parts, &seawayv1beta1.UploadPart{
+			Number: int32(p.Number), //nolint:gosec
+			Offset: offset,
+			Size:   p.Size,
+		})
+	}
+
+	log.FromContext(ctx).V(4).Info("upload resumed", "key", state.Key, "id", id, "parts", len(parts))
+	return connect.NewResponse(&seawayv1beta1.CreateUploadResponse{
+		UploadId: id,
+		PartSize: state.PartSize,
+		Parts:    parts,
+	}), nil
+}
+
+// UploadPart stores a single part of a multipart upload.  Every chunk must start
+// where the previous one ended and is checked against its own digest.  The part is
+// only stored once the digest of the whole part matches, so a broken stream never
+// leaves a partial part behind.
+func (s *Service) UploadPart(ctx context.Context, stream *connect.ClientStream[seawayv1beta1.UploadPartRequest]) (*connect.Response[seawayv1beta1.UploadPartResponse], error) {
+	logger := log.FromContext(ctx)
+
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, streamError(err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expected part info, got nothing"))
+	}
+
+	info := stream.Msg().GetPartInfo()
+	if info == nil || info.GetPart() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected part info, got %T", stream.Msg().GetPayload()))
+	}
+
+	part := info.GetPart()
+	if !source.ValidDigest(part.GetSha256()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%w: %q", source.ErrInvalidDigest, part.GetSha256()))
+	}
+
+	storage, store, err := s.uploadStore(ctx, info.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.uploadState(ctx, store, storage, info.GetNamespace(), info.GetName(), info.GetUploadId())
+	if err != nil {
+		return nil, err
+	}
+
+	if part.GetNumber() < 1 || part.GetNumber() > state.parts() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("part %d is out of range", part.GetNumber()))
+	}
+
+	offset, size := state.part(part.GetNumber())
+	if part.GetOffset() != offset || part.GetSize() != size {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("part %d must start at %d and be %d bytes", part.GetNumber(), offset, size))
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, size))
+	h := sha256.New()
+	for stream.Receive() {
+		chunk := stream.Msg().GetChunk()
+		if chunk == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected chunk, got %T", stream.Msg().GetPayload()))
+		}
+
+		data := chunk.GetData()
+		switch {
+		case len(data) > MaxChunkSize:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("chunk is larger than %d bytes", MaxChunkSize))
+		case chunk.GetOffset() != offset+int64(buf.Len()):
+			return nil, connect.NewError(connect.CodeInvalidArgument,
+				fmt.Errorf("chunk at offset %d, expected %d", chunk.GetOffset(), offset+int64(buf.Len())))
+		case int64(buf.Len()+len(data)) > size:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("part %d is larger than %d bytes", part.GetNumber(), size))
+		}
+
+		sum := sha256.Sum256(data)
+		if digest := hex.EncodeToString(sum[:]); digest != chunk.GetSha256() {
+			return nil, connect.NewError(connect.CodeDataLoss, fmt.Errorf("chunk at offset %d digest mismatch: expected %s, got %s", chunk.GetOffset(), chunk.GetSha256(), digest))
+		}
+
+		h.Write(data)
+		buf.Write(data)
+	}
+
+	if err := stream.Err(); err != nil {
+		return nil, streamError(err)
+	}
+
+	if int64(buf.Len()) != size {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("part %d is incomplete: got %d of %d bytes", part.GetNumber(), buf.Len(), size))
+	}
+
+	if digest := hex.EncodeToString(h.Sum(nil)); digest != part.GetSha256() {
+		return nil, connect.NewError(connect.CodeDataLoss, fmt.Errorf("part %d digest mismatch: expected %s, got %s", part.GetNumber(), part.GetSha256(), digest))
+	}
+
+	_, err = store.PutPart(ctx, state.Key, info.GetUploadId(), int(part.GetNumber()), buf.Bytes(), part.GetSha256())
+	if err != nil {
+		if isNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("upload %s not found", info.GetUploadId()))
+		}
+		logger.Error(err, "unable to store part", "key", state.Key, "part", part.GetNumber())
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	logger.V(6).Info("part uploaded", "key", state.Key, "part", part.GetNumber(), "size", size)
+	return connect.NewResponse(&seawayv1beta1.UploadPartResponse{
+		Part: part,
+	}), nil
+}
+
+// CompleteUpload joins the parts into the archive at its upload key and reads it back
+// to verify the digest the client sent when the upload was started.  The archive is
+// only moved to its revision key once it has been verified, a rejected archive is
+// removed from the upload key.
+func (s *Service) CompleteUpload(ctx context.Context, req *connect.Request[seawayv1beta1.CompleteUploadRequest]) (*connect.Response[seawayv1beta1.UploadResponse], error) {
+	logger := log.FromContext(ctx)
+	msg := req.Msg
+
+	storage, store, err := s.uploadStore(ctx, msg.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	id := msg.GetUploadId()
+	state, err := s.uploadState(ctx, store, storage, msg.GetNamespace(), msg.GetName(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := store.Parts(ctx, state.Key, id)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("upload %s not found", id))
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	if err := checkParts(state, parts); err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	if _, err := store.CompleteUpload(ctx, state.Key, id, parts); err != nil {
+		logger.Error(err, "unable to complete the upload", "key", state.Key, "id", id)
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	if err := verifyObject(ctx, store, state.Key, state, s.options.ArchiveLimits); err != nil {
+		if rerr := store.Delete(ctx, state.Key); rerr != nil {
+			logger.Error(rerr, "unable to remove rejected archive", "key", state.Key)
+		}
+		return nil, err
+	}
+
+	key := util.ArchiveKey(storage.Prefix, msg.GetNamespace(), msg.GetName(), state.revision())
+	if err := promote(ctx, store, state.Key, key, state.Size); err != nil {
+		logger.Error(err, "unable to store archive", "key", key)
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := store.Delete(ctx, util.UploadKey(storage.Prefix, msg.GetNamespace(), msg.GetName(), id)); err != nil {
+		logger.Error(err, "unable to remove the upload state", "id", id)
+	}
+
+	logger.Info("file uploaded", "key", key, "size", state.Size, "parts", len(parts))
+	return connect.NewResponse(&seawayv1beta1.UploadResponse{
+		Key:     key,
+		Size:    state.Size,
+		Etag:    state.revision(),
+		Message: "ok",
+	}), nil
+}
+
+// uploadStore resolves the storage settings from the config and returns the backend
+// for them.  Backends without multipart support are reported as unimplemented so the
+// client falls back to a single stream.
+func (s *Service) uploadStore(ctx context.Context, config string) (v1beta1.EnvironmentConfigStorage, multipartBackend, error) {
+	storage, backend, err := s.storageBackend(ctx, config)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to resolve the environment config", "config", config)
+		return storage, nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	store, ok := backend.(multipartBackend)
+	if !ok {
+		return storage, nil, connect.NewError(connect.CodeUnimplemented,
+			fmt.Errorf("the %s storage backend %w", storage.Type, seawaystorage.ErrNotSupported))
+	}
+
+	if err := seawaystorage.EnsureBucket(ctx, backend); err != nil {
+		return storage, nil, connect.NewError(connect.CodeFailedPrecondition, errors.Join(errors.New("unable to find or create bucket"), err))
+	}
+
+	return storage, store, nil
+}
+
+// uploadState loads the state that was stored when the upload was started.
+func (s *Service) uploadState(
+	ctx context.Context,
+	store seawaystorage.Storage,
+	storage v1beta1.EnvironmentConfigStorage,
+	namespace, name, id string,
+) (uploadState, error) {
+	var state uploadState
+
+	if id == "" {
+		return state, connect.NewError(connect.CodeInvalidArgument, errors.New("upload id is required"))
+	}
+
+	rc, err := store.Get(ctx, util.UploadKey(storage.Prefix, namespace, name, id))
+	if err != nil {
+		if isNotFound(err) {
+			return state, connect.NewError(connect.CodeNotFound, fmt.Errorf("upload %s not found", id))
+		}
+		return state, connect.NewError(connect.CodeUnavailable, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	if err := json.NewDecoder(rc).Decode(&state); err != nil {
+		return state, connect.NewError(connect.CodeInternal, fmt.Errorf("unable to read the upload state: %w", err))
+	}
+
+	if state.PartSize <= 0 {
+		return state, connect.NewError(connect.CodeInternal, fmt.Errorf("upload %s has an invalid part size", id))
+	}
+
+	return state, nil
+}
+
+// checkParts makes sure that every part has been stored with the expected size.
+func checkParts(state uploadState, parts []seawaystorage.Part) error {
+	if int32(len(parts)) != state.parts() { //nolint:gosec
+		return fmt.Errorf("expected %d parts, got %d", state.parts(), len(parts))
+	}
+
+	for i, p := range parts {
+		if p.Number != i+1 {
+			return fmt.Errorf("part %d is missing", i+1)
+		}
+
+		if _, size := state.part(int32(p.Number)); p.Size != size { //nolint:gosec
+			return fmt.Errorf("part %d is %d bytes, expected %d", p.Number, p.Size, size)
+		}
+	}
+
+	return nil
+}
+
+// verifyObject reads the joined archive back to check its size and digest against the
+// upload state and to validate it as a build context.  Both are done in a single read.
+func verifyObject(ctx context.Context, store seawaystorage.Storage, key string, state uploadState, limits source.Limits) error {
+	rc, err := store.Get(ctx, key)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	h := sha256.New()
+	var n byteCounter
+	tee := io.TeeReader(rc, io.MultiWriter(h, &n))
+
+	verr := source.ValidateArchive(tee, limits, state.Dockerfile)
+	// The validation stops at the end of the archive, the digest covers every byte.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	if int64(n) != state.Size {
+		return connect.NewError(connect.CodeDataLoss, fmt.Errorf("size mismatch: expected %d, got %d", state.Size, n))
+	}
+
+	if digest := hex.EncodeToString(h.Sum(nil)); digest != state.Sha256 {
+		return connect.NewError(connect.CodeDataLoss, fmt.Errorf("digest mismatch: expected %s, got %s", state.Sha256, digest))
+	}
+
+	if verr != nil {
+		return validationError(verr)
+	}
+
+	return nil
+}
+
+// byteCounter counts the bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,352 @@
+package seaway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/lister"
+	"ctx.sh/seaway/pkg/mock"
+	"ctx.sh/seaway/pkg/source"
+	"ctx.sh/seaway/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int64
+		size      int64
+		expected  int64
+	}{
+		{"default", 0, 1024, DefaultPartSize},
+		{"too small", 1024, 1024, MinPartSize},
+		{"too large", 1024 * 1024 * 1024, 1024, MaxPartSize},
+		{"too many parts", MinPartSize, MaxParts*MaxPartSize + MaxParts, MaxPartSize + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, partSize(tt.requested, tt.size))
+		})
+	}
+}
+
+func TestUploadState(t *testing.T) {
+	state := uploadState{Size: 25, PartSize: 10}
+	assert.Equal(t, int32(3), state.parts())
+
+	offset, size := state.part(1)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, int64(10), size)
+
+	offset, size = state.part(3)
+	assert.Equal(t, int64(20), offset)
+	assert.Equal(t, int64(5), size)
+}
+
+func TestCheckParts(t *testing.T) {
+	state := uploadState{Size: 25, PartSize: 10}
+
+	assert.NoError(t, checkParts(state, []storage.Part{
+		{Number: 1, Size: 10},
+		{Number: 2, Size: 10},
+		{Number: 3, Size: 5},
+	}))
+
+	assert.ErrorContains(t, checkParts(state, []storage.Part{
+		{Number: 1, Size: 10},
+		{Number: 3, Size: 5},
+	}), "expected 3 parts")
+
+	assert.ErrorContains(t, checkParts(state, []storage.Part{
+		{Number: 1, Size: 10},
+		{Number: 3, Size: 5},
+		{Number: 4, Size: 5},
+	}), "part 2 is missing")
+
+	assert.ErrorContains(t, checkParts(state, []storage.Part{
+		{Number: 1, Size: 10},
+		{Number: 2, Size: 9},
+		{Number: 3, Size: 5},
+	}), "part 2 is 9 bytes")
+}
+
+func newFilesystemTestClient(t *testing.T, root string) seawayv1beta1connect.SeawayServiceClient {
+	t.Helper()
+
+	sclient, _ := newFilesystemTestServer(t, root)
+	return sclient
+}
+
+// newFilesystemTestServer also returns the number of requests being handled so tests
+// can wait for handlers to return after the client goes away.
+func newFilesystemTestServer(t *testing.T, root string) (seawayv1beta1connect.SeawayServiceClient, *atomic.Int32) {
+	t.Helper()
+
+	svc := &Service{
+		options: &Options{
+			StorageType:   v1beta1.EnvironmentStorageTypeFilesystem,
+			StoragePath:   root,
+			StorageBucket: "seaway",
+			StoragePrefix: "artifacts",
+			ArchiveLimits: source.Limits{MaxSize: 1 << 20, MaxEntries: 10},
+		},
+		configs: lister.NewEnvironmentConfigLister(mock.NewClient(), v1beta1.DefaultControllerNamespace),
+	}
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(svc)
+
+	var inflight atomic.Int32
+	mux := http.NewServeMux()
+	mux.Handle(path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inflight.Add(1)
+		defer inflight.Add(-1)
+		handler.ServeHTTP(w, r)
+	}))
+
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return seawayv1beta1connect.NewSeawayServiceClient(server.Client(), server.URL), &inflight
+}
+
+func TestMultipartUpload_Filesystem(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	created, err := sclient.CreateUpload(ctx, connect.NewRequest(&seawayv1beta1.CreateUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		Sha256:    digest,
+		Size:      int64(len(data)),
+	}))
+	require.NoError(t, err)
+
+	stream := sclient.UploadPart(ctx)
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_PartInfo{
+			PartInfo: &seawayv1beta1.PartInfo{
+				Name:      "app",
+				Namespace: "default",
+				UploadId:  created.Msg.GetUploadId(),
+				Part: &seawayv1beta1.UploadPart{
+					Number: 1,
+					Size:   int64(len(data)),
+					Sha256: digest,
+				},
+			},
+		},
+	}))
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_Chunk{
+			Chunk: &seawayv1beta1.Chunk{
+				Data:   data,
+				Sha256: digest,
+			},
+		},
+	}))
+	_, err = stream.CloseAndReceive()
+	require.NoError(t, err)
+
+	resp, err := sclient.CompleteUpload(ctx, connect.NewRequest(&seawayv1beta1.CompleteUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		UploadId:  created.Msg.GetUploadId(),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, digest, resp.Msg.GetEtag())
+
+	stored, err := os.ReadFile(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", digest+".tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// The upload state is removed once the upload is complete.
+	_, err = os.Stat(filepath.Join(root, "seaway", "artifacts", "uploads", "default", "app", created.Msg.GetUploadId()+".json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUpload_Revision(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	revision := strings.Repeat("ab", 32)
+	archive := filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", revision+".tar.gz")
+
+	// The manifest digest is the revision of a single stream upload.
+	resp, err := uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Sha256:     digest,
+		Revision:   revision,
+		Dockerfile: "Dockerfile",
+	}, data)
+	require.NoError(t, err)
+	assert.Equal(t, revision, resp.Msg.GetEtag())
+	_, err = os.Stat(archive)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(archive))
+
+	// And of a multipart upload.
+	created, err := sclient.CreateUpload(ctx, connect.NewRequest(&seawayv1beta1.CreateUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		Sha256:    digest,
+		Revision:  revision,
+		Size:      int64(len(data)),
+	}))
+	require.NoError(t, err)
+
+	// Resuming the upload for another revision is rejected.
+	_, err = sclient.CreateUpload(ctx, connect.NewRequest(&seawayv1beta1.CreateUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		Sha256:    digest,
+		Size:      int64(len(data)),
+		UploadId:  created.Msg.GetUploadId(),
+	}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	stream := sclient.UploadPart(ctx)
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_PartInfo{
+			PartInfo: &seawayv1beta1.PartInfo{
+				Name:      "app",
+				Namespace: "default",
+				UploadId:  created.Msg.GetUploadId(),
+				Part: &seawayv1beta1.UploadPart{
+					Number: 1,
+					Size:   int64(len(data)),
+					Sha256: digest,
+				},
+			},
+		},
+	}))
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_Chunk{
+			Chunk: &seawayv1beta1.Chunk{
+				Data:   data,
+				Sha256: digest,
+			},
+		},
+	}))
+	_, err = stream.CloseAndReceive()
+	require.NoError(t, err)
+
+	completed, err := sclient.CompleteUpload(ctx, connect.NewRequest(&seawayv1beta1.CompleteUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		UploadId:  created.Msg.GetUploadId(),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, revision, completed.Msg.GetEtag())
+
+	stored, err := os.ReadFile(archive)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+}
+
+// multipartUpload uploads the data as a single part.
+func multipartUpload(ctx context.Context, sclient seawayv1beta1connect.SeawayServiceClient, revision string, data []byte) (*connect.Response[seawayv1beta1.UploadResponse], error) {
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	created, err := sclient.CreateUpload(ctx, connect.NewRequest(&seawayv1beta1.CreateUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		Sha256:    digest,
+		Revision:  revision,
+		Size:      int64(len(data)),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	stream := sclient.UploadPart(ctx)
+	_ = stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_PartInfo{
+			PartInfo: &seawayv1beta1.PartInfo{
+				Name:      "app",
+				Namespace: "default",
+				UploadId:  created.Msg.GetUploadId(),
+				Part: &seawayv1beta1.UploadPart{
+					Number: 1,
+					Size:   int64(len(data)),
+					Sha256: digest,
+				},
+			},
+		},
+	})
+	_ = stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_Chunk{
+			Chunk: &seawayv1beta1.Chunk{
+				Data:   data,
+				Sha256: digest,
+			},
+		},
+	})
+	if _, err := stream.CloseAndReceive(); err != nil {
+		return nil, err
+	}
+
+	return sclient.CompleteUpload(ctx, connect.NewRequest(&seawayv1beta1.CompleteUploadRequest{
+		Name:      "app",
+		Namespace: "default",
+		UploadId:  created.Msg.GetUploadId(),
+	}))
+}
+
+func TestCompleteUpload_Immutable(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	revision := strings.Repeat("ab", 32)
+	archive := filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", revision+".tar.gz")
+
+	resp, err := multipartUpload(ctx, sclient, revision, data)
+	require.NoError(t, err)
+	assert.Equal(t, revision, resp.Msg.GetEtag())
+
+	// An archive that is rejected after it has been joined doesn't replace or remove
+	// the one that was stored for the revision.
+	_, err = multipartUpload(ctx, sclient, revision, []byte("not really a tarball"))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	stored, err := os.ReadFile(archive)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+	assert.Empty(t, stagedArchives(t, root))
+
+	// A rejected archive for a new revision is never stored.
+	other := strings.Repeat("cd", 32)
+	_, err = multipartUpload(ctx, sclient, other, []byte("not really a tarball"))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = os.Stat(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", other+".tar.gz"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Empty(t, stagedArchives(t, root))
+}
@@ -1,7 +1,15 @@
 package seaway
 
 import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/lister"
+	"ctx.sh/seaway/pkg/source"
+	"ctx.sh/seaway/pkg/storage"
 	"ctx.sh/seaway/pkg/tracker"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -9,28 +17,81 @@ import (
 
 // +kubebuilder:skip
 type Options struct {
-	Client        client.Client
-	Namespace     string
-	StorageURL    string
-	StorageBucket string
-	StoragePrefix string
-	StorageRegion string
-	Tracker       *tracker.Tracker
+	Client                client.Client
+	Namespace             string
+	DefaultConfig         string
+	StorageType           v1beta1.EnvironmentStorageType
+	StorageURL            string
+	StorageBucket         string
+	StoragePrefix         string
+	StorageRegion         string
+	StorageForcePathStyle bool
+	StoragePath           string
+	Tracker               *tracker.Tracker
+	// Reviewer checks callers against the cluster's authentication and RBAC.  When
+	// nil, calls are accepted without any checks.
+	Reviewer Reviewer
+	// ArchiveLimits bounds the build contexts that are accepted.  Zero values disable
+	// the limits.
+	ArchiveLimits source.Limits
 }
 
 // +kubebuilder:skip
 type Service struct {
 	options *Options
+	configs *lister.EnvironmentConfigLister
 	// TODO: Mutex for uploading artifacts...
 }
 
 func RegisterWithWebhook(wh webhook.Server, opts *Options) error {
 	service := &Service{
 		options: opts,
+		configs: lister.NewEnvironmentConfigLister(opts.Client, v1beta1.DefaultControllerNamespace),
+	}
+
+	handlerOpts := make([]connect.HandlerOption, 0)
+	if opts.Reviewer != nil {
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(NewAuthInterceptor(opts.Reviewer)))
 	}
 
-	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(service)
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(service, handlerOpts...)
 	wh.Register(path, handler)
 
 	return nil
 }
+
+// storageBackend resolves the storage settings from the named environment config and
+// returns the backend for them.  The backend uses the credentials from the operator's
+// environment.
+func (s *Service) storageBackend(ctx context.Context, name string) (v1beta1.EnvironmentConfigStorage, storage.Storage, error) {
+	config, err := s.storageConfig(ctx, name)
+	if err != nil {
+		return config, nil, err
+	}
+
+	backend, err := storage.New(config, nil)
+	return config, backend, err
+}
+
+// storageConfig returns the storage settings from the named environment config.  If
+// the config can't be found, the storage options the service was started with are used.
+func (s *Service) storageConfig(ctx context.Context, name string) (v1beta1.EnvironmentConfigStorage, error) {
+	config, err := s.configs.Resolve(ctx, name, s.options.DefaultConfig)
+	if err != nil {
+		return v1beta1.EnvironmentConfigStorage{}, err
+	}
+
+	if config != nil {
+		return config.Spec.Storage, nil
+	}
+
+	return v1beta1.EnvironmentConfigStorage{
+		Type:           s.options.StorageType,
+		Bucket:         s.options.StorageBucket,
+		Endpoint:       s.options.StorageURL,
+		ForcePathStyle: s.options.StorageForcePathStyle,
+		Path:           s.options.StoragePath,
+		Prefix:         s.options.StoragePrefix,
+		Region:         s.options.StorageRegion,
+	}, nil
+}
@@ -3,34 +3,27 @@ package seaway
 import (
 	"context"
 	"io"
-	"net/url"
 	"sync"
 
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
+	"ctx.sh/seaway/pkg/storage"
 )
 
-type StoreOptions struct {
-	Endpoint string
-	Region   string
-	Bucket   string
-}
-
+// Store streams an object to the storage backend as it's written.
 type Store struct {
-	options *StoreOptions
+	backend storage.Storage
 	reader  *io.PipeReader
 	writer  *io.PipeWriter
-	info    minio.UploadInfo
+	info    storage.ObjectInfo
 	err     error
 	done    chan struct{}
 	putOnce sync.Once
 	sync.Mutex
 }
 
-func NewStore(options *StoreOptions) *Store {
+func NewStore(backend storage.Storage) *Store {
 	r, w := io.Pipe()
 	return &Store{
-		options: options,
+		backend: backend,
 		writer:  w,
 		reader:  r,
 		done:    make(chan struct{}),
@@ -41,15 +34,10 @@ func (s *Store) Put(ctx context.Context, key string) {
 	defer close(s.done)
 
 	s.putOnce.Do(func() {
-		store, err := s.getStore()
-		if err != nil {
-			s.err = err
-			return
-		}
-
-		bucket := s.options.Bucket
-		info, err := store.PutObject(ctx, bucket, key, s.reader, -1, minio.PutObjectOptions{})
+		info, err := s.backend.Put(ctx, key, s.reader, -1)
 		if err != nil {
+			// Unblock any writers waiting on the pipe.
+			_ = s.reader.CloseWithError(err)
 			s.err = err
 			return
 		}
@@ -58,27 +46,7 @@ func (s *Store) Put(ctx context.Context, key string) {
 	})
 }
 
-func (s *Store) EnsureBucket(ctx context.Context, bucket string) error {
-	store, err := s.getStore()
-	if err != nil {
-		return err
-	}
-
-	ok, err := store.BucketExists(ctx, bucket)
-	if err != nil {
-		return err
-	}
-
-	if ok {
-		return nil
-	}
-
-	return store.MakeBucket(ctx, bucket, minio.MakeBucketOptions{
-		Region: s.options.Region,
-	})
-}
-
-func (s *Store) Info() minio.UploadInfo {
+func (s *Store) Info() storage.ObjectInfo {
 	return s.info
 }
 
@@ -110,20 +78,3 @@ func (s *Store) Close() {
 func (s *Store) CloseWithError(err error) {
 	_ = s.writer.CloseWithError(err)
 }
-
-func (s *Store) getStore() (*minio.Client, error) {
-	endpoint, err := url.Parse(s.options.Endpoint)
-	if err != nil {
-		return nil, err
-	}
-
-	return minio.New(endpoint.Host, &minio.Options{
-		Creds: credentials.NewChainCredentials([]credentials.Provider{
-			// Requires MINIO_ACCESS_KEY and MINIO_SECRET_KEY.
-			&credentials.EnvMinio{},
-			// Requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
-			&credentials.EnvAWS{},
-		}),
-		Region: s.options.Region,
-	})
-}
@@ -2,11 +2,19 @@ package seaway
 
 import (
 	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
+	"io"
+	"strings"
 
 	"connectrpc.com/connect"
 	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/source"
+	seawaystorage "ctx.sh/seaway/pkg/storage"
 	"ctx.sh/seaway/pkg/util"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -19,64 +27,189 @@ func (s *Service) Upload(ctx context.Context, stream *connect.ClientStream[seawa
 	// 	actually start handling initially.  The right solution is to probably fail
 	// 	here and make the client re-send the request.
 
-	// TODO: require info for the first request.  Then stream the chunks.
-	//   This allows validation and we use the put to block.
 	logger := log.FromContext(ctx)
 	logger.V(4).Info("Received a file upload request")
 
-	store := NewStore(&StoreOptions{
-		Region:   s.options.StorageRegion,
-		Bucket:   s.options.StorageBucket,
-		Endpoint: s.options.StorageURL,
-	})
+	// The artifact info is required as the first message so we know which config
+	// to pull the storage settings from before any of the chunks are streamed.
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, streamError(err)
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expected artifact info, got nothing"))
+	}
+
+	info := stream.Msg().GetArtifactInfo()
+	if info == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected artifact info, got %T", stream.Msg().GetPayload()))
+	}
+
+	// The archive is stored under its revision, so it has to be known up front.
+	// Current clients send the manifest digest as the revision, older clients only
+	// send the digest or the md5 etag of the archive.
+	revision, err := uploadRevision(info)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
-	err := store.EnsureBucket(ctx, s.options.StorageBucket)
+	storage, backend, err := s.storageBackend(ctx, info.GetConfig())
+	if err != nil {
+		logger.Error(err, "failed to resolve the environment config", "config", info.GetConfig())
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	err = seawaystorage.EnsureBucket(ctx, backend)
 	if err != nil {
 		logger.Error(err, "failed to ensure bucket exists")
 		werr := errors.New("unable to find or create bucket")
 		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.Join(werr, err))
 	}
 
-	for {
-		if more := stream.Receive(); !more {
-			store.Close()
-			break
+	// Archives are never overwritten.  If the revision is already stored the stream
+	// is only checked against the digests, otherwise it's written to an upload key
+	// and only moved into place once it has been verified.
+	key := util.ArchiveKey(storage.Prefix, info.GetNamespace(), info.GetName(), revision)
+	exists, err := backend.Exists(ctx, key)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+
+	var store *Store
+	var staged string
+	if !exists {
+		id, err := newUploadID()
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
 		}
 
+		staged = util.ArchiveUploadKey(storage.Prefix, info.GetNamespace(), info.GetName(), id)
+		store = NewStore(backend)
+		// Start the streaming put operation.
+		go store.Put(ctx, staged)
+	}
+
+	md5sum := md5.New() //nolint:gosec
+	sha256sum := sha256.New()
+	h := io.MultiWriter(md5sum, sha256sum)
+	var size int64
+
+	for stream.Receive() {
 		switch payload := stream.Msg().GetPayload().(type) {
-		case *seawayv1beta1.UploadRequest_ArtifactInfo:
-			name := payload.ArtifactInfo.Name
-			namespace := payload.ArtifactInfo.Namespace
-			key := util.ArchiveKey(s.options.StoragePrefix, name, namespace)
-			// Start the streaming put operation.
-			go store.Put(ctx, key)
 		case *seawayv1beta1.UploadRequest_Chunk:
 			logger.V(6).Info("received chunk", "size", len(payload.Chunk))
+			size += int64(len(payload.Chunk))
+			if err := s.options.ArchiveLimits.CheckSize(size); err != nil {
+				discard(ctx, backend, store, staged)
+				return nil, validationError(err)
+			}
+
+			h.Write(payload.Chunk)
+			if store == nil {
+				continue
+			}
+
 			err := store.Write(payload.Chunk)
 			if err != nil {
+				discard(ctx, backend, store, staged)
 				return nil, connect.NewError(connect.CodeInternal, err)
 			}
 		default:
-			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected info or chunk, got %T", payload))
+			discard(ctx, backend, store, staged)
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("expected chunk, got %T", payload))
+		}
+	}
+	// The write is only finished once the whole stream has been received.  If the
+	// client went away the write is aborted so a truncated archive is never stored.
+	if err := receiveError(ctx, stream.Err()); err != nil {
+		discard(ctx, backend, store, staged)
+		return nil, streamError(err)
+	}
+
+	if store != nil {
+		logger.V(4).Info("waiting for upload to complete")
+		store.Close()
+		store.Wait()
+
+		if err := store.Err(); err != nil {
+			return nil, connect.NewError(connect.CodeUnknown, err)
 		}
 	}
-	if err := stream.Err(); err != nil {
-		return nil, connect.NewError(connect.CodeUnknown, err)
+
+	if err := checkDigests(info, md5sum, sha256sum); err != nil {
+		discard(ctx, backend, store, staged)
+		return nil, connect.NewError(connect.CodeDataLoss, err)
 	}
 
-	logger.V(4).Info("waiting for upload to complete")
-	store.Wait()
+	if store != nil {
+		if err := s.validateObject(ctx, backend, staged, info.GetDockerfile()); err != nil {
+			discard(ctx, backend, store, staged)
+			return nil, err
+		}
 
-	if err := store.Err(); err != nil {
-		return nil, connect.NewError(connect.CodeUnknown, err)
+		if err := promote(ctx, backend, staged, key, size); err != nil {
+			logger.Error(err, "unable to store archive", "key", key)
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 	}
 
-	info := store.Info()
-	logger.Info("file uploaded", "key", info.Key, "size", info.Size)
+	logger.Info("file uploaded", "key", key, "size", size, "existing", exists)
 	return connect.NewResponse(&seawayv1beta1.UploadResponse{
-		Key:     info.Key,
-		Size:    info.Size,
-		Etag:    info.ETag,
+		Key:     key,
+		Size:    size,
+		Etag:    revision,
 		Message: "ok",
 	}), nil
 }
+
+// uploadRevision returns the revision the archive is stored under: the manifest digest
+// if the client sent one, then the sha256 digest of the archive, and otherwise the md5
+// etag.
+func uploadRevision(info *seawayv1beta1.ArtifactInfo) (string, error) {
+	if info.GetEtag() != "" && !validEtag(info.GetEtag()) {
+		return "", fmt.Errorf("invalid etag %q: expected the md5 sum of the archive", info.GetEtag())
+	}
+
+	if info.GetSha256() != "" && !source.ValidDigest(info.GetSha256()) {
+		return "", fmt.Errorf("%w: %q", source.ErrInvalidDigest, info.GetSha256())
+	}
+
+	if info.GetRevision() != "" {
+		if !source.ValidDigest(info.GetRevision()) {
+			return "", fmt.Errorf("%w: %q", source.ErrInvalidDigest, info.GetRevision())
+		}
+		if info.GetSha256() == "" && info.GetEtag() == "" {
+			return "", errors.New("expected the sha256 digest or md5 etag of the archive")
+		}
+		return info.GetRevision(), nil
+	}
+
+	if info.GetSha256() != "" {
+		return info.GetSha256(), nil
+	}
+
+	if info.GetEtag() == "" {
+		return "", errors.New("expected the sha256 digest or md5 etag of the archive")
+	}
+
+	return info.GetEtag(), nil
+}
+
+// checkDigests compares the digests of the received archive with the ones sent by
+// the client.
+func checkDigests(info *seawayv1beta1.ArtifactInfo, md5sum, sha256sum hash.Hash) error {
+	if etag := hex.EncodeToString(md5sum.Sum(nil)); info.GetEtag() != "" && etag != info.GetEtag() {
+		return fmt.Errorf("etag mismatch: expected %s, got %s", info.GetEtag(), etag)
+	}
+
+	if digest := hex.EncodeToString(sha256sum.Sum(nil)); info.GetSha256() != "" && digest != info.GetSha256() {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", info.GetSha256(), digest)
+	}
+
+	return nil
+}
+
+// validEtag returns true if the etag is a hex encoded md5 sum.
+func validEtag(etag string) bool {
+	b, err := hex.DecodeString(etag)
+	return err == nil && len(b) == md5.Size && etag == strings.ToLower(etag)
+}
@@ -0,0 +1,311 @@
+package seaway
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArchive returns a gzipped tar archive with the files.  Contents starting with
+// "->" are written as symlinks to the rest of the content.
+func testArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		content := files[name]
+		hdr := &tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(content))}
+		if target, ok := strings.CutPrefix(content, "->"); ok {
+			hdr = &tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target, Mode: 0o777}
+			content = ""
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
+
+func upload(ctx context.Context, sclient seawayv1beta1connect.SeawayServiceClient, etag, dockerfile string, data []byte) (*connect.Response[seawayv1beta1.UploadResponse], error) {
+	return uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Etag:       etag,
+		Dockerfile: dockerfile,
+	}, data)
+}
+
+func uploadInfo(ctx context.Context, sclient seawayv1beta1connect.SeawayServiceClient, info *seawayv1beta1.ArtifactInfo, data []byte) (*connect.Response[seawayv1beta1.UploadResponse], error) {
+	stream := sclient.Upload(ctx)
+	_ = stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_ArtifactInfo{ArtifactInfo: info},
+	})
+	_ = stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_Chunk{Chunk: data},
+	})
+	return stream.CloseAndReceive()
+}
+
+// stagedArchives returns the archives that were left behind at an upload key.
+func stagedArchives(t *testing.T, root string) []string {
+	t.Helper()
+
+	matches, err := filepath.Glob(filepath.Join(root, "seaway", "artifacts", "uploads", "default", "app", "*.tar.gz"))
+	require.NoError(t, err)
+	return matches
+}
+
+func md5sum(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec
+	return hex.EncodeToString(sum[:])
+}
+
+func TestValidEtag(t *testing.T) {
+	assert.True(t, validEtag("5d41402abc4b2a76b9719d911017c592"))
+	assert.False(t, validEtag(""))
+	assert.False(t, validEtag("5D41402ABC4B2A76B9719D911017C592"))
+	assert.False(t, validEtag("../../5d41402abc4b2a76b9719d911017"))
+	assert.False(t, validEtag("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"))
+}
+
+func TestUpload_Filesystem(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	etag := md5sum(data)
+
+	resp, err := upload(ctx, sclient, etag, "Dockerfile", data)
+	require.NoError(t, err)
+	assert.Equal(t, etag, resp.Msg.GetEtag())
+
+	stored, err := os.ReadFile(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", etag+".tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// The archive is keyed by the etag, so it has to be sent.
+	_, err = upload(ctx, sclient, "", "Dockerfile", data)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = upload(ctx, sclient, "5d41402abc4b2a76b9719d911017c592", "Dockerfile", data)
+	assert.Equal(t, connect.CodeDataLoss, connect.CodeOf(err))
+}
+
+func TestUpload_Sha256(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	// The sha256 digest takes precedence over the etag as the revision.
+	resp, err := uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Etag:       md5sum(data),
+		Sha256:     digest,
+		Dockerfile: "Dockerfile",
+	}, data)
+	require.NoError(t, err)
+	assert.Equal(t, digest, resp.Msg.GetEtag())
+
+	_, err = os.Stat(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", digest+".tar.gz"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		info *seawayv1beta1.ArtifactInfo
+		code connect.Code
+	}{
+		{"without etag", &seawayv1beta1.ArtifactInfo{Sha256: digest}, 0},
+		{"invalid digest", &seawayv1beta1.ArtifactInfo{Sha256: "../" + digest[3:]}, connect.CodeInvalidArgument},
+		{"invalid etag", &seawayv1beta1.ArtifactInfo{Sha256: digest, Etag: "abc"}, connect.CodeInvalidArgument},
+		{"digest mismatch", &seawayv1beta1.ArtifactInfo{Sha256: strings.Repeat("0", 64)}, connect.CodeDataLoss},
+		{"etag mismatch", &seawayv1beta1.ArtifactInfo{Sha256: digest, Etag: "5d41402abc4b2a76b9719d911017c592"}, connect.CodeDataLoss},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.info.Name = "app"
+			tt.info.Namespace = "default"
+			tt.info.Dockerfile = "Dockerfile"
+			_, err := uploadInfo(ctx, sclient, tt.info, data)
+			if tt.code == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.code, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestUpload_Validation(t *testing.T) {
+	many := make(map[string]string)
+	for i := range 11 {
+		many[strings.Repeat("f", i+1)] = ""
+	}
+
+	tests := []struct {
+		name       string
+		data       []byte
+		dockerfile string
+		code       connect.Code
+	}{
+		{"not gzip", []byte("not really a tarball"), "", connect.CodeInvalidArgument},
+		{"traversal", testArchive(t, map[string]string{"../escape": "x"}), "", connect.CodeInvalidArgument},
+		{"absolute", testArchive(t, map[string]string{"/etc/passwd": "x"}), "", connect.CodeInvalidArgument},
+		{"symlink", testArchive(t, map[string]string{"link": "->../../etc"}), "", connect.CodeInvalidArgument},
+		{"too many entries", testArchive(t, many), "", connect.CodeResourceExhausted},
+		{"too large", bytes.Repeat([]byte{0}, 2<<20), "", connect.CodeResourceExhausted},
+		{"missing dockerfile", testArchive(t, map[string]string{"main.go": "package main"}), "Dockerfile", connect.CodeFailedPrecondition},
+		{"custom dockerfile", testArchive(t, map[string]string{"build/Dockerfile": "FROM scratch"}), "./build/Dockerfile", 0},
+		{"no dockerfile needed", testArchive(t, map[string]string{"main.go": "package main"}), "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			sclient := newFilesystemTestClient(t, root)
+			etag := md5sum(tt.data)
+
+			_, err := upload(context.Background(), sclient, etag, tt.dockerfile, tt.data)
+			if tt.code == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, tt.code, connect.CodeOf(err), err)
+			// Rejected archives are never stored.
+			_, err = os.Stat(filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", etag+".tar.gz"))
+			assert.ErrorIs(t, err, os.ErrNotExist)
+			assert.Empty(t, stagedArchives(t, root))
+		})
+	}
+}
+
+func TestUpload_Immutable(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	sclient := newFilesystemTestClient(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	revision := strings.Repeat("ab", 32)
+	archive := filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", revision+".tar.gz")
+
+	_, err := uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Etag:       md5sum(data),
+		Revision:   revision,
+		Dockerfile: "Dockerfile",
+	}, data)
+	require.NoError(t, err)
+
+	// A corrupt upload of the same revision doesn't replace or remove the archive.
+	_, err = uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Etag:       md5sum(data),
+		Revision:   revision,
+		Dockerfile: "Dockerfile",
+	}, []byte("not really a tarball"))
+	assert.Equal(t, connect.CodeDataLoss, connect.CodeOf(err))
+
+	stored, err := os.ReadFile(archive)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	// Uploading it again is accepted and leaves it unchanged.
+	resp, err := uploadInfo(ctx, sclient, &seawayv1beta1.ArtifactInfo{
+		Name:       "app",
+		Namespace:  "default",
+		Etag:       md5sum(data),
+		Revision:   revision,
+		Dockerfile: "Dockerfile",
+	}, data)
+	require.NoError(t, err)
+	assert.Equal(t, revision, resp.Msg.GetEtag())
+
+	stored, err = os.ReadFile(archive)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+	assert.Empty(t, stagedArchives(t, root))
+}
+
+func TestUpload_Interrupted(t *testing.T) {
+	root := t.TempDir()
+	sclient, inflight := newFilesystemTestServer(t, root)
+
+	data := testArchive(t, map[string]string{"Dockerfile": "FROM scratch\n"})
+	revision := strings.Repeat("ab", 32)
+	archive := filepath.Join(root, "seaway", "artifacts", "archives", "default", "app", revision+".tar.gz")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := sclient.Upload(ctx)
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_ArtifactInfo{ArtifactInfo: &seawayv1beta1.ArtifactInfo{
+			Name:       "app",
+			Namespace:  "default",
+			Etag:       md5sum(data),
+			Revision:   revision,
+			Dockerfile: "Dockerfile",
+		}},
+	}))
+	require.NoError(t, stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_Chunk{Chunk: data},
+	}))
+
+	// Wait for the data to be staged, then go away without closing the stream.
+	require.Eventually(t, func() bool {
+		matches, err := filepath.Glob(filepath.Join(root, "seaway", "artifacts", "uploads", "default", "app", ".tmp-*"))
+		if err != nil || len(matches) == 0 {
+			return false
+		}
+		fi, err := os.Stat(matches[0])
+		return err == nil && fi.Size() == int64(len(data))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		return inflight.Load() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err := stream.CloseAndReceive()
+	require.Error(t, err)
+
+	_, err = os.Stat(archive)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, stagedArchives(t, root))
+}
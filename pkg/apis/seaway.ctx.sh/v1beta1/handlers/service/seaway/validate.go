@@ -0,0 +1,60 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seaway
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/source"
+	seawaystorage "ctx.sh/seaway/pkg/storage"
+)
+
+// validationError returns the connect error for a build context that was rejected.
+// Errors that don't come from the checks themselves are storage failures.
+func validationError(err error) *connect.Error {
+	switch {
+	case errors.Is(err, source.ErrTooLarge), errors.Is(err, source.ErrTooManyEntries):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	case errors.Is(err, source.ErrMissingDockerfile):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, source.ErrInvalidArchive),
+		errors.Is(err, source.ErrInvalidPath),
+		errors.Is(err, source.ErrInvalidLink),
+		errors.Is(err, source.ErrUnsupportedEntry):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+}
+
+// validateObject reads the stored archive back and checks that it can be used as a
+// build context.
+func (s *Service) validateObject(ctx context.Context, store seawaystorage.Storage, key, dockerfile string) error {
+	rc, err := store.Get(ctx, key)
+	if err != nil {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	if err := source.ValidateArchive(rc, s.options.ArchiveLimits, dockerfile); err != nil {
+		return validationError(err)
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@
 package v1beta1
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -24,14 +25,16 @@ const (
 )
 
 var (
-	DefaultExcludes = []string{
-		"vendor/*",
-		".venv/*",
-		"node_modules/*",
-		".git/*",
-		".idea/*",
-		".vscode/*",
-		".terraform/*",
+	// DefaultIgnores are gitignore patterns applied before the ignore files, so they
+	// can be negated with a pattern like !vendor/.
+	DefaultIgnores = []string{
+		"vendor/",
+		".venv/",
+		"node_modules/",
+		".git/",
+		".idea/",
+		".vscode/",
+		".terraform/",
 	}
 
 	DefaultIncludes = []string{
@@ -61,20 +64,56 @@ func (me *ManifestEnvironmentSpec) UnmarshalYAML(unmarshal func(interface{}) err
 
 // Includes returns a regular expression that matches the files that should be included
 // in the build context.  It is used while when we walk the file system to build the
-// archive that will be uploaded to the object storage.
-func (me *ManifestEnvironmentSpec) Includes() *regexp.Regexp {
-	include := append(DefaultIncludes, me.Build.Include...) //nolint:gocritic
-	r := strings.Join(include, "|")
-	return regexp.MustCompile(r)
+// archive that will be uploaded to the object storage.  An error names the first
+// pattern that isn't a valid regular expression.
+func (me *ManifestEnvironmentSpec) Includes() (*regexp.Regexp, error) {
+	include := append([]string{}, DefaultIncludes...)
+	if me.Build != nil {
+		include = append(include, me.Build.Include...)
+	}
+
+	return compilePatterns("include", include)
 }
 
 // Excludes returns a regular expression that matches the files that should be excluded
 // from the build context.  Like includes, it is used when we walk the file system to
 // build the archive that will be uploaded to the object storage.  Currently, excludes
 // are processed after includes so if there are files in included directories that match
-// the exclude pattern they will be excluded.
-func (me *ManifestEnvironmentSpec) Excludes() *regexp.Regexp {
-	exclude := append(DefaultExcludes, me.Build.Exclude...) //nolint:gocritic
-	r := strings.Join(exclude, "|")
-	return regexp.MustCompile(r)
+// the exclude pattern they will be excluded.  It returns nil if there are no excludes.
+func (me *ManifestEnvironmentSpec) Excludes() (*regexp.Regexp, error) {
+	if me.Build == nil || len(me.Build.Exclude) == 0 {
+		return nil, nil
+	}
+
+	return compilePatterns("exclude", me.Build.Exclude)
+}
+
+// compilePatterns joins the patterns into a single regular expression after checking
+// that each of them compiles on its own.
+func compilePatterns(kind string, patterns []string) (*regexp.Regexp, error) {
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", kind, p, err)
+		}
+	}
+
+	return regexp.Compile("(?:" + strings.Join(patterns, ")|(?:") + ")")
+}
+
+// Dockerfile returns the path of the Dockerfile in the build context.  It's empty for
+// engines that build without one, so the server doesn't require it in the archive.
+func (me *ManifestEnvironmentSpec) Dockerfile() string {
+	if me.Build == nil {
+		return DefaultDockerfile
+	}
+
+	if me.Build.Engine == EnvironmentBuildEngineBuildpacks {
+		return ""
+	}
+
+	if me.Build.Dockerfile != nil && *me.Build.Dockerfile != "" {
+		return *me.Build.Dockerfile
+	}
+
+	return DefaultDockerfile
 }
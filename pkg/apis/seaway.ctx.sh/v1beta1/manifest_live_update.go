@@ -0,0 +1,73 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.  This is used exclusively
+// for the manifest loading process in the client.
+func (ms *ManifestLiveUpdateSync) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type ManifestLiveUpdateSyncDefaulted ManifestLiveUpdateSync
+	var out ManifestLiveUpdateSyncDefaulted
+	if err := unmarshal(&out); err != nil {
+		return err
+	}
+
+	src := path.Clean(strings.TrimPrefix(out.Src, "./"))
+	if out.Src == "" || path.IsAbs(src) || src == ".." || strings.HasPrefix(src, "../") {
+		return fmt.Errorf("live update src must be a path inside of the build context: %q", out.Src)
+	}
+
+	if !path.IsAbs(out.Dest) {
+		return fmt.Errorf("live update dest must be an absolute path: %q", out.Dest)
+	}
+
+	out.Src = src
+	out.Dest = path.Clean(out.Dest)
+	*ms = ManifestLiveUpdateSync(out)
+	return nil
+}
+
+// Target returns the path in the container for the slash separated path relative to
+// the build context, or false if the path isn't covered by the rule.  A src of "."
+// covers the whole build context.
+func (ms ManifestLiveUpdateSync) Target(name string) (string, bool) {
+	switch {
+	case ms.Src == ".":
+		return path.Join(ms.Dest, name), true
+	case name == ms.Src:
+		return ms.Dest, true
+	case strings.HasPrefix(name, ms.Src+"/"):
+		return path.Join(ms.Dest, strings.TrimPrefix(name, ms.Src+"/")), true
+	}
+
+	return "", false
+}
+
+// Target returns the path in the container for the file using the first sync rule
+// that covers it.
+func (lu *ManifestLiveUpdate) Target(name string) (string, bool) {
+	for _, s := range lu.Sync {
+		if dest, ok := s.Target(name); ok {
+			return dest, true
+		}
+	}
+
+	return "", false
+}
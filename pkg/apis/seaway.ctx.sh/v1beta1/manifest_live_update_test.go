@@ -0,0 +1,68 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestManifestLiveUpdateSync_UnmarshalYAML(t *testing.T) {
+	var tests = []struct {
+		name    string
+		input   string
+		src     string
+		dest    string
+		wantErr bool
+	}{
+		{"Valid", "src: ./app/\ndest: /srv/app/", "app", "/srv/app", false},
+		{"Root", "src: .\ndest: /srv", ".", "/srv", false},
+		{"MissingSrc", "dest: /srv", "", "", true},
+		{"AbsoluteSrc", "src: /app\ndest: /srv", "", "", true},
+		{"EscapingSrc", "src: ../app\ndest: /srv", "", "", true},
+		{"RelativeDest", "src: app\ndest: srv", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ms ManifestLiveUpdateSync
+			err := yaml.Unmarshal([]byte(tt.input), &ms)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && (ms.Src != tt.src || ms.Dest != tt.dest) {
+				t.Errorf("UnmarshalYAML() = %s -> %s, want %s -> %s", ms.Src, ms.Dest, tt.src, tt.dest)
+			}
+		})
+	}
+}
+
+func TestManifestLiveUpdate_Target(t *testing.T) {
+	lu := &ManifestLiveUpdate{
+		Sync: []ManifestLiveUpdateSync{
+			{Src: "app", Dest: "/srv/app"},
+			{Src: "wsgi.py", Dest: "/srv/wsgi.py"},
+		},
+	}
+
+	var tests = []struct {
+		name string
+		dest string
+		ok   bool
+	}{
+		{"app/views.py", "/srv/app/views.py", true},
+		{"app", "/srv/app", true},
+		{"wsgi.py", "/srv/wsgi.py", true},
+		{"application.py", "", false},
+		{"requirements.txt", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dest, ok := lu.Target(tt.name)
+			if dest != tt.dest || ok != tt.ok {
+				t.Errorf("Target() = %v, %v, want %v, %v", dest, ok, tt.dest, tt.ok)
+			}
+		})
+	}
+}
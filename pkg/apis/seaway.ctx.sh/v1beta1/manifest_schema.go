@@ -0,0 +1,28 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import _ "embed"
+
+// manifestSchema is generated from the manifest types by `make schemagen`.
+//
+//go:embed manifest.schema.json
+var manifestSchema []byte
+
+// ManifestSchema returns the JSON schema of the manifest.  Editors can use it to
+// complete and check manifest.yaml, and `seactl validate` checks manifests against it.
+func ManifestSchema() []byte {
+	return manifestSchema
+}
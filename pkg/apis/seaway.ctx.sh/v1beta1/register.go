@@ -54,6 +54,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Environment{},
 		&EnvironmentList{},
+		&EnvironmentConfig{},
+		&EnvironmentConfigList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -17,6 +17,7 @@ package v1beta1
 // +kubebuilder:docs-gen:collapse=Apache License
 
 import (
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -112,10 +113,15 @@ type EnvironmentBuild struct {
 	// +optional
 	// +nullable
 	Command []string `json:"command" yaml:"command"`
-	// Image is the build image ot use for the build job.  By default we use kaniko, but
-	// this can be overridden.  However, we don't have a way to override the build command
-	// at this point.
-	// TODO: Override for the build command that is used.
+	// Engine is the image builder used for the build job.  Kaniko and BuildKit build from
+	// the Dockerfile in the build context while buildpacks detect how to build the app
+	// from the source.  Defaults to kaniko.
+	// +optional
+	// +kubebuilder:validation:Enum=kaniko;buildkit;buildpacks
+	Engine EnvironmentBuildEngine `json:"engine" yaml:"engine"`
+	// Image is the build image to use for the build job.  The default depends on the
+	// engine.  For buildpacks this is the builder image which provides the lifecycle
+	// and the buildpacks used to build the app.
 	// +optional
 	Image *string `json:"image" yaml:"image"`
 	// Platform is the platform to build the image for.  This is optional and will default
@@ -133,13 +139,21 @@ type EnvironmentBuild struct {
 	Include []string `json:"include" yaml:"include"`
 	// Exclude is a list of files to exclude from the build context.  This is used to filter
 	// out files that are not needed for the build.  They take the form of a regular expression
-	// and are appended to the default excludes.  Excludes are processed after includes so
-	// if there are files in included directories that match the exclude pattern they will be
-	// excluded.
+	// and are applied after the .dockerignore and .seawayignore files.  Excludes are processed
+	// after includes so if there are files in included directories that match the exclude
+	// pattern they will be excluded.
 	// +optional
 	Exclude []string `json:"exclude" yaml:"exclude"`
 }
 
+type EnvironmentBuildEngine string
+
+const (
+	EnvironmentBuildEngineKaniko     EnvironmentBuildEngine = "kaniko"
+	EnvironmentBuildEngineBuildKit   EnvironmentBuildEngine = "buildkit"
+	EnvironmentBuildEngineBuildpacks EnvironmentBuildEngine = "buildpacks"
+)
+
 type EnvironmentVars struct {
 	// Env is a list of environment variables to set in the app's container.  The environment
 	// variables set here will also be used as substitution variables when the dependencies
@@ -168,8 +182,9 @@ type EnvironmentSpec struct {
 	// Build is the build spec for the environment.
 	// +optional
 	Build *EnvironmentBuild `json:"build" yaml:"build"`
-	// TODO: why did I name this config?  Do I use it?
-	// Config
+	// Config is the name of the EnvironmentConfig in the controller namespace that
+	// provides the registry and storage settings for the environment.  If it is not
+	// set, the controller's default config is used.
 	// +optional
 	Config string `json:"config" yaml:"config"`
 	// Command is the command that will be used to start the deployed application.
@@ -226,6 +241,7 @@ type EnvironmentSpec struct {
 // +k8s:defaulter-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Namespaced,shortName=env,singular=environment
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Stage",type="string",JSONPath=".status.stage"
 // +kubebuilder:printcolumn:name="Last Updated",type="date",JSONPath=".status.lastUpdated"
 // +kubebuilder:printcolumn:name="Expected Revision",type="string",JSONPath=".status.expectedRevision",priority=1
@@ -258,9 +274,59 @@ const (
 	EnvironmentStageFailed            EnvironmentStage = "Server error"
 )
 
+// Condition types that are set on the environment status.  Unlike the stage, these
+// are meant to be consumed by tooling (e.g. kubectl wait --for=condition=Ready).
+const (
+	EnvironmentConditionSourceUploaded = "SourceUploaded"
+	EnvironmentConditionImageBuilt     = "ImageBuilt"
+	EnvironmentConditionDeployed       = "Deployed"
+	EnvironmentConditionReady          = "Ready"
+)
+
+// Machine-readable reasons for the environment conditions.
+const (
+	EnvironmentReasonRevisionReceived   = "RevisionReceived"
+	EnvironmentReasonPending            = "Pending"
+	EnvironmentReasonProgressing        = "Progressing"
+	EnvironmentReasonBuilding           = "Building"
+	EnvironmentReasonBuildUnchanged     = "BuildUnchanged"
+	EnvironmentReasonBuildSkipped       = "BuildSkipped"
+	EnvironmentReasonBuildFailing       = "BuildFailing"
+	EnvironmentReasonBuildFailed        = "BuildFailed"
+	EnvironmentReasonVerifying          = "Verifying"
+	EnvironmentReasonImageVerified      = "ImageVerified"
+	EnvironmentReasonImageNotFound      = "ImageNotFound"
+	EnvironmentReasonDeploying          = "Deploying"
+	EnvironmentReasonWaitingForReplicas = "WaitingForReplicas"
+	EnvironmentReasonDeployFailed       = "DeployFailed"
+	EnvironmentReasonDeployed           = "Deployed"
+	EnvironmentReasonError              = "Error"
+)
+
 type EnvironmentStatus struct {
+	// Stage is the current reconciliation stage.  It's used by the controller to track
+	// progress and is only meant for display, use the conditions for anything else.
 	// +optional
 	Stage EnvironmentStage `json:"stage,omitempty"`
+	// Sequence is incremented every time the stage or the expected revision changes.
+	// Clients tracking the environment use it to resume without missing or repeating
+	// stages.
+	// +optional
+	Sequence int64 `json:"sequence,omitempty"`
+	// ObservedGeneration is the generation of the environment that was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions represent the latest observations of the environment's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// History is a bounded list of the revisions that have been processed, with the
+	// most recent revision first.
+	// +optional
+	History []EnvironmentRevision `json:"history,omitempty"`
 	// +optional
 	ExpectedRevision string `json:"expectedRevision,omitempty"`
 	// +optional
@@ -269,6 +335,53 @@ type EnvironmentStatus struct {
 	LastUpdated metav1.Time `json:"lastUpdated,omitempty"`
 	// +optional
 	Reason string `json:"reason,omitempty"`
+	// BuildLog is the tail of the log of the container that failed the last build,
+	// either the builder or the container that fetches the source.  It's kept in the
+	// status so it's still available after the build job has been cleaned up.
+	// +optional
+	BuildLog string `json:"buildLog,omitempty"`
+}
+
+// EnvironmentRevisionOutcome is the result of processing a revision.
+type EnvironmentRevisionOutcome string
+
+const (
+	EnvironmentRevisionPending      EnvironmentRevisionOutcome = "Pending"
+	EnvironmentRevisionDeployed     EnvironmentRevisionOutcome = "Deployed"
+	EnvironmentRevisionBuildFailed  EnvironmentRevisionOutcome = "BuildFailed"
+	EnvironmentRevisionDeployFailed EnvironmentRevisionOutcome = "DeployFailed"
+	EnvironmentRevisionSuperseded   EnvironmentRevisionOutcome = "Superseded"
+)
+
+// EnvironmentRevision is a record of a revision that was built and deployed.
+type EnvironmentRevision struct {
+	// Revision is the source revision.
+	Revision string `json:"revision"`
+	// Image is the registry reference of the image built for the revision.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Digest is the manifest digest of the image once it's in the registry.  The tag
+	// can be overwritten, so the digest identifies the image that was deployed.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Archive is the storage key of the source archive the revision was built from.
+	// +optional
+	Archive string `json:"archive,omitempty"`
+	// Rollback is set when the revision was deployed from an existing image.
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+	// BuildStarted is the time the build job was created.
+	// +optional
+	BuildStarted *metav1.Time `json:"buildStarted,omitempty"`
+	// BuildCompleted is the time the build job completed.
+	// +optional
+	BuildCompleted *metav1.Time `json:"buildCompleted,omitempty"`
+	// Deployed is the time the revision became available.
+	// +optional
+	Deployed *metav1.Time `json:"deployed,omitempty"`
+	// Outcome is the result of processing the revision.
+	// +optional
+	Outcome EnvironmentRevisionOutcome `json:"outcome,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -279,6 +392,129 @@ type EnvironmentList struct {
 	Items           []Environment `json:"items"`
 }
 
+type EnvironmentConfigRegistry struct {
+	// URL is the url of the registry that build jobs push images to.  The controller
+	// also uses it to verify that the image exists after a build.
+	// +required
+	URL string `json:"url" yaml:"url"`
+	// NodePort is the port that the registry is exposed on for each node.  Deployments
+	// pull their images from localhost using this port.
+	// +required
+	NodePort int32 `json:"nodePort" yaml:"nodePort"`
+}
+
+type EnvironmentConfigStorage struct {
+	// Type is the object storage backend.  S3 covers any S3 compatible service like
+	// minio and localstack.  The filesystem backend stores the archives on a volume
+	// claim shared by the operator and the build jobs.  Defaults to s3.
+	// +optional
+	// +kubebuilder:validation:Enum=s3;gcs;azure;filesystem
+	Type EnvironmentStorageType `json:"type" yaml:"type"`
+	// Bucket is the object storage bucket where the source archives are stored.  For
+	// azure this is the container and for the filesystem backend it's a directory
+	// under the path.
+	// +required
+	Bucket string `json:"bucket" yaml:"bucket"`
+	// Credentials is the name of the secret in the controller namespace containing the
+	// object storage credentials.  By default, this is set to storage-credentials.  S3
+	// uses AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, azure uses AZURE_STORAGE_ACCESS_KEY
+	// and gcs uses a service account key in credentials.json.  The filesystem backend
+	// doesn't use credentials.
+	// +optional
+	Credentials string `json:"credentials" yaml:"credentials"`
+	// Endpoint is the url of the object storage service.  It's required for s3 and
+	// azure, where it's the blob service url of the storage account.  Gcs uses the
+	// public endpoint when it's not set.
+	// +optional
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// ForcePathStyle forces path style bucket addressing.  This is needed for most
+	// S3 compatible services like minio and localstack.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle" yaml:"forcePathStyle"`
+	// Prefix is the key prefix used for the source archives.  By default, this is set
+	// to artifacts.
+	// +optional
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Region is the object storage region.  Only used by s3.
+	// +optional
+	Region string `json:"region" yaml:"region"`
+	// Path is the directory the filesystem backend keeps the archives in.  The volume
+	// claim is mounted here in the build jobs and the operator is expected to mount it
+	// at the same path.  Defaults to /var/lib/seaway.
+	// +optional
+	Path string `json:"path" yaml:"path"`
+	// VolumeClaim is the name of the persistent volume claim that backs the filesystem
+	// storage.  Claims are namespaced and the build jobs run in the controller
+	// namespace, so the claim has to be created there.  It's mounted by both the
+	// operator and the build jobs, so it needs to be ReadWriteMany unless they are
+	// scheduled on the same node.  It's required for the filesystem storage.
+	// +optional
+	VolumeClaim string `json:"volumeClaim" yaml:"volumeClaim"`
+	// Retention is the number of revisions whose source archives are kept for each
+	// environment.  Archives of older revisions are removed once a new revision has
+	// been deployed.  It can't be more than the number of revisions kept in the
+	// environment history.  Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Retention int32 `json:"retention" yaml:"retention"`
+}
+
+// Validate returns an error if the storage settings can't be used.
+func (s EnvironmentConfigStorage) Validate() error {
+	if s.Type == EnvironmentStorageTypeFilesystem && s.VolumeClaim == "" {
+		return fmt.Errorf("the filesystem storage requires a volume claim in the %s namespace", DefaultControllerNamespace)
+	}
+
+	return nil
+}
+
+// RequiresCredentials returns whether the backend reads credentials from the secret.
+func (s EnvironmentConfigStorage) RequiresCredentials() bool {
+	return s.Type != EnvironmentStorageTypeFilesystem
+}
+
+type EnvironmentStorageType string
+
+const (
+	EnvironmentStorageTypeS3         EnvironmentStorageType = "s3"
+	EnvironmentStorageTypeGCS        EnvironmentStorageType = "gcs"
+	EnvironmentStorageTypeAzure      EnvironmentStorageType = "azure"
+	EnvironmentStorageTypeFilesystem EnvironmentStorageType = "filesystem"
+)
+
+// EnvironmentConfigSpec defines the registry and storage that environments
+// referencing the config will use.
+type EnvironmentConfigSpec struct {
+	// Registry is the image registry configuration.
+	// +optional
+	Registry EnvironmentConfigRegistry `json:"registry" yaml:"registry"`
+	// Storage is the object storage configuration for the source archives.
+	// +optional
+	Storage EnvironmentConfigStorage `json:"storage" yaml:"storage"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:defaulter-gen=true
+// +kubebuilder:resource:scope=Namespaced,shortName=econf,singular=environmentconfig
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+type EnvironmentConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec EnvironmentConfigSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type EnvironmentConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvironmentConfig `json:"items"`
+}
+
 type DependencyType string
 
 // ManifestDependency is a dependency configuration that can be applied to
@@ -319,23 +555,86 @@ type ManifestWaitCondition struct {
 	Timeout time.Duration `yaml:"timeout"`
 }
 
+// ManifestLiveUpdate describes how `seactl dev` updates the running app containers in
+// place instead of building a new image.  Changes to files that aren't covered by a
+// sync rule, the Dockerfile, or the rebuild patterns still go through a full rebuild.
+type ManifestLiveUpdate struct {
+	// Sync maps paths in the build context to paths in the app container.
+	// +required
+	Sync []ManifestLiveUpdateSync `yaml:"sync"`
+	// Rebuild is a list of gitignore style patterns for files that always need a
+	// full rebuild, like the list of dependencies.
+	// +optional
+	Rebuild []string `yaml:"rebuild"`
+	// Run is a list of commands that are run in the app container after the files
+	// were copied, like restarting the application server.
+	// +optional
+	Run []ManifestLiveUpdateRun `yaml:"run"`
+}
+
+type ManifestLiveUpdateSync struct {
+	// Src is the path of a file or directory relative to the build context.
+	// +required
+	Src string `yaml:"src"`
+	// Dest is the absolute path in the app container that src is copied to.
+	// +required
+	Dest string `yaml:"dest"`
+}
+
+type ManifestLiveUpdateRun struct {
+	// Command is run in the app container with /bin/sh -c.
+	// +required
+	Command string `yaml:"command"`
+	// Trigger is a list of gitignore style patterns.  If it's set, the command only
+	// runs when one of the copied files matches.
+	// +optional
+	Trigger []string `yaml:"trigger"`
+}
+
 // ManifestEnvironmentSpec is a spec for an environment in the manifest and
 // is used by the client.
 type ManifestEnvironmentSpec struct {
-	Name      string `yaml:"name"`
+	// Name is the name of the environment that is passed to the commands.
+	Name string `yaml:"name"`
+	// Namespace is the namespace the environment is deployed to.  It's created by
+	// sync if it doesn't exist.  Default is `default`.
+	// +optional
 	Namespace string `yaml:"namespace"`
 	// Endpoint is the Seaway API endpoint that the client will use to interact
 	// with the environment.
 	// +optional
-	Endpoint        string               `json:"endpoint" yaml:"endpoint"`
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// CAFile is the path of the certificate authority bundle used to verify the
+	// endpoint.  The system roots are used when it isn't set.
+	// +optional
+	CAFile string `json:"caFile,omitempty" yaml:"caFile"`
+	// InsecureSkipVerify disables the verification of the endpoint's certificate.
+	// Loopback endpoints are port forwards through the kubernetes API and are never
+	// verified.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify"`
+	// LiveUpdate copies changed files into the running app containers while
+	// `seactl dev` is watching the build context.
+	// +optional
+	LiveUpdate *ManifestLiveUpdate `json:"liveUpdate,omitempty" yaml:"liveUpdate"`
+
+	// Dependencies are the kustomizations that are applied before the app is deployed.
+	// +optional
 	Dependencies    []ManifestDependency `yaml:"dependencies"`
 	EnvironmentSpec `yaml:",inline"`
 }
 
 // Manifest is the top level manifest definition for the client.
 type Manifest struct {
-	Name         string                    `yaml:"name"`
-	Version      string                    `yaml:"version"`
-	Description  string                    `yaml:"description"`
+	// Name is the name of the application.  It's used to name the environment and
+	// the resources that are deployed for it.
+	Name string `yaml:"name"`
+	// Version is the version of the application.  Default is `v0.0.0`.
+	// +optional
+	Version string `yaml:"version"`
+	// Description is a short description of the application.
+	// +optional
+	Description string `yaml:"description"`
+	// Environments are the environments that the application can be synced to.
 	Environments []ManifestEnvironmentSpec `yaml:"environments"`
 }
@@ -15,6 +15,9 @@
 package v1beta1
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -36,6 +39,11 @@ func (e *Environment) SetupWebhookWithManager(mgr ctrl.Manager) error {
 func (e *Environment) Validate() (admission.Warnings, error) {
 	warnings := make([]string, 0)
 
+	// The revision is part of the archive key, so it has to be a single path segment.
+	if rev := e.GetRevision(); rev == "." || rev == ".." || strings.ContainsAny(rev, "/\\") {
+		return warnings, fmt.Errorf("invalid revision %q: must be a single path segment", rev)
+	}
+
 	// TODO: validate hostnames for ingress TLS
 	return warnings, nil
 }
@@ -21,6 +21,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -116,6 +117,111 @@ func (in *EnvironmentBuild) DeepCopy() *EnvironmentBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfig) DeepCopyInto(out *EnvironmentConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfig.
+func (in *EnvironmentConfig) DeepCopy() *EnvironmentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfigList) DeepCopyInto(out *EnvironmentConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EnvironmentConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfigList.
+func (in *EnvironmentConfigList) DeepCopy() *EnvironmentConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EnvironmentConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfigRegistry) DeepCopyInto(out *EnvironmentConfigRegistry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfigRegistry.
+func (in *EnvironmentConfigRegistry) DeepCopy() *EnvironmentConfigRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfigRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfigSpec) DeepCopyInto(out *EnvironmentConfigSpec) {
+	*out = *in
+	out.Registry = in.Registry
+	out.Storage = in.Storage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfigSpec.
+func (in *EnvironmentConfigSpec) DeepCopy() *EnvironmentConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfigStorage) DeepCopyInto(out *EnvironmentConfigStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfigStorage.
+func (in *EnvironmentConfigStorage) DeepCopy() *EnvironmentConfigStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfigStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentIngress) DeepCopyInto(out *EnvironmentIngress) {
 	*out = *in
@@ -248,6 +354,33 @@ func (in EnvironmentResources) DeepCopy() EnvironmentResources {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentRevision) DeepCopyInto(out *EnvironmentRevision) {
+	*out = *in
+	if in.BuildStarted != nil {
+		in, out := &in.BuildStarted, &out.BuildStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.BuildCompleted != nil {
+		in, out := &in.BuildCompleted, &out.BuildCompleted
+		*out = (*in).DeepCopy()
+	}
+	if in.Deployed != nil {
+		in, out := &in.Deployed, &out.Deployed
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentRevision.
+func (in *EnvironmentRevision) DeepCopy() *EnvironmentRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentService) DeepCopyInto(out *EnvironmentService) {
 	*out = *in
@@ -360,6 +493,20 @@ func (in *EnvironmentSpec) DeepCopy() *EnvironmentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentStatus) DeepCopyInto(out *EnvironmentStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]EnvironmentRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
 }
 
@@ -447,6 +594,11 @@ func (in *ManifestDependency) DeepCopy() *ManifestDependency {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestEnvironmentSpec) DeepCopyInto(out *ManifestEnvironmentSpec) {
 	*out = *in
+	if in.LiveUpdate != nil {
+		in, out := &in.LiveUpdate, &out.LiveUpdate
+		*out = new(ManifestLiveUpdate)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Dependencies != nil {
 		in, out := &in.Dependencies, &out.Dependencies
 		*out = make([]ManifestDependency, len(*in))
@@ -467,6 +619,73 @@ func (in *ManifestEnvironmentSpec) DeepCopy() *ManifestEnvironmentSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestLiveUpdate) DeepCopyInto(out *ManifestLiveUpdate) {
+	*out = *in
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = make([]ManifestLiveUpdateSync, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rebuild != nil {
+		in, out := &in.Rebuild, &out.Rebuild
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Run != nil {
+		in, out := &in.Run, &out.Run
+		*out = make([]ManifestLiveUpdateRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestLiveUpdate.
+func (in *ManifestLiveUpdate) DeepCopy() *ManifestLiveUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestLiveUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestLiveUpdateRun) DeepCopyInto(out *ManifestLiveUpdateRun) {
+	*out = *in
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestLiveUpdateRun.
+func (in *ManifestLiveUpdateRun) DeepCopy() *ManifestLiveUpdateRun {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestLiveUpdateRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestLiveUpdateSync) DeepCopyInto(out *ManifestLiveUpdateSync) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestLiveUpdateSync.
+func (in *ManifestLiveUpdateSync) DeepCopy() *ManifestLiveUpdateSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestLiveUpdateSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestWaitCondition) DeepCopyInto(out *ManifestWaitCondition) {
 	*out = *in
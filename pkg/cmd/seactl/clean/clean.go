@@ -72,10 +72,8 @@ func (c *Command) RunE(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	console.Info("Deleting source archive")
-
-	// TODO: Need a delete endpoint for the controller or force the controller to delete the
-	// archive when the environment is deleted as part of the finalizer.
+	// The build job, source archive and images are removed by the controller as
+	// part of the environment finalizer.
 
 	return delete(ctx, client, env)
 }
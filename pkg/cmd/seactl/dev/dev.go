@@ -0,0 +1,398 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"ctx.sh/seaway/pkg/cmd/util"
+	"ctx.sh/seaway/pkg/console"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Command struct {
+	// Sync holds the upload options shared with the sync command.  Force and Resume
+	// only apply to the first sync.
+	Sync     sync.Command
+	Debounce time.Duration
+	Logs     bool
+}
+
+// RunE is the main function for the dev command.  It syncs the environment, then
+// watches the build context and syncs again whenever it changes until interrupted.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected environment name")
+	}
+
+	manifest, env, err := load(args[0])
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	client, err := kube.NewKubectlCmd("", kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	sclient, err := util.NewSeawayClient(env, kubeContext)
+	if err != nil {
+		console.Fatal("Unable to create the seaway client: %s", err)
+	}
+
+	filter, err := sync.NewFilter(env)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	w, err := newWatcher(filter)
+	if err != nil {
+		console.Fatal("Unable to watch the build context: %s", err)
+	}
+	defer func() {
+		_ = w.Close()
+	}()
+
+	live, err := newLiveUpdate(env)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	s := &session{
+		Command:     c,
+		kubeContext: kubeContext,
+		envName:     args[0],
+		client:      client,
+		sclient:     sclient,
+		watcher:     w,
+		manifest:    manifest,
+		env:         env,
+		live:        live,
+		changes:     make(map[string]struct{}),
+	}
+
+	return s.run(ctx)
+}
+
+// session is a single run of the dev command.  Everything but the operations and the
+// log streams happens on the goroutine that calls run.
+type session struct {
+	*Command
+	kubeContext string
+	envName     string
+	client      *kube.KubectlCmd
+	sclient     seawayv1beta1connect.SeawayServiceClient
+	exec        *kube.PodExec
+	watcher     *watcher
+	manifest    v1beta1.Manifest
+	env         v1beta1.ManifestEnvironmentSpec
+	live        *liveUpdate
+
+	// changes are the files that changed since the last operation started.
+	changes map[string]struct{}
+	// reload is set when the manifest or an ignore file changed.
+	reload bool
+	// deployed is set once a full sync succeeded, live updates are only applied to
+	// pods that are running the code from this session.
+	deployed   bool
+	op         *operation
+	cancelLogs context.CancelFunc
+}
+
+// operation is a full sync or a live update running in the background.
+type operation struct {
+	cancel  context.CancelFunc
+	done    chan error
+	started time.Time
+	// files are the changes copied by a live update.  It's nil for a full sync.
+	files []string
+}
+
+func (s *session) run(ctx context.Context) error {
+	// The timer fires right away for the initial sync.
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		var done chan error
+		if s.op != nil {
+			done = s.op.done
+		}
+
+		select {
+		case <-ctx.Done():
+			s.stop()
+			return nil
+		case event, ok := <-s.watcher.fsw.Events:
+			if !ok {
+				s.stop()
+				return nil
+			}
+
+			relevant, err := s.watcher.Relevant(event)
+			if err != nil {
+				console.ListWarning("Unable to watch %s: %s", event.Name, err)
+			}
+			if relevant {
+				name := filepath.ToSlash(filepath.Clean(event.Name))
+				s.changes[name] = struct{}{}
+				s.reload = s.reload || isConfig(name)
+				timer.Reset(s.Debounce)
+			}
+		case err, ok := <-s.watcher.fsw.Errors:
+			if !ok {
+				s.stop()
+				return nil
+			}
+			console.ListWarning("Watch error: %s", err)
+		case <-timer.C:
+			if s.op != nil {
+				s.cancel()
+				console.ListWarning("Cancelled, newer changes were found")
+			}
+
+			if s.reload {
+				s.reloadConfig()
+			}
+
+			s.next(ctx)
+		case err := <-done:
+			s.finish(ctx, err)
+		}
+	}
+}
+
+// next starts a live update if every change can be copied into the running pods, and
+// a full sync otherwise.
+func (s *session) next(ctx context.Context) {
+	names := make([]string, 0, len(s.changes))
+	for name := range s.changes {
+		names = append(names, name)
+	}
+	s.changes = make(map[string]struct{})
+
+	if s.deployed && s.live != nil && len(names) > 0 {
+		files := expand(s.watcher.filter, names)
+		if len(files) == 0 {
+			return
+		}
+
+		if plan, ok := s.live.plan(files); ok {
+			s.startLive(ctx, plan, files)
+			return
+		}
+	}
+
+	s.startSync(ctx)
+}
+
+// startSync runs the sync pipeline in the background.
+func (s *session) startSync(ctx context.Context) {
+	opts := s.Sync
+	s.Sync.Force = false
+	s.Sync.Resume = ""
+	s.deployed = false
+
+	console.Section("Syncing %s", s.envName)
+	s.start(ctx, nil, func(ctx context.Context) error {
+		return opts.Sync(ctx, s.client, s.sclient, s.manifest.Name, s.env)
+	})
+}
+
+// startLive copies the changed files into the running pods in the background.
+func (s *session) startLive(ctx context.Context, plan *livePlan, files []string) {
+	if s.exec == nil {
+		exec, err := kube.NewPodExec(s.kubeContext)
+		if err != nil {
+			console.Error("Unable to create the exec client: %s", err)
+			s.startSync(ctx)
+			return
+		}
+		s.exec = exec
+	}
+
+	console.Section("Live updating %s", s.envName)
+	for _, f := range files {
+		console.ListItem(f)
+	}
+
+	exec := s.exec
+	labels := s.labels()
+	namespace := s.env.Namespace
+	s.start(ctx, files, func(ctx context.Context) error {
+		return plan.apply(ctx, exec, namespace, labels)
+	})
+}
+
+func (s *session) start(ctx context.Context, files []string, fn func(context.Context) error) {
+	opCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(opCtx)
+	}()
+
+	s.op = &operation{
+		cancel:  cancel,
+		done:    done,
+		started: time.Now(),
+		files:   files,
+	}
+}
+
+// cancel stops the operation and waits for it to return.  The files of a cancelled
+// live update are copied again with the next changes.
+func (s *session) cancel() {
+	s.op.cancel()
+	<-s.op.done
+
+	for _, f := range s.op.files {
+		s.changes[f] = struct{}{}
+	}
+	s.op = nil
+}
+
+// finish reports the result of the operation.  Once a revision is deployed the logs of
+// the new pods are streamed until the next deploy.  A failed live update falls back to
+// a full sync.
+func (s *session) finish(ctx context.Context, err error) {
+	op := s.op
+	op.cancel()
+	s.op = nil
+
+	switch {
+	case op.files != nil && err != nil:
+		console.Error("Live update failed: %s", err)
+		console.Info("Falling back to a full sync")
+		s.startSync(ctx)
+		return
+	case op.files != nil:
+		console.ListSuccess("Live update complete")
+	case err != nil:
+		console.Error("Sync failed: %s", err)
+	default:
+		s.deployed = true
+		if s.Logs {
+			s.streamLogs(ctx, op.started)
+		}
+	}
+
+	console.Info("Waiting for changes")
+}
+
+// streamLogs replaces the current log stream with one that follows the application
+// pods from the given time on.
+func (s *session) streamLogs(ctx context.Context, since time.Time) {
+	if s.cancelLogs != nil {
+		s.cancelLogs()
+	}
+
+	streamer, err := kube.NewLogStreamer(s.kubeContext, corev1.PodLogOptions{
+		Follow:    true,
+		Container: AppContainer,
+		SinceTime: &metav1.Time{Time: since},
+	})
+	if err != nil {
+		console.ListWarning("Unable to stream the application logs: %s", err)
+		return
+	}
+
+	logCtx, cancel := context.WithCancel(ctx)
+	s.cancelLogs = cancel
+
+	labels := s.labels()
+	namespace := s.env.Namespace
+	go func() {
+		if err := streamer.PodLogs(logCtx, namespace, labels); err != nil && logCtx.Err() == nil {
+			console.ListWarning("Unable to stream the application logs: %s", err)
+		}
+	}()
+}
+
+// labels returns the selector for the application pods.
+func (s *session) labels() string {
+	return fmt.Sprintf("app=%s,group=application", s.manifest.Name)
+}
+
+// reloadConfig loads the manifest and ignore files again.  If they can't be loaded
+// the previous configuration is kept.
+func (s *session) reloadConfig() {
+	s.reload = false
+
+	manifest, env, err := load(s.envName)
+	if err != nil {
+		console.ListWarning("%s, keeping the previous manifest", err)
+		return
+	}
+
+	filter, err := sync.NewFilter(env)
+	if err != nil {
+		console.ListWarning("%s, keeping the previous manifest", err)
+		return
+	}
+
+	live, err := newLiveUpdate(env)
+	if err != nil {
+		console.ListWarning("%s, keeping the previous manifest", err)
+		return
+	}
+
+	if err := s.watcher.SetFilter(filter); err != nil {
+		console.ListWarning("Unable to watch the build context: %s", err)
+	}
+
+	s.manifest = manifest
+	s.env = env
+	s.live = live
+}
+
+func (s *session) stop() {
+	if s.op != nil {
+		s.cancel()
+	}
+
+	if s.cancelLogs != nil {
+		s.cancelLogs()
+	}
+}
+
+func load(name string) (v1beta1.Manifest, v1beta1.ManifestEnvironmentSpec, error) {
+	var manifest v1beta1.Manifest
+	if err := manifest.Load(ManifestFile); err != nil {
+		return manifest, v1beta1.ManifestEnvironmentSpec{}, fmt.Errorf("unable to load manifest: %w", err)
+	}
+
+	env, err := manifest.GetEnvironment(name)
+	if err != nil {
+		return manifest, env, fmt.Errorf("build context '%s' not found in the manifest", name)
+	}
+
+	return manifest, env, nil
+}
@@ -0,0 +1,299 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"ctx.sh/seaway/pkg/console"
+	"ctx.sh/seaway/pkg/ignore"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"ctx.sh/seaway/pkg/source"
+)
+
+// AppContainer is the container that live updates are applied to.
+const AppContainer = "app"
+
+// liveUpdate decides which changes can be copied into the running containers.
+type liveUpdate struct {
+	spec       *v1beta1.ManifestLiveUpdate
+	dockerfile string
+	rebuild    *ignore.Matcher
+	// triggers holds the matcher for each of the run commands, nil if the command
+	// always runs.
+	triggers []*ignore.Matcher
+}
+
+// newLiveUpdate returns nil if the environment doesn't configure live updates.
+func newLiveUpdate(env v1beta1.ManifestEnvironmentSpec) (*liveUpdate, error) {
+	if env.LiveUpdate == nil || len(env.LiveUpdate.Sync) == 0 {
+		return nil, nil
+	}
+
+	rebuild, err := ignore.New(env.LiveUpdate.Rebuild...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid live update rebuild pattern: %w", err)
+	}
+
+	triggers := make([]*ignore.Matcher, len(env.LiveUpdate.Run))
+	for i, run := range env.LiveUpdate.Run {
+		if len(run.Trigger) == 0 {
+			continue
+		}
+
+		triggers[i], err = ignore.New(run.Trigger...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid live update trigger for %q: %w", run.Command, err)
+		}
+	}
+
+	dockerfile := env.Dockerfile()
+	if dockerfile != "" {
+		dockerfile = path.Clean(dockerfile)
+	}
+
+	return &liveUpdate{
+		spec:       env.LiveUpdate,
+		dockerfile: dockerfile,
+		rebuild:    rebuild,
+		triggers:   triggers,
+	}, nil
+}
+
+// livePlan is the set of changes applied to every app container.
+type livePlan struct {
+	// copies maps the changed files to their paths in the container.
+	copies map[string]string
+	// removes are the paths in the container of the files that were deleted.
+	removes  []string
+	commands []string
+}
+
+// plan returns the changes for the files, or false if any of them needs a full
+// rebuild: the Dockerfile, the manifest and ignore files, files matching the rebuild
+// patterns, and files that aren't covered by a sync rule.
+func (lu *liveUpdate) plan(files []string) (*livePlan, bool) {
+	p := &livePlan{
+		copies: make(map[string]string, len(files)),
+	}
+
+	for _, name := range files {
+		if isConfig(name) || name == lu.dockerfile || lu.rebuild.Ignored(name, false) {
+			return nil, false
+		}
+
+		dest, ok := lu.spec.Target(name)
+		if !ok {
+			return nil, false
+		}
+
+		_, err := os.Stat(name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			p.removes = append(p.removes, dest)
+		case err != nil:
+			return nil, false
+		default:
+			p.copies[name] = dest
+		}
+	}
+
+	for i, run := range lu.spec.Run {
+		if lu.triggers[i] == nil || anyIgnored(lu.triggers[i], files) {
+			p.commands = append(p.commands, run.Command)
+		}
+	}
+
+	sort.Strings(p.removes)
+	return p, true
+}
+
+// apply copies the files into the app container of every running pod, removes the
+// deleted files and runs the commands.
+func (p *livePlan) apply(ctx context.Context, exec *kube.PodExec, ns, labels string) error {
+	pods, err := exec.RunningPods(ctx, ns, labels)
+	if err != nil {
+		return err
+	}
+
+	if len(pods) == 0 {
+		return errors.New("there are no running pods to update")
+	}
+
+	archive, err := p.archive()
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		console.ListNotice("Updating %s", pod.Name)
+		if err := p.applyPod(ctx, exec, ns, pod.Name, archive); err != nil {
+			return fmt.Errorf("%s: %w", pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *livePlan) applyPod(ctx context.Context, exec *kube.PodExec, ns, pod string, archive []byte) error {
+	if len(p.copies) > 0 {
+		// The archive paths are relative to the root of the container.
+		if err := run(ctx, exec, ns, pod, []string{"tar", "-xf", "-", "-C", "/"}, bytes.NewReader(archive)); err != nil {
+			return fmt.Errorf("unable to copy files: %w", err)
+		}
+	}
+
+	if len(p.removes) > 0 {
+		if err := run(ctx, exec, ns, pod, append([]string{"rm", "-rf", "--"}, p.removes...), nil); err != nil {
+			return fmt.Errorf("unable to remove files: %w", err)
+		}
+	}
+
+	for _, command := range p.commands {
+		console.ListItem(command)
+		if err := run(ctx, exec, ns, pod, []string{"/bin/sh", "-c", command}, nil); err != nil {
+			return fmt.Errorf("%q failed: %w", command, err)
+		}
+	}
+
+	return nil
+}
+
+// archive returns an uncompressed tar archive of the copied files with the container
+// paths as names.
+func (p *livePlan) archive() ([]byte, error) {
+	names := make([]string, 0, len(p.copies))
+	for name := range p.copies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range names {
+		if err := addFile(tw, name, strings.TrimPrefix(p.copies[name], "/")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func addFile(tw *tar.Writer, name, dest string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     dest,
+		Size:     info.Size(),
+		Mode:     int64(source.Mode(info.Mode())),
+		ModTime:  info.ModTime(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// run executes the command in the app container and includes the output in the
+// error if it fails.
+func run(ctx context.Context, exec *kube.PodExec, ns, pod string, command []string, stdin io.Reader) error {
+	var out bytes.Buffer
+	err := exec.Exec(ctx, ns, pod, AppContainer, command, kube.ExecStreams{
+		Stdin:  stdin,
+		Stdout: &out,
+		Stderr: &out,
+	})
+	if err != nil && out.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(out.String()))
+	}
+
+	return err
+}
+
+// expand replaces the directories in the list of changes with the files inside of
+// them that are part of the build context.
+func expand(filter *sync.Filter, names []string) []string {
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		info, err := os.Stat(name)
+		if err != nil || !info.IsDir() {
+			files = append(files, name)
+			continue
+		}
+
+		_ = filepath.WalkDir(name, func(f string, d fs.DirEntry, e error) error {
+			if e != nil {
+				return nil
+			}
+
+			slashed := filepath.ToSlash(f)
+			if d.IsDir() {
+				if f != name && filter.SkipDir(slashed) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if filter.Included(slashed) {
+				files = append(files, slashed)
+			}
+			return nil
+		})
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+func anyIgnored(m *ignore.Matcher, files []string) bool {
+	for _, name := range files {
+		if m.Ignored(name, false) {
+			return true
+		}
+	}
+
+	return false
+}
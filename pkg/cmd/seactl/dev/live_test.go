@@ -0,0 +1,130 @@
+package dev
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func liveEnv() v1beta1.ManifestEnvironmentSpec {
+	return v1beta1.ManifestEnvironmentSpec{
+		EnvironmentSpec: v1beta1.EnvironmentSpec{
+			Build: &v1beta1.EnvironmentBuild{Include: []string{`^app/`, `\.py$`, `^requirements.txt$`}},
+		},
+		LiveUpdate: &v1beta1.ManifestLiveUpdate{
+			Sync: []v1beta1.ManifestLiveUpdateSync{
+				{Src: "app", Dest: "/srv/app"},
+				{Src: "wsgi.py", Dest: "/srv/wsgi.py"},
+			},
+			Rebuild: []string{"requirements.txt"},
+			Run: []v1beta1.ManifestLiveUpdateRun{
+				{Command: "kill -HUP 1"},
+				{Command: "migrate", Trigger: []string{"app/migrations/"}},
+			},
+		},
+	}
+}
+
+func TestLiveUpdate_Plan(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("app/migrations", 0o755))
+	for _, name := range []string{"app/views.py", "app/migrations/0001.py", "wsgi.py", "setup.py"} {
+		require.NoError(t, os.WriteFile(name, []byte(name), 0o644))
+	}
+
+	lu, err := newLiveUpdate(liveEnv())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		files    []string
+		ok       bool
+		copies   map[string]string
+		removes  []string
+		commands []string
+	}{
+		{
+			name:     "copy",
+			files:    []string{"app/views.py", "wsgi.py"},
+			ok:       true,
+			copies:   map[string]string{"app/views.py": "/srv/app/views.py", "wsgi.py": "/srv/wsgi.py"},
+			commands: []string{"kill -HUP 1"},
+		},
+		{
+			name:     "remove",
+			files:    []string{"app/old.py"},
+			ok:       true,
+			copies:   map[string]string{},
+			removes:  []string{"/srv/app/old.py"},
+			commands: []string{"kill -HUP 1"},
+		},
+		{
+			name:     "trigger",
+			files:    []string{"app/migrations/0001.py"},
+			ok:       true,
+			copies:   map[string]string{"app/migrations/0001.py": "/srv/app/migrations/0001.py"},
+			commands: []string{"kill -HUP 1", "migrate"},
+		},
+		{name: "dockerfile", files: []string{"app/views.py", "Dockerfile"}},
+		{name: "rebuild pattern", files: []string{"requirements.txt"}},
+		{name: "manifest", files: []string{ManifestFile}},
+		{name: "not synced", files: []string{"setup.py"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, ok := lu.plan(tt.files)
+			require.Equal(t, tt.ok, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.copies, plan.copies)
+			assert.Equal(t, tt.removes, plan.removes)
+			assert.Equal(t, tt.commands, plan.commands)
+		})
+	}
+}
+
+func TestLivePlan_Archive(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("wsgi.py", []byte("app = None"), 0o664))
+	require.NoError(t, os.WriteFile("run.sh", []byte("#!/bin/sh"), 0o775))
+
+	plan := &livePlan{copies: map[string]string{"wsgi.py": "/srv/wsgi.py", "run.sh": "/srv/bin/run.sh"}}
+	data, err := plan.archive()
+	require.NoError(t, err)
+
+	tr := tar.NewReader(bytes.NewReader(data))
+	modes := make(map[string]int64)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		modes[hdr.Name] = hdr.Mode
+	}
+	assert.Equal(t, map[string]int64{"srv/bin/run.sh": 0o755, "srv/wsgi.py": 0o644}, modes)
+}
+
+func TestExpand(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("app/api/node_modules", 0o755))
+	for _, name := range []string{"app/api/views.py", "app/api/node_modules/x.py", "app/api/README.md"} {
+		require.NoError(t, os.WriteFile(name, nil, 0o644))
+	}
+
+	filter, err := sync.NewFilter(liveEnv())
+	require.NoError(t, err)
+
+	files := expand(filter, []string{"app/api", "wsgi.py"})
+	assert.Equal(t, []string{"app/api/README.md", "app/api/views.py", "wsgi.py"}, files)
+}
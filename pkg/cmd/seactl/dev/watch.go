@@ -0,0 +1,119 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dev
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"github.com/fsnotify/fsnotify"
+)
+
+// ManifestFile is the manifest in the root of the build context.
+const ManifestFile = "manifest.yaml"
+
+// watcher reports changes to the files in the build context.  Directories that the
+// filter skips are never watched, so large trees like node_modules don't use up the
+// inotify watches.
+type watcher struct {
+	fsw    *fsnotify.Watcher
+	filter *sync.Filter
+}
+
+func newWatcher(filter *sync.Filter) (*watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{
+		fsw:    fsw,
+		filter: filter,
+	}
+
+	if err := w.addTree("."); err != nil {
+		_ = fsw.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Close stops watching.
+func (w *watcher) Close() error {
+	return w.fsw.Close()
+}
+
+// SetFilter replaces the filter after the ignore files or the manifest changed.
+// Directories that are no longer skipped are picked up by the next addTree.
+func (w *watcher) SetFilter(filter *sync.Filter) error {
+	w.filter = filter
+	return w.addTree(".")
+}
+
+// addTree watches the directory and every directory below it that isn't skipped.
+func (w *watcher) addTree(root string) error {
+	return filepath.WalkDir(root, func(f string, d fs.DirEntry, e error) error {
+		if e != nil {
+			// The directory may have been removed while we were walking it.
+			if errors.Is(e, fs.ErrNotExist) {
+				return nil
+			}
+			return e
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if name := filepath.ToSlash(f); name != "." && w.filter.SkipDir(name) {
+			return filepath.SkipDir
+		}
+
+		return w.fsw.Add(f)
+	})
+}
+
+// Relevant returns true if the event changes the build context.  Directories are
+// watched as they're created, since they may already hold files that were moved in.
+func (w *watcher) Relevant(event fsnotify.Event) (bool, error) {
+	if event.Op == fsnotify.Chmod {
+		return false, nil
+	}
+
+	name := filepath.ToSlash(filepath.Clean(event.Name))
+	if isConfig(name) {
+		return true, nil
+	}
+
+	if event.Has(fsnotify.Create) {
+		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+			if w.filter.SkipDir(name) {
+				return false, nil
+			}
+			return true, w.addTree(event.Name)
+		}
+	}
+
+	return w.filter.Included(name), nil
+}
+
+// isConfig returns true for the files that change how the build context is filtered.
+func isConfig(name string) bool {
+	return name == ManifestFile || name == sync.SeawayIgnoreFile || name == sync.DockerIgnoreFile
+}
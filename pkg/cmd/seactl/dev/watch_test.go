@@ -0,0 +1,64 @@
+package dev
+
+import (
+	"os"
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Relevant(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("node_modules/pkg", 0o755))
+	require.NoError(t, os.WriteFile(sync.SeawayIgnoreFile, []byte("*.tmp\n"), 0o600))
+
+	filter, err := sync.NewFilter(v1beta1.ManifestEnvironmentSpec{
+		EnvironmentSpec: v1beta1.EnvironmentSpec{
+			Build: &v1beta1.EnvironmentBuild{Include: []string{`\.py$`, `\.tmp$`}},
+		},
+	})
+	require.NoError(t, err)
+
+	w, err := newWatcher(filter)
+	require.NoError(t, err)
+	defer func() {
+		_ = w.Close()
+	}()
+
+	// Skipped directories aren't watched.
+	assert.NotContains(t, w.fsw.WatchList(), "node_modules")
+	assert.NotContains(t, w.fsw.WatchList(), "node_modules/pkg")
+
+	require.NoError(t, os.MkdirAll("src/app", 0o755))
+
+	tests := []struct {
+		name     string
+		event    fsnotify.Event
+		relevant bool
+	}{
+		{"included file", fsnotify.Event{Name: "main.py", Op: fsnotify.Write}, true},
+		{"excluded file", fsnotify.Event{Name: "README.md", Op: fsnotify.Write}, false},
+		{"ignored file", fsnotify.Event{Name: "cache.tmp", Op: fsnotify.Create}, false},
+		{"chmod", fsnotify.Event{Name: "main.py", Op: fsnotify.Chmod}, false},
+		{"removed file", fsnotify.Event{Name: "main.py", Op: fsnotify.Remove}, true},
+		{"manifest", fsnotify.Event{Name: ManifestFile, Op: fsnotify.Write}, true},
+		{"ignore file", fsnotify.Event{Name: sync.SeawayIgnoreFile, Op: fsnotify.Write}, true},
+		{"new directory", fsnotify.Event{Name: "src", Op: fsnotify.Create}, true},
+		{"skipped directory", fsnotify.Event{Name: "node_modules", Op: fsnotify.Create}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relevant, err := w.Relevant(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.relevant, relevant)
+		})
+	}
+
+	// New directories are watched along with everything below them.
+	assert.Contains(t, w.fsw.WatchList(), "src/app")
+}
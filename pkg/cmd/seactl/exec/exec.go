@@ -0,0 +1,156 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/console"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/kubectl/pkg/util/term"
+)
+
+type Command struct {
+	Container string
+	Stdin     bool
+	TTY       bool
+}
+
+// RunE is the main function for the exec command.  It runs the command in a ready
+// application pod.  If the pod goes away while the command is running, for example
+// when a new revision rolls out, the command is started again in a new pod.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+
+	// Interrupts are passed through to the command when a terminal is attached.
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGTERM)
+	defer cancel()
+
+	envName, command, err := splitArgs(args, cmd.ArgsLenAtDash())
+	if err != nil {
+		return err
+	}
+
+	var manifest v1beta1.Manifest
+	err = manifest.Load("manifest.yaml")
+	if err != nil {
+		console.Fatal("Unable to load manifest")
+	}
+
+	env, err := manifest.GetEnvironment(envName)
+	if err != nil {
+		console.Fatal("Build environment '%s' not found in the manifest", envName)
+	}
+
+	exec, err := kube.NewPodExec(kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	tty := term.TTY{
+		In:  os.Stdin,
+		Out: os.Stdout,
+		Raw: c.Stdin && c.TTY,
+	}
+	useTTY := tty.Raw && tty.IsTerminalIn()
+
+	labels := fmt.Sprintf("app=%s,group=application", manifest.Name)
+	for {
+		pod, err := exec.ReadyPod(ctx, env.Namespace, labels)
+		if err == nil && pod == nil {
+			console.Info("Waiting for a ready pod")
+			pod, err = exec.WaitForReadyPod(ctx, env.Namespace, labels)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			console.Fatal(err.Error())
+		}
+
+		streams := kube.ExecStreams{
+			Stdout: os.Stdout,
+		}
+		if c.Stdin {
+			streams.Stdin = os.Stdin
+		}
+
+		run := func() error {
+			return exec.Exec(ctx, env.Namespace, pod.Name, c.Container, command, streams)
+		}
+
+		if useTTY {
+			streams.TTY = true
+			streams.TerminalSizeQueue = sizeQueue{tty.MonitorSize(tty.GetSize())}
+			err = tty.Safe(run)
+		} else {
+			streams.Stderr = os.Stderr
+			err = run()
+		}
+
+		var exitErr utilexec.CodeExitError
+		switch {
+		case err == nil, ctx.Err() != nil:
+			return nil
+		case exec.Unavailable(ctx, env.Namespace, pod.Name):
+			// Commands that were killed with the pod also report an exit code, so
+			// this is checked first.
+			console.ListWarning("%s is no longer available, reconnecting", pod.Name)
+		case errors.As(err, &exitErr):
+			os.Exit(exitErr.Code)
+		default:
+			console.Fatal(err.Error())
+		}
+	}
+}
+
+// splitArgs returns the environment name and the command.  The command follows the
+// environment name, usually after a -- so its flags aren't parsed by seactl.
+func splitArgs(args []string, dash int) (string, []string, error) {
+	if len(args) < 2 || dash == 0 || dash > 1 {
+		return "", nil, fmt.Errorf("expected environment name and a command: exec [environment] -- [command]")
+	}
+
+	return args[0], args[1:], nil
+}
+
+// sizeQueue adapts the kubectl terminal size queue to the one remotecommand expects.
+type sizeQueue struct {
+	term.TerminalSizeQueue
+}
+
+func (q sizeQueue) Next() *remotecommand.TerminalSize {
+	if q.TerminalSizeQueue == nil {
+		return nil
+	}
+
+	size := q.TerminalSizeQueue.Next()
+	if size == nil {
+		return nil
+	}
+
+	return &remotecommand.TerminalSize{
+		Width:  size.Width,
+		Height: size.Height,
+	}
+}
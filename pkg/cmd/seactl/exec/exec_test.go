@@ -0,0 +1,52 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		dash    int
+		env     string
+		command []string
+		wantErr bool
+	}{
+		{"after dash", []string{"dev", "ls", "-la"}, 1, "dev", []string{"ls", "-la"}, false},
+		{"without dash", []string{"dev", "env"}, -1, "dev", []string{"env"}, false},
+		{"no command", []string{"dev"}, 1, "", nil, true},
+		{"no environment", []string{"ls", "-la"}, 0, "", nil, true},
+		{"dash after command", []string{"dev", "ls", "-la"}, 2, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, command, err := splitArgs(tt.args, tt.dash)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.env, env)
+			assert.Equal(t, tt.command, command)
+		})
+	}
+}
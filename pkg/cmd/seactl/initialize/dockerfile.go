@@ -0,0 +1,236 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// dockerfile holds the instructions of the final build stage that the manifest is
+// generated from, along with the sources copied from the build context by any stage.
+type dockerfile struct {
+	ports   []dockerPort
+	workdir string
+	cmd     []string
+	// copies are the COPY and ADD instructions of the final stage.
+	copies []dockerCopy
+	// sources are the paths copied from the build context by every stage.
+	sources []string
+}
+
+type dockerPort struct {
+	port     int32
+	protocol corev1.Protocol
+}
+
+// dockerCopy is a COPY or ADD from the build context.  The destination is absolute,
+// and keeps its trailing slash since it changes how files are copied.
+type dockerCopy struct {
+	sources []string
+	dest    string
+}
+
+// parseDockerfile reads the instructions that matter for the manifest.  Anything that
+// depends on build arguments or environment variables is skipped, since its value
+// can't be known without building the image.
+func parseDockerfile(r io.Reader) (*dockerfile, error) {
+	instructions, err := readInstructions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	df := &dockerfile{}
+	for _, inst := range instructions {
+		keyword, rest, _ := strings.Cut(inst, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToUpper(keyword) {
+		case "FROM":
+			// Only the final stage ends up in the image.
+			df.ports = nil
+			df.workdir = ""
+			df.cmd = nil
+			df.copies = nil
+		case "EXPOSE":
+			for _, field := range strings.Fields(rest) {
+				if port, ok := parsePort(field); ok {
+					df.ports = append(df.ports, port)
+				}
+			}
+		case "WORKDIR":
+			if rest != "" && !strings.Contains(rest, "$") {
+				df.workdir = resolve(df.workdir, rest)
+			}
+		case "CMD":
+			df.cmd = parseCommand(rest)
+		case "COPY", "ADD":
+			c, ok := parseCopy(rest, df.workdir)
+			if !ok {
+				continue
+			}
+			df.copies = append(df.copies, c)
+			df.sources = append(df.sources, c.sources...)
+		}
+	}
+
+	return df, nil
+}
+
+// readInstructions joins continued lines and drops comments and blank lines.
+func readInstructions(r io.Reader) ([]string, error) {
+	var instructions []string
+	var current strings.Builder
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\"))
+			current.WriteString(" ")
+			continue
+		}
+
+		current.WriteString(line)
+		if inst := strings.TrimSpace(current.String()); inst != "" {
+			instructions = append(instructions, inst)
+		}
+		current.Reset()
+	}
+
+	if inst := strings.TrimSpace(current.String()); inst != "" {
+		instructions = append(instructions, inst)
+	}
+
+	return instructions, scanner.Err()
+}
+
+// parsePort parses a port in the port[/protocol] form of EXPOSE.
+func parsePort(field string) (dockerPort, bool) {
+	number, proto, _ := strings.Cut(field, "/")
+	port, err := strconv.ParseUint(number, 10, 16)
+	if err != nil || port == 0 {
+		return dockerPort{}, false
+	}
+
+	protocol := corev1.ProtocolTCP
+	switch strings.ToLower(proto) {
+	case "", "tcp":
+	case "udp":
+		protocol = corev1.ProtocolUDP
+	case "sctp":
+		protocol = corev1.ProtocolSCTP
+	default:
+		return dockerPort{}, false
+	}
+
+	return dockerPort{port: int32(port), protocol: protocol}, true
+}
+
+// parseCommand returns the command in exec form.  Shell form commands are run with
+// /bin/sh -c like docker does.
+func parseCommand(rest string) []string {
+	if args, ok := jsonArgs(rest); ok {
+		return args
+	}
+
+	if rest == "" {
+		return nil
+	}
+
+	return []string{"/bin/sh", "-c", rest}
+}
+
+// parseCopy returns the sources and the destination of a COPY or ADD.  Copies from
+// other stages or images, remote sources and anything with variables are skipped.
+func parseCopy(rest, workdir string) (dockerCopy, bool) {
+	fields := strings.Fields(rest)
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+		if strings.HasPrefix(fields[0], "--from") {
+			return dockerCopy{}, false
+		}
+		fields = fields[1:]
+	}
+
+	args, ok := jsonArgs(strings.Join(fields, " "))
+	if !ok {
+		args = fields
+	}
+
+	if len(args) < 2 {
+		return dockerCopy{}, false
+	}
+
+	sources := make([]string, 0, len(args)-1)
+	for _, src := range args[:len(args)-1] {
+		if strings.Contains(src, "://") || strings.Contains(src, "$") || strings.HasPrefix(src, "<<") {
+			continue
+		}
+
+		clean := path.Clean(strings.TrimPrefix(src, "/"))
+		if clean == ".." || strings.HasPrefix(clean, "../") {
+			continue
+		}
+		sources = append(sources, clean)
+	}
+
+	dest := args[len(args)-1]
+	if len(sources) == 0 || strings.Contains(dest, "$") {
+		return dockerCopy{}, false
+	}
+
+	resolved := resolve(workdir, dest)
+	if strings.HasSuffix(dest, "/") || dest == "." {
+		resolved = strings.TrimSuffix(resolved, "/") + "/"
+	}
+
+	return dockerCopy{sources: sources, dest: resolved}, true
+}
+
+// resolve returns the absolute path of name relative to the working directory.
+func resolve(workdir, name string) string {
+	if path.IsAbs(name) {
+		return path.Clean(name)
+	}
+
+	if workdir == "" {
+		workdir = "/"
+	}
+
+	return path.Join(workdir, name)
+}
+
+func jsonArgs(s string) ([]string, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return nil, false
+	}
+
+	var args []string
+	if err := json.Unmarshal([]byte(s), &args); err != nil {
+		return nil, false
+	}
+
+	return args, true
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	content := `# syntax=docker/dockerfile:1
+FROM golang:1.22 AS build
+WORKDIR /src
+COPY go.mod go.sum ./
+COPY cmd/ ./cmd/
+EXPOSE 6060
+RUN go build -o /out/app ./cmd/app
+
+FROM debian:bookworm-slim
+WORKDIR /app
+# The binary comes from the build stage.
+COPY --from=build /out/app /usr/local/bin/app
+COPY --chown=app:app config/ config
+ADD https://example.com/ca.pem /etc/ssl/ca.pem
+COPY ["static", "templates", "./web/"]
+COPY $CONFIG /etc/app.yaml
+WORKDIR www
+EXPOSE 8080 9090/udp \
+  bad/proto 0
+CMD ["app", "serve", \
+  "--port=8080"]
+`
+
+	df, err := parseDockerfile(strings.NewReader(content))
+	require.NoError(t, err)
+
+	assert.Equal(t, []dockerPort{
+		{port: 8080, protocol: corev1.ProtocolTCP},
+		{port: 9090, protocol: corev1.ProtocolUDP},
+	}, df.ports)
+	assert.Equal(t, "/app/www", df.workdir)
+	assert.Equal(t, []string{"app", "serve", "--port=8080"}, df.cmd)
+	assert.Equal(t, []dockerCopy{
+		{sources: []string{"config"}, dest: "/app/config"},
+		{sources: []string{"static", "templates"}, dest: "/app/web/"},
+	}, df.copies)
+	assert.Equal(t, []string{"go.mod", "go.sum", "cmd", "config", "static", "templates"}, df.sources)
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		rest     string
+		expected []string
+	}{
+		{"exec form", `["gunicorn", "wsgi:app"]`, []string{"gunicorn", "wsgi:app"}},
+		{"shell form", `python -m http.server 8000`, []string{"/bin/sh", "-c", "python -m http.server 8000"}},
+		{"invalid json", `[python`, []string{"/bin/sh", "-c", "[python"}},
+		{"empty", ``, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseCommand(tt.rest))
+		})
+	}
+}
+
+func TestParseCopy(t *testing.T) {
+	tests := []struct {
+		name     string
+		rest     string
+		workdir  string
+		expected dockerCopy
+		ok       bool
+	}{
+		{"file", "app.py /srv/app.py", "", dockerCopy{[]string{"app.py"}, "/srv/app.py"}, true},
+		{"relative dest", "app.py .", "/srv", dockerCopy{[]string{"app.py"}, "/srv/"}, true},
+		{"no workdir", "app.py app/", "", dockerCopy{[]string{"app.py"}, "/app/"}, true},
+		{"whole context", ". .", "/srv", dockerCopy{[]string{"."}, "/srv/"}, true},
+		{"cleaned", "./src/../lib/ /lib", "", dockerCopy{[]string{"lib"}, "/lib"}, true},
+		{"flags", "--chmod=755 --link run.sh /run.sh", "", dockerCopy{[]string{"run.sh"}, "/run.sh"}, true},
+		{"outside the context", "../secret /secret", "", dockerCopy{}, false},
+		{"from stage", "--from=build /out /out", "", dockerCopy{}, false},
+		{"variable dest", "app.py $DEST", "", dockerCopy{}, false},
+		{"heredoc", "<<EOF /etc/motd", "", dockerCopy{}, false},
+		{"missing dest", "app.py", "", dockerCopy{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := parseCopy(tt.rest, tt.workdir)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, c)
+		})
+	}
+}
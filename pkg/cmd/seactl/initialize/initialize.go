@@ -0,0 +1,87 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"bytes"
+	"os"
+
+	"ctx.sh/seaway/pkg/console"
+	"github.com/spf13/cobra"
+)
+
+// ManifestFile is the manifest that is written.
+const ManifestFile = "manifest.yaml"
+
+type Command struct {
+	Name      string
+	Namespace string
+	Force     bool
+}
+
+// RunE is the main function for the init command.  It looks at the Dockerfile, the
+// kustomizations and the ignore files in the current directory and writes a manifest
+// with a local environment that can be synced without changes.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(ManifestFile); err == nil && !c.Force {
+		console.Fatal("%s already exists, use --force to overwrite it", ManifestFile)
+	}
+
+	p, err := detect(".", c.Name)
+	if err != nil {
+		console.Fatal("Unable to read the project: %s", err)
+	}
+
+	if c.Namespace != "" {
+		p.Namespace = c.Namespace
+	}
+
+	var buf bytes.Buffer
+	if err := render(&buf, p); err != nil {
+		console.Fatal("Unable to generate the manifest: %s", err)
+	}
+
+	if err := os.WriteFile(ManifestFile, buf.Bytes(), 0o644); err != nil { //nolint:gosec
+		console.Fatal("Unable to write %s: %s", ManifestFile, err)
+	}
+
+	summarize(p)
+	return nil
+}
+
+func summarize(p *project) {
+	console.Info("Wrote %s for '%s'", ManifestFile, p.Name)
+
+	if p.Dockerfile != "" {
+		console.ListItem("build: %s with kaniko", p.Dockerfile)
+	} else {
+		console.ListNotice("build: no Dockerfile found, using buildpacks")
+	}
+
+	for _, port := range p.Ports {
+		console.ListItem("port: %d (%s)", port.Port, port.Name)
+	}
+
+	for _, dep := range p.Dependencies {
+		console.ListItem("dependency: %s", dep.Path)
+	}
+
+	if len(p.IgnoreFiles) == 0 && p.GitIgnore {
+		console.ListNotice(".gitignore isn't used for the build context, copy it to .seawayignore if needed")
+	}
+
+	console.Newline()
+	console.Info("Run 'seactl sync %s' to deploy it", EnvironmentName)
+}
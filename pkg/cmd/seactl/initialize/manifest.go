@@ -0,0 +1,148 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvironmentName is the name of the environment in the generated manifest.
+const EnvironmentName = "local"
+
+const manifestTemplate = `# Generated by seactl init.  Review it, then run 'seactl sync {{ .Environment }}' to
+# build and deploy the app.
+name: {{ scalar .Name }}
+version: 0.1.0
+description: Application managed by Seaway
+
+environments:
+  - name: {{ .Environment }}
+    namespace: {{ scalar .Namespace }}
+    build:
+{{- if .Dockerfile }}
+      engine: kaniko
+      dockerfile: {{ scalar .Dockerfile }}
+      # Only files that match one of these regular expressions are sent to the
+      # build.  They were taken from the COPY and ADD instructions in the Dockerfile.
+{{- else }}
+      # There is no Dockerfile, so the image is built with Cloud Native Buildpacks.
+      engine: buildpacks
+      # Only files that match one of these regular expressions are sent to the build.
+{{- end }}
+      include:
+{{- range .Includes }}
+        - {{ scalar . }}
+{{- end }}
+      # Files can be left out with 'exclude', which takes regular expressions too.
+{{- if .IgnoreFiles }}
+      # Files matched by {{ join .IgnoreFiles " and " }} are never sent.
+{{- else if .GitIgnore }}
+      # .gitignore isn't read.  Copy it to .seawayignore to leave the same files out.
+{{- end }}
+{{- if .Ports }}
+    # The ports exposed by the Dockerfile.
+    network:
+      service:
+        enabled: true
+        ports:
+{{- range .Ports }}
+          - name: {{ scalar .Name }}
+            port: {{ .Port }}
+{{- if .Protocol }}
+            protocol: {{ .Protocol }}
+{{- end }}
+{{- end }}
+{{- else }}
+    # Uncomment to create a service for the app.
+    # network:
+    #   service:
+    #     enabled: true
+    #     ports:
+    #       - name: http
+    #         port: 8080
+{{- end }}
+{{- if or .Workdir .Cmd }}
+    # The working directory and command of the image are used unless they are set
+    # here.
+{{- if .Workdir }}
+    # workingDir: {{ scalar .Workdir }}
+{{- end }}
+{{- if .Cmd }}
+    # args: {{ flow .Cmd }}
+{{- end }}
+{{- end }}
+{{- if .LiveUpdate }}
+    # Uncomment to copy changed files into the running containers during
+    # 'seactl dev' instead of building a new image.
+    # liveUpdate:
+    #   sync:
+{{- range .LiveUpdate }}
+    #     - src: {{ scalar .Src }}
+    #       dest: {{ scalar .Dest }}
+{{- end }}
+{{- end }}
+{{- if .Dependencies }}
+    # Kustomizations that are applied before the app is deployed.  They are applied
+    # in order, so move the ones that others depend on to the top.
+    dependencies:
+{{- range .Dependencies }}
+      - name: {{ scalar .Name }}
+        path: {{ scalar .Path }}
+{{- end }}
+{{- else }}
+    # Uncomment to apply kustomizations before the app is deployed.
+    # dependencies:
+    #   - name: base
+    #     path: k8s/base
+{{- end }}
+`
+
+// render writes the manifest for the project.
+func render(w io.Writer, p *project) error {
+	tmpl, err := template.New("manifest").Funcs(template.FuncMap{
+		"scalar": scalar,
+		"flow":   flow,
+		"join":   strings.Join,
+	}).Parse(manifestTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, struct {
+		*project
+		Environment string
+	}{p, EnvironmentName})
+}
+
+// scalar returns the value as a YAML scalar, quoted only when it needs to be.
+func scalar(s string) (string, error) {
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// flow returns the list in YAML flow style.  JSON arrays are valid YAML.
+func flow(args []string) (string, error) {
+	out, err := json.Marshal(args)
+	return string(out), err
+}
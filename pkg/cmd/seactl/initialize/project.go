@@ -0,0 +1,302 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// KustomizeDir is the directory that is searched for kustomizations to use as
+	// dependencies.
+	KustomizeDir = "k8s"
+	// GitIgnoreFile is only used to suggest a .seawayignore, it isn't read by sync.
+	GitIgnoreFile = ".gitignore"
+	// IncludeAll is the include pattern used when the whole directory is copied
+	// into the image.
+	IncludeAll = ".*"
+)
+
+var (
+	// Dockerfiles are the names that are checked, in order.
+	Dockerfiles = []string{"Dockerfile", "Containerfile"} //nolint:gochecknoglobals
+	// KustomizationFiles are the names kustomize looks for in a directory.
+	KustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"} //nolint:gochecknoglobals
+)
+
+// project is what was found in the project directory.
+type project struct {
+	Name         string
+	Namespace    string
+	Dockerfile   string
+	Workdir      string
+	Cmd          []string
+	Includes     []string
+	Ports        []v1beta1.EnvironmentPort
+	Dependencies []v1beta1.ManifestDependency
+	LiveUpdate   []v1beta1.ManifestLiveUpdateSync
+	// IgnoreFiles are the ignore files that sync applies to the build context.
+	IgnoreFiles []string
+	GitIgnore   bool
+}
+
+// detect looks at the project in the root directory.  The name defaults to the name
+// of the directory.
+func detect(root, name string) (*project, error) {
+	if name == "" {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.Base(abs)
+	}
+
+	p := &project{
+		Name: sanitize(name),
+	}
+	p.Namespace = p.Name
+
+	for _, f := range []string{sync.DockerIgnoreFile, sync.SeawayIgnoreFile} {
+		if isFile(filepath.Join(root, f)) {
+			p.IgnoreFiles = append(p.IgnoreFiles, f)
+		}
+	}
+	p.GitIgnore = isFile(filepath.Join(root, GitIgnoreFile))
+
+	for _, f := range Dockerfiles {
+		if isFile(filepath.Join(root, f)) {
+			p.Dockerfile = f
+			break
+		}
+	}
+
+	if p.Dockerfile == "" {
+		// Buildpacks need the whole source tree.
+		p.Includes = []string{IncludeAll}
+	} else if err := p.readDockerfile(root); err != nil {
+		return nil, err
+	}
+
+	deps, err := dependencies(root)
+	if err != nil {
+		return nil, err
+	}
+	p.Dependencies = deps
+
+	return p, nil
+}
+
+func (p *project) readDockerfile(root string) error {
+	file, err := os.Open(filepath.Join(root, p.Dockerfile))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	df, err := parseDockerfile(file)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", p.Dockerfile, err)
+	}
+
+	p.Workdir = df.workdir
+	p.Cmd = df.cmd
+	p.Includes = includes(root, df.sources)
+	if p.Dockerfile != v1beta1.DefaultDockerfile {
+		p.Includes = append(p.Includes, "^"+regexp.QuoteMeta(p.Dockerfile)+"$")
+	}
+
+	seen := make(map[int32]bool)
+	for _, port := range df.ports {
+		if seen[port.port] {
+			continue
+		}
+		seen[port.port] = true
+
+		name := "http"
+		if len(p.Ports) > 0 || port.protocol != corev1.ProtocolTCP {
+			name = fmt.Sprintf("%s-%d", strings.ToLower(string(port.protocol)), port.port)
+		}
+
+		ep := v1beta1.EnvironmentPort{Name: name, Port: port.port}
+		if port.protocol != corev1.ProtocolTCP {
+			ep.Protocol = port.protocol
+		}
+		p.Ports = append(p.Ports, ep)
+	}
+
+	p.LiveUpdate = liveUpdateSyncs(root, df.copies)
+	return nil
+}
+
+// includes returns the include patterns for the sources copied into the image.
+// Directories include everything below them.
+func includes(root string, sources []string) []string {
+	patterns := make([]string, 0, len(sources))
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		var pattern string
+		switch {
+		case src == ".":
+			return []string{IncludeAll}
+		case hasGlob(src):
+			pattern = "^" + globPattern(src) + "(/|$)"
+		case isDir(filepath.Join(root, filepath.FromSlash(src))):
+			pattern = "^" + regexp.QuoteMeta(src) + "/"
+		default:
+			pattern = "^" + regexp.QuoteMeta(src) + "$"
+		}
+
+		if !seen[pattern] {
+			seen[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
+
+// liveUpdateSyncs maps the copied sources to their paths in the image.  Like docker,
+// the contents of a directory are copied into the destination, and files are copied
+// into it if it ends with a slash or there are several sources.
+func liveUpdateSyncs(root string, copies []dockerCopy) []v1beta1.ManifestLiveUpdateSync {
+	syncs := make([]v1beta1.ManifestLiveUpdateSync, 0)
+	for _, c := range copies {
+		for _, src := range c.sources {
+			if hasGlob(src) {
+				continue
+			}
+
+			dest := path.Clean(c.dest)
+			dir := src == "." || isDir(filepath.Join(root, filepath.FromSlash(src)))
+			if !dir && (strings.HasSuffix(c.dest, "/") || len(c.sources) > 1) {
+				dest = path.Join(dest, path.Base(src))
+			}
+
+			syncs = append(syncs, v1beta1.ManifestLiveUpdateSync{Src: src, Dest: dest})
+		}
+	}
+
+	return syncs
+}
+
+// dependencies returns the kustomizations in the k8s directory.  If the directory is
+// a kustomization itself it's the only dependency, otherwise each subdirectory that
+// is one becomes a dependency.  CRDs are applied first.
+func dependencies(root string) ([]v1beta1.ManifestDependency, error) {
+	dir := filepath.Join(root, KustomizeDir)
+	if isKustomization(dir) {
+		return []v1beta1.ManifestDependency{{Name: KustomizeDir, Path: KustomizeDir}}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]v1beta1.ManifestDependency, 0)
+	for _, e := range entries {
+		if e.IsDir() && isKustomization(filepath.Join(dir, e.Name())) {
+			deps = append(deps, v1beta1.ManifestDependency{
+				Name: sanitize(e.Name()),
+				Path: path.Join(KustomizeDir, e.Name()),
+			})
+		}
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool {
+		return isCRDs(deps[i].Path) && !isCRDs(deps[j].Path)
+	})
+
+	return deps, nil
+}
+
+func isCRDs(name string) bool {
+	base := strings.ToLower(path.Base(name))
+	return base == "crds" || base == "crd"
+}
+
+func isKustomization(dir string) bool {
+	for _, f := range KustomizationFiles {
+		if isFile(filepath.Join(dir, f)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sanitize turns the name into a DNS label so it can be used for the environment
+// and the namespace.
+func sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+
+	out := b.String()
+	if len(out) > validation.DNS1123LabelMaxLength {
+		out = out[:validation.DNS1123LabelMaxLength]
+	}
+
+	out = strings.Trim(out, "-")
+	if out == "" {
+		return "app"
+	}
+
+	return out
+}
+
+func hasGlob(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// globPattern converts a docker source glob into a regular expression.
+func globPattern(glob string) string {
+	pattern := regexp.QuoteMeta(glob)
+	pattern = strings.ReplaceAll(pattern, `\*`, `[^/]*`)
+	return strings.ReplaceAll(pattern, `\?`, `[^/]`)
+}
+
+func isFile(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func isDir(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package initialize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func projectDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "My_Service")
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	t.Chdir(dir)
+
+	return dir
+}
+
+// load parses the rendered manifest the same way sync does.
+func load(t *testing.T, p *project) v1beta1.ManifestEnvironmentSpec {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, render(&buf, p))
+
+	var manifest v1beta1.Manifest
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &manifest), buf.String())
+
+	env, err := manifest.GetEnvironment(EnvironmentName)
+	require.NoError(t, err)
+
+	return env
+}
+
+func TestDetect(t *testing.T) {
+	projectDir(t, map[string]string{
+		"Dockerfile": `FROM python:3.12-slim
+WORKDIR /srv
+COPY requirements.txt .
+COPY *.py ./
+COPY app app
+COPY entrypoint.sh /entrypoint.sh
+EXPOSE 8000 9000 5353/udp 8000
+CMD gunicorn wsgi:app
+`,
+		"requirements.txt":              "",
+		"wsgi.py":                       "",
+		"notes.txt":                     "",
+		"app/__init__.py":               "",
+		"app/templates/index.html":      "",
+		"entrypoint.sh":                 "",
+		"tests/test_app.py":             "",
+		".dockerignore":                 "**/__pycache__\n",
+		".gitignore":                    "*.pyc\n",
+		"k8s/base/kustomization.yaml":   "",
+		"k8s/crds/kustomization.yml":    "",
+		"k8s/overlays/dev/kustomize.md": "",
+	})
+
+	p, err := detect(".", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-service", p.Name)
+	assert.Equal(t, "my-service", p.Namespace)
+	assert.Equal(t, "Dockerfile", p.Dockerfile)
+	assert.Equal(t, "/srv", p.Workdir)
+	assert.Equal(t, []string{"/bin/sh", "-c", "gunicorn wsgi:app"}, p.Cmd)
+	assert.Equal(t, []string{
+		`^requirements\.txt$`,
+		`^[^/]*\.py(/|$)`,
+		`^app/`,
+		`^entrypoint\.sh$`,
+	}, p.Includes)
+	assert.Equal(t, []v1beta1.EnvironmentPort{
+		{Name: "http", Port: 8000},
+		{Name: "tcp-9000", Port: 9000},
+		{Name: "udp-5353", Port: 5353, Protocol: corev1.ProtocolUDP},
+	}, p.Ports)
+	assert.Equal(t, []v1beta1.ManifestDependency{
+		{Name: "crds", Path: "k8s/crds"},
+		{Name: "base", Path: "k8s/base"},
+	}, p.Dependencies)
+	assert.Equal(t, []v1beta1.ManifestLiveUpdateSync{
+		{Src: "requirements.txt", Dest: "/srv/requirements.txt"},
+		{Src: "app", Dest: "/srv/app"},
+		{Src: "entrypoint.sh", Dest: "/entrypoint.sh"},
+	}, p.LiveUpdate)
+	assert.Equal(t, []string{".dockerignore"}, p.IgnoreFiles)
+	assert.True(t, p.GitIgnore)
+
+	env := load(t, p)
+	assert.Equal(t, "my-service", env.Namespace)
+	assert.Equal(t, v1beta1.EnvironmentBuildEngineKaniko, env.Build.Engine)
+	assert.Equal(t, p.Ports, env.Network.Service.Ports)
+	assert.True(t, env.Network.Service.Enabled)
+	require.Len(t, env.Dependencies, 2)
+	assert.Equal(t, "k8s/crds", env.Dependencies[0].Path)
+	assert.Equal(t, "k8s/base", env.Dependencies[1].Path)
+	assert.Nil(t, env.LiveUpdate)
+	assert.Nil(t, env.Args)
+
+	filter, err := sync.NewFilter(env)
+	require.NoError(t, err)
+	for name, included := range map[string]bool{
+		"Dockerfile":                  true,
+		"manifest.yaml":               true,
+		"requirements.txt":            true,
+		"wsgi.py":                     true,
+		"app/__init__.py":             true,
+		"app/templates/index.html":    true,
+		"entrypoint.sh":               true,
+		"notes.txt":                   false,
+		"tests/test_app.py":           false,
+		"k8s/base/kustomization.yaml": false,
+	} {
+		assert.Equal(t, included, filter.Included(name), name)
+	}
+}
+
+func TestDetectWithoutDockerfile(t *testing.T) {
+	projectDir(t, map[string]string{
+		"main.go":                     "package main",
+		"k8s/kustomization.yaml":      "",
+		"k8s/base/kustomization.yaml": "",
+	})
+
+	p, err := detect(".", "api")
+	require.NoError(t, err)
+
+	assert.Equal(t, "api", p.Name)
+	assert.Empty(t, p.Dockerfile)
+	assert.Equal(t, []string{IncludeAll}, p.Includes)
+	assert.Equal(t, []v1beta1.ManifestDependency{{Name: "k8s", Path: "k8s"}}, p.Dependencies)
+	assert.False(t, p.GitIgnore)
+
+	env := load(t, p)
+	assert.Equal(t, v1beta1.EnvironmentBuildEngineBuildpacks, env.Build.Engine)
+	assert.Nil(t, env.Network)
+
+	filter, err := sync.NewFilter(env)
+	require.NoError(t, err)
+	assert.True(t, filter.Included("main.go"))
+}
+
+func TestDetectContainerfile(t *testing.T) {
+	projectDir(t, map[string]string{
+		"Containerfile": "FROM scratch\nCOPY . /\n",
+		"main.go":       "package main",
+	})
+
+	p, err := detect(".", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Containerfile", p.Dockerfile)
+	assert.Equal(t, []string{IncludeAll, `^Containerfile$`}, p.Includes)
+	assert.Equal(t, []v1beta1.ManifestLiveUpdateSync{{Src: ".", Dest: "/"}}, p.LiveUpdate)
+	assert.Empty(t, p.Dependencies)
+
+	env := load(t, p)
+	assert.Equal(t, "Containerfile", env.Dockerfile())
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"api", "api"},
+		{"My_Service", "my-service"},
+		{"--web.app--", "web-app"},
+		{"___", "app"},
+		{"a-very-long-project-name-that-goes-past-the-limit-of-a-dns-label-by-far", "a-very-long-project-name-that-goes-past-the-limit-of-a-dns-labe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitize(tt.name))
+		})
+	}
+}
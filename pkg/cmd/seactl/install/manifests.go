@@ -7,11 +7,11 @@ import (
 
 // Generated YAML for the CRD installation.
 var crdYaml = `
-YXBpVmVyc2lvbjogYXBpZXh0ZW5zaW9ucy5rOHMuaW8vdjEKa2luZDogQ3VzdG9tUmVzb3VyY2VEZWZpbml0aW9uCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY29udHJvbGxlci1nZW4ua3ViZWJ1aWxkZXIuaW8vdmVyc2lvbjogdjAuMTYuMQogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogZW52aXJvbm1lbnRzLnNlYXdheS5jdHguc2gKc3BlYzoKICBncm91cDogc2Vhd2F5LmN0eC5zaAogIG5hbWVzOgogICAga2luZDogRW52aXJvbm1lbnQKICAgIGxpc3RLaW5kOiBFbnZpcm9ubWVudExpc3QKICAgIHBsdXJhbDogZW52aXJvbm1lbnRzCiAgICBzaG9ydE5hbWVzOgogICAgLSBlbnYKICAgIHNpbmd1bGFyOiBlbnZpcm9ubWVudAogIHNjb3BlOiBOYW1lc3BhY2VkCiAgdmVyc2lvbnM6CiAgLSBhZGRpdGlvbmFsUHJpbnRlckNvbHVtbnM6CiAgICAtIGpzb25QYXRoOiAuc3RhdHVzLnN0YWdlCiAgICAgIG5hbWU6IFN0YWdlCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLnN0YXR1cy5sYXN0VXBkYXRlZAogICAgICBuYW1lOiBMYXN0IFVwZGF0ZWQKICAgICAgdHlwZTogZGF0ZQogICAgLSBqc29uUGF0aDogLnN0YXR1cy5leHBlY3RlZFJldmlzaW9uCiAgICAgIG5hbWU6IEV4cGVjdGVkIFJldmlzaW9uCiAgICAgIHByaW9yaXR5OiAxCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLnN0YXR1cy5kZXBsb3llZFJldmlzaW9uCiAgICAgIG5hbWU6IERlcGxveWVkIFJldmlzaW9uCiAgICAgIHByaW9yaXR5OiAxCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLm1ldGFkYXRhLmNyZWF0aW9uVGltZXN0YW1wCiAgICAgIG5hbWU6IEFnZQogICAgICB0eXBlOiBkYXRlCiAgICBuYW1lOiB2MWJldGExCiAgICBzY2hlbWE6CiAgICAgIG9wZW5BUElWM1NjaGVtYToKICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgYXBpVmVyc2lvbjoKICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICBraW5kOgogICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgIG1ldGFkYXRhOgogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgIHNwZWM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgYXJnczoKICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgIGJ1aWxkOgogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYXJnczoKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgZG9ja2VyZmlsZToKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgZXhjbHVkZToKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgIGltYWdlOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICBpbmNsdWRlOgogICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgcGxhdGZvcm06CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICBjb21tYW5kOgogICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgY29uZmlnOgogICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgbGlmZWN5Y2xlOgogICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgIHBvc3RTdGFydDoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgZXhlYzoKICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICBjb21tYW5kOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgIGh0dHBHZXQ6CiAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIGh0dHBIZWFkZXJzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgbmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHZhbHVlOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIG5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB2YWx1ZQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgICBwYXRoOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgICBzY2hlbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgIHNsZWVwOgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIHNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAtIHNlY29uZHMKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICB0Y3BTb2NrZXQ6CiAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIHByZVN0b3A6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGV4ZWM6CiAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICBodHRwR2V0OgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICBodHRwSGVhZGVyczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB2YWx1ZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSBuYW1lCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdmFsdWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgc2NoZW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICBzbGVlcDoKICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICBzZWNvbmRzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQ2NAogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgLSBzZWNvbmRzCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgdGNwU29ja2V0OgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgbGl2ZW5lc3NQcm9iZToKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBleGVjOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBjb21tYW5kOgogICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGZhaWx1cmVUaHJlc2hvbGQ6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgZ3JwYzoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICBzZXJ2aWNlOgogICAgICAgICAgICAgICAgICAgICAgICBkZWZhdWx0OiAiIgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgaHR0cEdldDoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBodHRwSGVhZGVyczoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgdmFsdWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgICAgIC0gdmFsdWUKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICBwYXRoOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgIHNjaGVtZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGluaXRpYWxEZWxheVNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgcGVyaW9kU2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICBzdWNjZXNzVGhyZXNob2xkOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHRjcFNvY2tldDoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIHRlcm1pbmF0aW9uR3JhY2VQZXJpb2RTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHRpbWVvdXRTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICBuZXR3b3JrOgogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgaW5ncmVzczoKICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBhbm5vdGF0aW9uczoKICAgICAgICAgICAgICAgICAgICAgICAgYWRkaXRpb25hbFByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgY2xhc3NOYW1lOgogICAgICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIGVuYWJsZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgdGxzOgogICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgaG9zdHM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHNlY3JldE5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBzZXJ2aWNlOgogICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGFubm90YXRpb25zOgogICAgICAgICAgICAgICAgICAgICAgICBhZGRpdGlvbmFsUHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICBlbmFibGVkOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICBleHRlcm5hbE5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgcG9ydHM6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5vZGVQb3J0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvdG9jb2w6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgdHlwZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICByZWFkaW5lc3NQcm9iZToKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBleGVjOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBjb21tYW5kOgogICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGZhaWx1cmVUaHJlc2hvbGQ6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgZ3JwYzoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICBzZXJ2aWNlOgogICAgICAgICAgICAgICAgICAgICAgICBkZWZhdWx0OiAiIgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgaHR0cEdldDoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBodHRwSGVhZGVyczoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgdmFsdWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgICAgIC0gdmFsdWUKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICBwYXRoOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgIHNjaGVtZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGluaXRpYWxEZWxheVNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgcGVyaW9kU2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICBzdWNjZXNzVGhyZXNob2xkOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHRjcFNvY2tldDoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgaG9zdDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIHRlcm1pbmF0aW9uR3JhY2VQZXJpb2RTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHRpbWVvdXRTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICByZXBsaWNhczoKICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICByZXNvdXJjZXM6CiAgICAgICAgICAgICAgICBhZGRpdGlvbmFsUHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICBwYXR0ZXJuOiBeKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKChbS01HVFBFXWkpfFtudW1rTUdUUEVdfChbZUVdKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKSk/JAogICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgcmV2aXNpb246CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBzZWN1cml0eUNvbnRleHQ6CiAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYWxsb3dQcml2aWxlZ2VFc2NhbGF0aW9uOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgYXBwQXJtb3JQcm9maWxlOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBsb2NhbGhvc3RQcm9maWxlOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gdHlwZQogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBjYXBhYmlsaXRpZXM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGFkZDoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgZHJvcDoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBwcml2aWxlZ2VkOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcHJvY01vdW50OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICByZWFkT25seVJvb3RGaWxlc3lzdGVtOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcnVuQXNHcm91cDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICBydW5Bc05vblJvb3Q6CiAgICAgICAgICAgICAgICAgICAgdHlwZTogYm9vbGVhbgogICAgICAgICAgICAgICAgICBydW5Bc1VzZXI6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQ2NAogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgc2VMaW51eE9wdGlvbnM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGxldmVsOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHJvbGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgdHlwZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICB1c2VyOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgc2VjY29tcFByb2ZpbGU6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGxvY2FsaG9zdFByb2ZpbGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgdHlwZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSB0eXBlCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIHdpbmRvd3NPcHRpb25zOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBnbXNhQ3JlZGVudGlhbFNwZWM6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgZ21zYUNyZWRlbnRpYWxTcGVjTmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBob3N0UHJvY2VzczoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYm9vbGVhbgogICAgICAgICAgICAgICAgICAgICAgcnVuQXNVc2VyTmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICBzdGFydHVwUHJvYmU6CiAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgZXhlYzoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBmYWlsdXJlVGhyZXNob2xkOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIGdycGM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgc2VydmljZToKICAgICAgICAgICAgICAgICAgICAgICAgZGVmYXVsdDogIiIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGh0dHBHZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgaHR0cEhlYWRlcnM6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHZhbHVlOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAtIG5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAtIHZhbHVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICBzY2hlbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBpbml0aWFsRGVsYXlTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHBlcmlvZFNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgc3VjY2Vzc1RocmVzaG9sZDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0Y3BTb2NrZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICB0ZXJtaW5hdGlvbkdyYWNlUGVyaW9kU2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0aW1lb3V0U2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgdmFyczoKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBlbnY6CiAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB2YWx1ZToKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgdmFsdWVGcm9tOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBjb25maWdNYXBLZXlSZWY6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAga2V5OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgbmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGRlZmF1bHQ6ICIiCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBvcHRpb25hbDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0ga2V5CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbWFwLXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgICAgICAgZmllbGRSZWY6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgYXBpVmVyc2lvbjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGZpZWxkUGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSBmaWVsZFBhdGgKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1tYXAtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXNvdXJjZUZpZWxkUmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGNvbnRhaW5lck5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBkaXZpc29yOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwYXR0ZXJuOiBeKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKChbS01HVFBFXWkpfFtudW1rTUdUUEVdfChbZUVdKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKSk/JAogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXNvdXJjZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSByZXNvdXJjZQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLW1hcC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHNlY3JldEtleVJlZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBrZXk6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgZGVmYXVsdDogIiIKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIG9wdGlvbmFsOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYm9vbGVhbgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSBrZXkKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1tYXAtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICBlbnZGcm9tOgogICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgY29uZmlnTWFwUmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBkZWZhdWx0OiAiIgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG9wdGlvbmFsOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLW1hcC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgcHJlZml4OgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICBzZWNyZXRSZWY6CiAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGRlZmF1bHQ6ICIiCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgb3B0aW9uYWw6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbWFwLXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgd29ya2luZ0RpcjoKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgIC0gcmV2aXNpb24KICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICBzdGF0dXM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgZGVwbG95ZWRSZXZpc2lvbjoKICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgIGV4cGVjdGVkUmV2aXNpb246CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBsYXN0VXBkYXRlZDoKICAgICAgICAgICAgICAgIGZvcm1hdDogZGF0ZS10aW1lCiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICByZWFzb246CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBzdGFnZToKICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICB0eXBlOiBvYmplY3QKICAgIHNlcnZlZDogdHJ1ZQogICAgc3RvcmFnZTogdHJ1ZQogICAgc3VicmVzb3VyY2VzOgogICAgICBzdGF0dXM6IHt9Ci0tLQphcGlWZXJzaW9uOiBhcGlleHRlbnNpb25zLms4cy5pby92MQpraW5kOiBDdXN0b21SZXNvdXJjZURlZmluaXRpb24KbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjb250cm9sbGVyLWdlbi5rdWJlYnVpbGRlci5pby92ZXJzaW9uOiB2MC4xNi4xCiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBlbnZpcm9ubWVudGNvbmZpZ3Muc2Vhd2F5LmN0eC5zaApzcGVjOgogIGdyb3VwOiBzZWF3YXkuY3R4LnNoCiAgbmFtZXM6CiAgICBraW5kOiBFbnZpcm9ubWVudENvbmZpZwogICAgbGlzdEtpbmQ6IEVudmlyb25tZW50Q29uZmlnTGlzdAogICAgcGx1cmFsOiBlbnZpcm9ubWVudGNvbmZpZ3MKICAgIHNob3J0TmFtZXM6CiAgICAtIGVjb25mCiAgICBzaW5ndWxhcjogZW52aXJvbm1lbnRjb25maWcKICBzY29wZTogTmFtZXNwYWNlZAogIHZlcnNpb25zOgogIC0gYWRkaXRpb25hbFByaW50ZXJDb2x1bW5zOgogICAgLSBqc29uUGF0aDogLm1ldGFkYXRhLmNyZWF0aW9uVGltZXN0YW1wCiAgICAgIG5hbWU6IEFnZQogICAgICB0eXBlOiBkYXRlCiAgICBuYW1lOiB2MWJldGExCiAgICBzY2hlbWE6CiAgICAgIG9wZW5BUElWM1NjaGVtYToKICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgYXBpVmVyc2lvbjoKICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICBraW5kOgogICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgIG1ldGFkYXRhOgogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgIHNwZWM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgcmVnaXN0cnk6CiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBub2RlUG9ydDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB1cmw6CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgIC0gbm9kZVBvcnQKICAgICAgICAgICAgICAgIC0gdXJsCiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICBzdG9yYWdlOgogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYnVja2V0OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICBjcmVkZW50aWFsczoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgZW5kcG9pbnQ6CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgIGZvcmNlUGF0aFN0eWxlOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcHJlZml4OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICByZWdpb246CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgIC0gYnVja2V0CiAgICAgICAgICAgICAgICAtIGVuZHBvaW50CiAgICAgICAgICAgICAgICAtIHJlZ2lvbgogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgIHR5cGU6IG9iamVjdAogICAgc2VydmVkOiB0cnVlCiAgICBzdG9yYWdlOiB0cnVlCiAgICBzdWJyZXNvdXJjZXM6IHt9Cg==`
+YXBpVmVyc2lvbjogYXBpZXh0ZW5zaW9ucy5rOHMuaW8vdjEKa2luZDogQ3VzdG9tUmVzb3VyY2VEZWZpbml0aW9uCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY29udHJvbGxlci1nZW4ua3ViZWJ1aWxkZXIuaW8vdmVyc2lvbjogdjAuMTYuMQogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogZW52aXJvbm1lbnRzLnNlYXdheS5jdHguc2gKc3BlYzoKICBncm91cDogc2Vhd2F5LmN0eC5zaAogIG5hbWVzOgogICAga2luZDogRW52aXJvbm1lbnQKICAgIGxpc3RLaW5kOiBFbnZpcm9ubWVudExpc3QKICAgIHBsdXJhbDogZW52aXJvbm1lbnRzCiAgICBzaG9ydE5hbWVzOgogICAgLSBlbnYKICAgIHNpbmd1bGFyOiBlbnZpcm9ubWVudAogIHNjb3BlOiBOYW1lc3BhY2VkCiAgdmVyc2lvbnM6CiAgLSBhZGRpdGlvbmFsUHJpbnRlckNvbHVtbnM6CiAgICAtIGpzb25QYXRoOiAuc3RhdHVzLmNvbmRpdGlvbnNbPyhALnR5cGU9PSJSZWFkeSIpXS5zdGF0dXMKICAgICAgbmFtZTogUmVhZHkKICAgICAgdHlwZTogc3RyaW5nCiAgICAtIGpzb25QYXRoOiAuc3RhdHVzLnN0YWdlCiAgICAgIG5hbWU6IFN0YWdlCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLnN0YXR1cy5sYXN0VXBkYXRlZAogICAgICBuYW1lOiBMYXN0IFVwZGF0ZWQKICAgICAgdHlwZTogZGF0ZQogICAgLSBqc29uUGF0aDogLnN0YXR1cy5leHBlY3RlZFJldmlzaW9uCiAgICAgIG5hbWU6IEV4cGVjdGVkIFJldmlzaW9uCiAgICAgIHByaW9yaXR5OiAxCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLnN0YXR1cy5kZXBsb3llZFJldmlzaW9uCiAgICAgIG5hbWU6IERlcGxveWVkIFJldmlzaW9uCiAgICAgIHByaW9yaXR5OiAxCiAgICAgIHR5cGU6IHN0cmluZwogICAgLSBqc29uUGF0aDogLm1ldGFkYXRhLmNyZWF0aW9uVGltZXN0YW1wCiAgICAgIG5hbWU6IEFnZQogICAgICB0eXBlOiBkYXRlCiAgICBuYW1lOiB2MWJldGExCiAgICBzY2hlbWE6CiAgICAgIG9wZW5BUElWM1NjaGVtYToKICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgYXBpVmVyc2lvbjoKICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICBraW5kOgogICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgIG1ldGFkYXRhOgogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgIHNwZWM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgYXJnczoKICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgIGJ1aWxkOgogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYXJnczoKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgZG9ja2VyZmlsZToKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgZW5naW5lOgogICAgICAgICAgICAgICAgICAgIGVudW06CiAgICAgICAgICAgICAgICAgICAgLSBrYW5pa28KICAgICAgICAgICAgICAgICAgICAtIGJ1aWxka2l0CiAgICAgICAgICAgICAgICAgICAgLSBidWlsZHBhY2tzCiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgIGV4Y2x1ZGU6CiAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICBpbWFnZToKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgaW5jbHVkZToKICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgIHBsYXRmb3JtOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgIGNvbmZpZzoKICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgIGxpZmVjeWNsZToKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBwb3N0U3RhcnQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGV4ZWM6CiAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICBodHRwR2V0OgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICBodHRwSGVhZGVyczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB2YWx1ZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSBuYW1lCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdmFsdWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgc2NoZW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICBzbGVlcDoKICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICBzZWNvbmRzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQ2NAogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgLSBzZWNvbmRzCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgdGNwU29ja2V0OgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBwcmVTdG9wOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBleGVjOgogICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIGNvbW1hbmQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgaHR0cEdldDoKICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICBob3N0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgaHR0cEhlYWRlcnM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdmFsdWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHZhbHVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgICAgIHBhdGg6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICAgIHNjaGVtZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgc2xlZXA6CiAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgc2Vjb25kczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gc2Vjb25kcwogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgIHRjcFNvY2tldDoKICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICBob3N0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgc3RvcFNpZ25hbDoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgIGxpdmVuZXNzUHJvYmU6CiAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgZXhlYzoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBmYWlsdXJlVGhyZXNob2xkOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIGdycGM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgc2VydmljZToKICAgICAgICAgICAgICAgICAgICAgICAgZGVmYXVsdDogIiIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGh0dHBHZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgaHR0cEhlYWRlcnM6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHZhbHVlOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAtIG5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAtIHZhbHVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICBzY2hlbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBpbml0aWFsRGVsYXlTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHBlcmlvZFNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgc3VjY2Vzc1RocmVzaG9sZDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0Y3BTb2NrZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICB0ZXJtaW5hdGlvbkdyYWNlUGVyaW9kU2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0aW1lb3V0U2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgbmV0d29yazoKICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgIGluZ3Jlc3M6CiAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgYW5ub3RhdGlvbnM6CiAgICAgICAgICAgICAgICAgICAgICAgIGFkZGl0aW9uYWxQcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgIGNsYXNzTmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBlbmFibGVkOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgIHRsczoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGhvc3RzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgICBzZWNyZXROYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgc2VydmljZToKICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBhbm5vdGF0aW9uczoKICAgICAgICAgICAgICAgICAgICAgICAgYWRkaXRpb25hbFByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgZW5hYmxlZDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYm9vbGVhbgogICAgICAgICAgICAgICAgICAgICAgZXh0ZXJuYWxOYW1lOgogICAgICAgICAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHBvcnRzOgogICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgbmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICBub2RlUG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHByb3RvY29sOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAtIG5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYXJyYXkKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgcmVhZGluZXNzUHJvYmU6CiAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgZXhlYzoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgY29tbWFuZDoKICAgICAgICAgICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBmYWlsdXJlVGhyZXNob2xkOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIGdycGM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgc2VydmljZToKICAgICAgICAgICAgICAgICAgICAgICAgZGVmYXVsdDogIiIKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgLSBwb3J0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIGh0dHBHZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgaHR0cEhlYWRlcnM6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHZhbHVlOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAtIG5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAtIHZhbHVlCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGFycmF5CiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBhbnlPZjoKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1pbnQtb3Itc3RyaW5nOiB0cnVlCiAgICAgICAgICAgICAgICAgICAgICBzY2hlbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBpbml0aWFsRGVsYXlTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHBlcmlvZFNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgc3VjY2Vzc1RocmVzaG9sZDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0Y3BTb2NrZXQ6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGhvc3Q6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICB0ZXJtaW5hdGlvbkdyYWNlUGVyaW9kU2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0aW1lb3V0U2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgcmVwbGljYXM6CiAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgcmVzb3VyY2VzOgogICAgICAgICAgICAgICAgYWRkaXRpb25hbFByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgcGF0dGVybjogXihcK3wtKT8oKFswLTldKyhcLlswLTldKik/KXwoXC5bMC05XSspKSgoW0tNR1RQRV1pKXxbbnVta01HVFBFXXwoW2VFXShcK3wtKT8oKFswLTldKyhcLlswLTldKik/KXwoXC5bMC05XSspKSkpPyQKICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgIHJldmlzaW9uOgogICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgc2VjdXJpdHlDb250ZXh0OgogICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgIGFsbG93UHJpdmlsZWdlRXNjYWxhdGlvbjoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgIGFwcEFybW9yUHJvZmlsZToKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgbG9jYWxob3N0UHJvZmlsZToKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICB0eXBlOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAtIHR5cGUKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgY2FwYWJpbGl0aWVzOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBhZGQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgIGRyb3A6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgcHJpdmlsZWdlZDoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgIHByb2NNb3VudDoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgcmVhZE9ubHlSb290RmlsZXN5c3RlbToKICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgIHJ1bkFzR3JvdXA6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQ2NAogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgcnVuQXNOb25Sb290OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcnVuQXNVc2VyOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHNlTGludXhPcHRpb25zOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBsZXZlbDoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICByb2xlOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgdXNlcjoKICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgIHNlY2NvbXBQcm9maWxlOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBsb2NhbGhvc3RQcm9maWxlOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gdHlwZQogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICB3aW5kb3dzT3B0aW9uczoKICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgZ21zYUNyZWRlbnRpYWxTcGVjOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIGdtc2FDcmVkZW50aWFsU3BlY05hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgaG9zdFByb2Nlc3M6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgICAgIHJ1bkFzVXNlck5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgc3RhcnR1cFByb2JlOgogICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgIGV4ZWM6CiAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgIGNvbW1hbmQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgZmFpbHVyZVRocmVzaG9sZDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICBncnBjOgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBwb3J0OgogICAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgIHNlcnZpY2U6CiAgICAgICAgICAgICAgICAgICAgICAgIGRlZmF1bHQ6ICIiCiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgIC0gcG9ydAogICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICBodHRwR2V0OgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBob3N0OgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIGh0dHBIZWFkZXJzOgogICAgICAgICAgICAgICAgICAgICAgICBpdGVtczoKICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgbmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICB2YWx1ZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgLSBuYW1lCiAgICAgICAgICAgICAgICAgICAgICAgICAgLSB2YWx1ZQogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbGlzdC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgIHBhdGg6CiAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgcG9ydDoKICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtaW50LW9yLXN0cmluZzogdHJ1ZQogICAgICAgICAgICAgICAgICAgICAgc2NoZW1lOgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgaW5pdGlhbERlbGF5U2Vjb25kczoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICBwZXJpb2RTZWNvbmRzOgogICAgICAgICAgICAgICAgICAgIGZvcm1hdDogaW50MzIKICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgIHN1Y2Nlc3NUaHJlc2hvbGQ6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgdGNwU29ja2V0OgogICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICBob3N0OgogICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgIHBvcnQ6CiAgICAgICAgICAgICAgICAgICAgICAgIGFueU9mOgogICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgLSB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAtIHBvcnQKICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgdGVybWluYXRpb25HcmFjZVBlcmlvZFNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQ2NAogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgdGltZW91dFNlY29uZHM6CiAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBpbnQzMgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgIHZhcnM6CiAgICAgICAgICAgICAgICBudWxsYWJsZTogdHJ1ZQogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgZW52OgogICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgbmFtZToKICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgdmFsdWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgIHZhbHVlRnJvbToKICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgY29uZmlnTWFwS2V5UmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGtleToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBkZWZhdWx0OiAiIgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgb3B0aW9uYWw6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIGtleQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLW1hcC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgICAgIGZpZWxkUmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGFwaVZlcnNpb246CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBmaWVsZFBhdGg6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gZmllbGRQYXRoCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbWFwLXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgICAgICAgZmlsZUtleVJlZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBrZXk6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBvcHRpb25hbDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGRlZmF1bHQ6IGZhbHNlCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHZvbHVtZU5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0ga2V5CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gcGF0aAogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHZvbHVtZU5hbWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1tYXAtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXNvdXJjZUZpZWxkUmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGNvbnRhaW5lck5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBkaXZpc29yOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgYW55T2Y6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAtIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIC0gdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBwYXR0ZXJuOiBeKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKChbS01HVFBFXWkpfFtudW1rTUdUUEVdfChbZUVdKFwrfC0pPygoWzAtOV0rKFwuWzAtOV0qKT8pfChcLlswLTldKykpKSk/JAogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWludC1vci1zdHJpbmc6IHRydWUKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXNvdXJjZToKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSByZXNvdXJjZQogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLW1hcC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgICAgIHNlY3JldEtleVJlZjoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBrZXk6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgZGVmYXVsdDogIiIKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIG9wdGlvbmFsOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogYm9vbGVhbgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgLSBrZXkKICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1tYXAtdHlwZTogYXRvbWljCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgICAgICAgIC0gbmFtZQogICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgICBlbnZGcm9tOgogICAgICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgY29uZmlnTWFwUmVmOgogICAgICAgICAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICBuYW1lOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICBkZWZhdWx0OiAiIgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG9wdGlvbmFsOgogICAgICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBib29sZWFuCiAgICAgICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLW1hcC10eXBlOiBhdG9taWMKICAgICAgICAgICAgICAgICAgICAgICAgcHJlZml4OgogICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICBzZWNyZXRSZWY6CiAgICAgICAgICAgICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgICAgICAgICAgIG5hbWU6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIGRlZmF1bHQ6ICIiCiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgICAgICAgICAgb3B0aW9uYWw6CiAgICAgICAgICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICAgICAgICAgICAgICB4LWt1YmVybmV0ZXMtbWFwLXR5cGU6IGF0b21pYwogICAgICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICAgICAgbnVsbGFibGU6IHRydWUKICAgICAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgd29ya2luZ0RpcjoKICAgICAgICAgICAgICAgIG51bGxhYmxlOiB0cnVlCiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgIC0gcmV2aXNpb24KICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICBzdGF0dXM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgYnVpbGRMb2c6CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBjb25kaXRpb25zOgogICAgICAgICAgICAgICAgaXRlbXM6CiAgICAgICAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgICAgICAgbGFzdFRyYW5zaXRpb25UaW1lOgogICAgICAgICAgICAgICAgICAgICAgZm9ybWF0OiBkYXRlLXRpbWUKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIG1lc3NhZ2U6CiAgICAgICAgICAgICAgICAgICAgICBtYXhMZW5ndGg6IDMyNzY4CiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICBvYnNlcnZlZEdlbmVyYXRpb246CiAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDY0CiAgICAgICAgICAgICAgICAgICAgICBtaW5pbXVtOiAwCiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBpbnRlZ2VyCiAgICAgICAgICAgICAgICAgICAgcmVhc29uOgogICAgICAgICAgICAgICAgICAgICAgbWF4TGVuZ3RoOiAxMDI0CiAgICAgICAgICAgICAgICAgICAgICBtaW5MZW5ndGg6IDEKICAgICAgICAgICAgICAgICAgICAgIHBhdHRlcm46IF5bQS1aYS16XShbQS1aYS16MC05Xyw6XSpbQS1aYS16MC05X10pPyQKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHN0YXR1czoKICAgICAgICAgICAgICAgICAgICAgIGVudW06CiAgICAgICAgICAgICAgICAgICAgICAtICJUcnVlIgogICAgICAgICAgICAgICAgICAgICAgLSAiRmFsc2UiCiAgICAgICAgICAgICAgICAgICAgICAtIFVua25vd24KICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICAgIHR5cGU6CiAgICAgICAgICAgICAgICAgICAgICBtYXhMZW5ndGg6IDMxNgogICAgICAgICAgICAgICAgICAgICAgcGF0dGVybjogXihbYS16MC05XShbLWEtejAtOV0qW2EtejAtOV0pPyhcLlthLXowLTldKFstYS16MC05XSpbYS16MC05XSk/KSovKT8oKFtBLVphLXowLTldWy1BLVphLXowLTlfLl0qKT9bQS1aYS16MC05XSkkCiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgIC0gbGFzdFRyYW5zaXRpb25UaW1lCiAgICAgICAgICAgICAgICAgIC0gbWVzc2FnZQogICAgICAgICAgICAgICAgICAtIHJlYXNvbgogICAgICAgICAgICAgICAgICAtIHN0YXR1cwogICAgICAgICAgICAgICAgICAtIHR5cGUKICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgICAgeC1rdWJlcm5ldGVzLWxpc3QtbWFwLWtleXM6CiAgICAgICAgICAgICAgICAtIHR5cGUKICAgICAgICAgICAgICAgIHgta3ViZXJuZXRlcy1saXN0LXR5cGU6IG1hcAogICAgICAgICAgICAgIGRlcGxveWVkUmV2aXNpb246CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBleHBlY3RlZFJldmlzaW9uOgogICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgaGlzdG9yeToKICAgICAgICAgICAgICAgIGl0ZW1zOgogICAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICAgIGFyY2hpdmU6CiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICBidWlsZENvbXBsZXRlZDoKICAgICAgICAgICAgICAgICAgICAgIGZvcm1hdDogZGF0ZS10aW1lCiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICBidWlsZFN0YXJ0ZWQ6CiAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGRhdGUtdGltZQogICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgZGVwbG95ZWQ6CiAgICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGRhdGUtdGltZQogICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgZGlnZXN0OgogICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgaW1hZ2U6CiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICBvdXRjb21lOgogICAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgICAgcmV2aXNpb246CiAgICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgICByb2xsYmFjazoKICAgICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcmVxdWlyZWQ6CiAgICAgICAgICAgICAgICAgIC0gcmV2aXNpb24KICAgICAgICAgICAgICAgICAgdHlwZTogb2JqZWN0CiAgICAgICAgICAgICAgICB0eXBlOiBhcnJheQogICAgICAgICAgICAgIGxhc3RVcGRhdGVkOgogICAgICAgICAgICAgICAgZm9ybWF0OiBkYXRlLXRpbWUKICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgIG9ic2VydmVkR2VuZXJhdGlvbjoKICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICByZWFzb246CiAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICBzZXF1ZW5jZToKICAgICAgICAgICAgICAgIGZvcm1hdDogaW50NjQKICAgICAgICAgICAgICAgIHR5cGU6IGludGVnZXIKICAgICAgICAgICAgICBzdGFnZToKICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICB0eXBlOiBvYmplY3QKICAgIHNlcnZlZDogdHJ1ZQogICAgc3RvcmFnZTogdHJ1ZQogICAgc3VicmVzb3VyY2VzOgogICAgICBzdGF0dXM6IHt9Ci0tLQphcGlWZXJzaW9uOiBhcGlleHRlbnNpb25zLms4cy5pby92MQpraW5kOiBDdXN0b21SZXNvdXJjZURlZmluaXRpb24KbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjb250cm9sbGVyLWdlbi5rdWJlYnVpbGRlci5pby92ZXJzaW9uOiB2MC4xNi4xCiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBlbnZpcm9ubWVudGNvbmZpZ3Muc2Vhd2F5LmN0eC5zaApzcGVjOgogIGdyb3VwOiBzZWF3YXkuY3R4LnNoCiAgbmFtZXM6CiAgICBraW5kOiBFbnZpcm9ubWVudENvbmZpZwogICAgbGlzdEtpbmQ6IEVudmlyb25tZW50Q29uZmlnTGlzdAogICAgcGx1cmFsOiBlbnZpcm9ubWVudGNvbmZpZ3MKICAgIHNob3J0TmFtZXM6CiAgICAtIGVjb25mCiAgICBzaW5ndWxhcjogZW52aXJvbm1lbnRjb25maWcKICBzY29wZTogTmFtZXNwYWNlZAogIHZlcnNpb25zOgogIC0gYWRkaXRpb25hbFByaW50ZXJDb2x1bW5zOgogICAgLSBqc29uUGF0aDogLm1ldGFkYXRhLmNyZWF0aW9uVGltZXN0YW1wCiAgICAgIG5hbWU6IEFnZQogICAgICB0eXBlOiBkYXRlCiAgICBuYW1lOiB2MWJldGExCiAgICBzY2hlbWE6CiAgICAgIG9wZW5BUElWM1NjaGVtYToKICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgYXBpVmVyc2lvbjoKICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICBraW5kOgogICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgIG1ldGFkYXRhOgogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgIHNwZWM6CiAgICAgICAgICAgIHByb3BlcnRpZXM6CiAgICAgICAgICAgICAgcmVnaXN0cnk6CiAgICAgICAgICAgICAgICBwcm9wZXJ0aWVzOgogICAgICAgICAgICAgICAgICBub2RlUG9ydDoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB1cmw6CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICByZXF1aXJlZDoKICAgICAgICAgICAgICAgIC0gbm9kZVBvcnQKICAgICAgICAgICAgICAgIC0gdXJsCiAgICAgICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICAgICAgICBzdG9yYWdlOgogICAgICAgICAgICAgICAgcHJvcGVydGllczoKICAgICAgICAgICAgICAgICAgYnVja2V0OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICBjcmVkZW50aWFsczoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgZW5kcG9pbnQ6CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgIGZvcmNlUGF0aFN0eWxlOgogICAgICAgICAgICAgICAgICAgIHR5cGU6IGJvb2xlYW4KICAgICAgICAgICAgICAgICAgcGF0aDoKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgICAgcHJlZml4OgogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICByZWdpb246CiAgICAgICAgICAgICAgICAgICAgdHlwZTogc3RyaW5nCiAgICAgICAgICAgICAgICAgIHJldGVudGlvbjoKICAgICAgICAgICAgICAgICAgICBmb3JtYXQ6IGludDMyCiAgICAgICAgICAgICAgICAgICAgbWF4aW11bTogMTAKICAgICAgICAgICAgICAgICAgICBtaW5pbXVtOiAxCiAgICAgICAgICAgICAgICAgICAgdHlwZTogaW50ZWdlcgogICAgICAgICAgICAgICAgICB0eXBlOgogICAgICAgICAgICAgICAgICAgIGVudW06CiAgICAgICAgICAgICAgICAgICAgLSBzMwogICAgICAgICAgICAgICAgICAgIC0gZ2NzCiAgICAgICAgICAgICAgICAgICAgLSBhenVyZQogICAgICAgICAgICAgICAgICAgIC0gZmlsZXN5c3RlbQogICAgICAgICAgICAgICAgICAgIHR5cGU6IHN0cmluZwogICAgICAgICAgICAgICAgICB2b2x1bWVDbGFpbToKICAgICAgICAgICAgICAgICAgICB0eXBlOiBzdHJpbmcKICAgICAgICAgICAgICAgIHJlcXVpcmVkOgogICAgICAgICAgICAgICAgLSBidWNrZXQKICAgICAgICAgICAgICAgIHR5cGU6IG9iamVjdAogICAgICAgICAgICB0eXBlOiBvYmplY3QKICAgICAgICB0eXBlOiBvYmplY3QKICAgIHNlcnZlZDogdHJ1ZQogICAgc3RvcmFnZTogdHJ1ZQogICAgc3VicmVzb3VyY2VzOiB7fQo=`
 
 // Generated YAML for the controller installation.
 var controllerYaml = `
-YXBpVmVyc2lvbjogdjEKa2luZDogTmFtZXNwYWNlCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogc2Vhd2F5LXN5c3RlbQotLS0KYXBpVmVyc2lvbjogcmJhYy5hdXRob3JpemF0aW9uLms4cy5pby92MQpraW5kOiBDbHVzdGVyUm9sZQptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIG5hbWU6IHNlYXdheS1zeXN0ZW0tcm9sZQpydWxlczoKLSBhcGlHcm91cHM6CiAgLSBhcHBzCiAgcmVzb3VyY2VzOgogIC0gZGVwbG95bWVudHMKICB2ZXJiczoKICAtIGNyZWF0ZQogIC0gZGVsZXRlCiAgLSBnZXQKICAtIGxpc3QKICAtIHBhdGNoCiAgLSB1cGRhdGUKICAtIHdhdGNoCi0gYXBpR3JvdXBzOgogIC0gYXBwcwogIHJlc291cmNlczoKICAtIGRlcGxveW1lbnRzL3N0YXR1cwogIHZlcmJzOgogIC0gZ2V0Ci0gYXBpR3JvdXBzOgogIC0gYmF0Y2gKICByZXNvdXJjZXM6CiAgLSBqb2JzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGRlbGV0ZQogIC0gZ2V0CiAgLSBsaXN0CiAgLSBwYXRjaAogIC0gdXBkYXRlCiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtIGJhdGNoCiAgcmVzb3VyY2VzOgogIC0gam9icy9zdGF0dXMKICB2ZXJiczoKICAtIGdldAotIGFwaUdyb3VwczoKICAtICIiCiAgcmVzb3VyY2VzOgogIC0gZXZlbnRzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGdldAogIC0gcGF0Y2gKICAtIHVwZGF0ZQotIGFwaUdyb3VwczoKICAtICIiCiAgcmVzb3VyY2VzOgogIC0gbmFtZXNwYWNlcwogIHZlcmJzOgogIC0gY3JlYXRlCiAgLSBnZXQKICAtIGxpc3QKICAtIHVwZGF0ZQogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSAiIgogIHJlc291cmNlczoKICAtIHNlY3JldHMKICAtIHNlcnZpY2VzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGRlbGV0ZQogIC0gZ2V0CiAgLSBsaXN0CiAgLSBwYXRjaAogIC0gdXBkYXRlCiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtICIiCiAgcmVzb3VyY2VzOgogIC0gc2VydmljZXMvc3RhdHVzCiAgdmVyYnM6CiAgLSBnZXQKLSBhcGlHcm91cHM6CiAgLSBleHRlbnNpb25zCiAgLSBuZXR3b3JraW5nLms4cy5pbwogIHJlc291cmNlczoKICAtIGluZ3Jlc3NlcwogIHZlcmJzOgogIC0gY3JlYXRlCiAgLSBkZWxldGUKICAtIGdldAogIC0gbGlzdAogIC0gcGF0Y2gKICAtIHVwZGF0ZQogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSBleHRlbnNpb25zCiAgLSBuZXR3b3JraW5nLms4cy5pbwogIHJlc291cmNlczoKICAtIGluZ3Jlc3Nlcy9zdGF0dXMKICB2ZXJiczoKICAtIGdldAotIGFwaUdyb3VwczoKICAtIHNlYXdheS5jdHguc2gKICByZXNvdXJjZXM6CiAgLSBlbnZpcm9ubWVudGNvbmZpZ3MKICB2ZXJiczoKICAtIGdldAogIC0gbGlzdAogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSBzZWF3YXkuY3R4LnNoCiAgcmVzb3VyY2VzOgogIC0gZW52aXJvbm1lbnRzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGRlbGV0ZQogIC0gZ2V0CiAgLSBsaXN0CiAgLSBwYXRjaAogIC0gdXBkYXRlCiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtIHNlYXdheS5jdHguc2gKICByZXNvdXJjZXM6CiAgLSBlbnZpcm9ubWVudHMvZmluYWxpemVycwogIHZlcmJzOgogIC0gdXBkYXRlCi0gYXBpR3JvdXBzOgogIC0gc2Vhd2F5LmN0eC5zaAogIHJlc291cmNlczoKICAtIGVudmlyb25tZW50cy9zdGF0dXMKICB2ZXJiczoKICAtIGdldAogIC0gcGF0Y2gKICAtIHVwZGF0ZQotLS0KYXBpVmVyc2lvbjogdjEKa2luZDogU2VydmljZUFjY291bnQKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBzZWF3YXktc3lzdGVtCiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCi0tLQphcGlWZXJzaW9uOiByYmFjLmF1dGhvcml6YXRpb24uazhzLmlvL3YxCmtpbmQ6IENsdXN0ZXJSb2xlQmluZGluZwptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIG5hbWU6IHNlYXdheS1zeXN0ZW0Kcm9sZVJlZjoKICBhcGlHcm91cDogcmJhYy5hdXRob3JpemF0aW9uLms4cy5pbwogIGtpbmQ6IENsdXN0ZXJSb2xlCiAgbmFtZTogc2Vhd2F5LXN5c3RlbS1yb2xlCnN1YmplY3RzOgotIGtpbmQ6IFNlcnZpY2VBY2NvdW50CiAgbmFtZTogc2Vhd2F5LXN5c3RlbQogIG5hbWVzcGFjZTogc2Vhd2F5LXN5c3RlbQotLS0KYXBpVmVyc2lvbjogY2VydC1tYW5hZ2VyLmlvL3YxCmtpbmQ6IElzc3VlcgptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGNlcnQtbWFuYWdlci5pby9pbmplY3QtY2EtZnJvbTogc2Vhd2F5LXN5c3RlbS9zZWF3YXktd2ViaG9vay1jZXJ0CiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICAgIHN0dnouaW8vYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIHN0dnouaW8vbGljZW5zZTogQXBhY2hlCiAgICBzdHZ6LmlvL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIGxhYmVsczoKICAgIGFwcC5rdWJlcm5ldGVzLmlvL25hbWU6IHNlYXdheQogIG5hbWU6IHNlYXdheS1zZWxmc2lnbmVkLWlzc3VlcgogIG5hbWVzcGFjZTogc2Vhd2F5LXN5c3RlbQpzcGVjOgogIHNlbGZTaWduZWQ6IHt9Ci0tLQphcGlWZXJzaW9uOiBjZXJ0LW1hbmFnZXIuaW8vdjEKa2luZDogQ2VydGlmaWNhdGUKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBsYWJlbHM6CiAgICBhcHAua3ViZXJuZXRlcy5pby9uYW1lOiBzZWF3YXkKICBuYW1lOiBzZWF3YXktd2ViaG9vay1jZXJ0CiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCnNwZWM6CiAgZG5zTmFtZXM6CiAgLSBzZWF3YXktd2ViaG9vay1zZXJ2aWNlLnNlYXdheS1zeXN0ZW0uc3ZjCiAgLSBzZWF3YXktd2ViaG9vay1zZXJ2aWNlLnNlYXdheS1zeXN0ZW0uc3ZjLmNsdXN0ZXIubG9jYWwKICBpc3N1ZXJSZWY6CiAgICBraW5kOiBJc3N1ZXIKICAgIG5hbWU6IHNlYXdheS1zZWxmc2lnbmVkLWlzc3VlcgogIHByaXZhdGVLZXk6CiAgICByb3RhdGlvblBvbGljeTogTmV2ZXIKICBzZWNyZXROYW1lOiBzZWF3YXktd2ViaG9vay1jZXJ0Ci0tLQphcGlWZXJzaW9uOiBhZG1pc3Npb25yZWdpc3RyYXRpb24uazhzLmlvL3YxCmtpbmQ6IE11dGF0aW5nV2ViaG9va0NvbmZpZ3VyYXRpb24KbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBzZWF3YXktd2ViaG9vawp3ZWJob29rczoKLSBhZG1pc3Npb25SZXZpZXdWZXJzaW9uczoKICAtIHYxCiAgY2xpZW50Q29uZmlnOgogICAgc2VydmljZToKICAgICAgbmFtZTogc2Vhd2F5LXdlYmhvb2stc2VydmljZQogICAgICBuYW1lc3BhY2U6IHNlYXdheS1zeXN0ZW0KICAgICAgcGF0aDogL211dGF0ZS1zZWF3YXktY3R4LXNoLXYxYmV0YTEtZW52aXJvbm1lbnQKICBmYWlsdXJlUG9saWN5OiBGYWlsCiAgbmFtZTogbWVudmlyb25tZW50LnNlYXdheS5jdHguc2gKICBydWxlczoKICAtIGFwaUdyb3VwczoKICAgIC0gc2Vhd2F5LmN0eC5zaAogICAgYXBpVmVyc2lvbnM6CiAgICAtIHYxYmV0YTEKICAgIG9wZXJhdGlvbnM6CiAgICAtIENSRUFURQogICAgLSBVUERBVEUKICAgIHJlc291cmNlczoKICAgIC0gZW52aXJvbm1lbnRzCiAgc2lkZUVmZmVjdHM6IE5vbmUKLS0tCmFwaVZlcnNpb246IGFkbWlzc2lvbnJlZ2lzdHJhdGlvbi5rOHMuaW8vdjEKa2luZDogVmFsaWRhdGluZ1dlYmhvb2tDb25maWd1cmF0aW9uCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY2VydC1tYW5hZ2VyLmlvL2luamVjdC1jYS1mcm9tOiBzZWF3YXktc3lzdGVtL3NlYXdheS13ZWJob29rLWNlcnQKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogICAgc3R2ei5pby9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgc3R2ei5pby9saWNlbnNlOiBBcGFjaGUKICAgIHN0dnouaW8vc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogc2Vhd2F5LXdlYmhvb2sKd2ViaG9va3M6Ci0gYWRtaXNzaW9uUmV2aWV3VmVyc2lvbnM6CiAgLSB2MQogIGNsaWVudENvbmZpZzoKICAgIHNlcnZpY2U6CiAgICAgIG5hbWU6IHNlYXdheS13ZWJob29rLXNlcnZpY2UKICAgICAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCiAgICAgIHBhdGg6IC92YWxpZGF0ZS1zZWF3YXktY3R4LXNoLXYxYmV0YTEtZW52aXJvbm1lbnQKICBmYWlsdXJlUG9saWN5OiBGYWlsCiAgbmFtZTogdmVudmlyb25tZW50LnNlYXdheS5jdHguc2gKICBydWxlczoKICAtIGFwaUdyb3VwczoKICAgIC0gc2Vhd2F5LmN0eC5zaAogICAgYXBpVmVyc2lvbnM6CiAgICAtIHYxYmV0YTEKICAgIG9wZXJhdGlvbnM6CiAgICAtIENSRUFURQogICAgLSBVUERBVEUKICAgIHJlc291cmNlczoKICAgIC0gZW52aXJvbm1lbnRzCiAgc2lkZUVmZmVjdHM6IE5vbmUKLS0tCmFwaVZlcnNpb246IHYxCmtpbmQ6IFNlcnZpY2UKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBsYWJlbHM6CiAgICBhcHA6IHNlYXdheQogIG5hbWU6IHNlYXdheS13ZWJob29rLXNlcnZpY2UKICBuYW1lc3BhY2U6IHNlYXdheS1zeXN0ZW0Kc3BlYzoKICBwb3J0czoKICAtIHBvcnQ6IDQ0MwogICAgcHJvdG9jb2w6IFRDUAogICAgdGFyZ2V0UG9ydDogOTQ0MwogIHNlbGVjdG9yOgogICAgYXBwOiBzZWF3YXktb3BlcmF0b3IKICB0eXBlOiBDbHVzdGVySVAKLS0tCmFwaVZlcnNpb246IGFwcHMvdjEKa2luZDogRGVwbG95bWVudAptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIGxhYmVsczoKICAgIGFwcDogc2Vhd2F5LW9wZXJhdG9yCiAgbmFtZTogc2Vhd2F5LW9wZXJhdG9yCiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCnNwZWM6CiAgcmVwbGljYXM6IDEKICBzZWxlY3RvcjoKICAgIG1hdGNoTGFiZWxzOgogICAgICBhcHA6IHNlYXdheS1vcGVyYXRvcgogIHRlbXBsYXRlOgogICAgbWV0YWRhdGE6CiAgICAgIGFubm90YXRpb25zOgogICAgICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgICAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICAgIGxhYmVsczoKICAgICAgICBhcHA6IHNlYXdheS1vcGVyYXRvcgogICAgc3BlYzoKICAgICAgY29udGFpbmVyczoKICAgICAgLSBpbWFnZTogZG9ja2VyLmlvL2N0eHNoL3NlYXdheTpsYXRlc3QKICAgICAgICBpbWFnZVB1bGxQb2xpY3k6IElmTm90UHJlc2VudAogICAgICAgIG5hbWU6IG9wZXJhdG9yCiAgICAgICAgcG9ydHM6CiAgICAgICAgLSBjb250YWluZXJQb3J0OiA5MDkwCiAgICAgICAgLSBjb250YWluZXJQb3J0OiA5NDQzCiAgICAgICAgc2VjdXJpdHlDb250ZXh0OgogICAgICAgICAgcnVuQXNHcm91cDogMAogICAgICAgICAgcnVuQXNOb25Sb290OiBmYWxzZQogICAgICAgICAgcnVuQXNVc2VyOiAwCiAgICAgICAgdm9sdW1lTW91bnRzOgogICAgICAgIC0gbW91bnRQYXRoOiAvZXRjL3dlYmhvb2svdGxzCiAgICAgICAgICBuYW1lOiB0bHMKICAgICAgICAgIHJlYWRPbmx5OiB0cnVlCiAgICAgIHNlcnZpY2VBY2NvdW50TmFtZTogc2Vhd2F5LXN5c3RlbQogICAgICB2b2x1bWVzOgogICAgICAtIG5hbWU6IHRscwogICAgICAgIHNlY3JldDoKICAgICAgICAgIHNlY3JldE5hbWU6IHNlYXdheS13ZWJob29rLWNlcnQK`
+YXBpVmVyc2lvbjogdjEKa2luZDogTmFtZXNwYWNlCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogc2Vhd2F5LXN5c3RlbQotLS0KYXBpVmVyc2lvbjogcmJhYy5hdXRob3JpemF0aW9uLms4cy5pby92MQpraW5kOiBDbHVzdGVyUm9sZQptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIG5hbWU6IHNlYXdheS1zeXN0ZW0tcm9sZQpydWxlczoKLSBhcGlHcm91cHM6CiAgLSBhcHBzCiAgcmVzb3VyY2VzOgogIC0gZGVwbG95bWVudHMKICB2ZXJiczoKICAtIGNyZWF0ZQogIC0gZGVsZXRlCiAgLSBnZXQKICAtIGxpc3QKICAtIHBhdGNoCiAgLSB1cGRhdGUKICAtIHdhdGNoCi0gYXBpR3JvdXBzOgogIC0gYXBwcwogIHJlc291cmNlczoKICAtIGRlcGxveW1lbnRzL3N0YXR1cwogIHZlcmJzOgogIC0gZ2V0Ci0gYXBpR3JvdXBzOgogIC0gYXV0aGVudGljYXRpb24uazhzLmlvCiAgcmVzb3VyY2VzOgogIC0gdG9rZW5yZXZpZXdzCiAgdmVyYnM6CiAgLSBjcmVhdGUKLSBhcGlHcm91cHM6CiAgLSBhdXRob3JpemF0aW9uLms4cy5pbwogIHJlc291cmNlczoKICAtIHN1YmplY3RhY2Nlc3NyZXZpZXdzCiAgdmVyYnM6CiAgLSBjcmVhdGUKLSBhcGlHcm91cHM6CiAgLSBiYXRjaAogIHJlc291cmNlczoKICAtIGpvYnMKICB2ZXJiczoKICAtIGNyZWF0ZQogIC0gZGVsZXRlCiAgLSBnZXQKICAtIGxpc3QKICAtIHBhdGNoCiAgLSB1cGRhdGUKICAtIHdhdGNoCi0gYXBpR3JvdXBzOgogIC0gYmF0Y2gKICByZXNvdXJjZXM6CiAgLSBqb2JzL3N0YXR1cwogIHZlcmJzOgogIC0gZ2V0Ci0gYXBpR3JvdXBzOgogIC0gIiIKICByZXNvdXJjZXM6CiAgLSBldmVudHMKICB2ZXJiczoKICAtIGNyZWF0ZQogIC0gZ2V0CiAgLSBwYXRjaAogIC0gdXBkYXRlCi0gYXBpR3JvdXBzOgogIC0gIiIKICByZXNvdXJjZXM6CiAgLSBuYW1lc3BhY2VzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGdldAogIC0gbGlzdAogIC0gdXBkYXRlCiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtICIiCiAgcmVzb3VyY2VzOgogIC0gcGVyc2lzdGVudHZvbHVtZWNsYWltcwogIC0gcG9kcwogIHZlcmJzOgogIC0gZ2V0CiAgLSBsaXN0CiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtICIiCiAgcmVzb3VyY2VzOgogIC0gcG9kcy9sb2cKICAtIHNlcnZpY2VzL3N0YXR1cwogIHZlcmJzOgogIC0gZ2V0Ci0gYXBpR3JvdXBzOgogIC0gIiIKICByZXNvdXJjZXM6CiAgLSBzZWNyZXRzCiAgLSBzZXJ2aWNlcwogIHZlcmJzOgogIC0gY3JlYXRlCiAgLSBkZWxldGUKICAtIGdldAogIC0gbGlzdAogIC0gcGF0Y2gKICAtIHVwZGF0ZQogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSBleHRlbnNpb25zCiAgLSBuZXR3b3JraW5nLms4cy5pbwogIHJlc291cmNlczoKICAtIGluZ3Jlc3NlcwogIHZlcmJzOgogIC0gY3JlYXRlCiAgLSBkZWxldGUKICAtIGdldAogIC0gbGlzdAogIC0gcGF0Y2gKICAtIHVwZGF0ZQogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSBleHRlbnNpb25zCiAgLSBuZXR3b3JraW5nLms4cy5pbwogIHJlc291cmNlczoKICAtIGluZ3Jlc3Nlcy9zdGF0dXMKICB2ZXJiczoKICAtIGdldAotIGFwaUdyb3VwczoKICAtIHNlYXdheS5jdHguc2gKICByZXNvdXJjZXM6CiAgLSBlbnZpcm9ubWVudGNvbmZpZ3MKICB2ZXJiczoKICAtIGdldAogIC0gbGlzdAogIC0gd2F0Y2gKLSBhcGlHcm91cHM6CiAgLSBzZWF3YXkuY3R4LnNoCiAgcmVzb3VyY2VzOgogIC0gZW52aXJvbm1lbnRzCiAgdmVyYnM6CiAgLSBjcmVhdGUKICAtIGRlbGV0ZQogIC0gZ2V0CiAgLSBsaXN0CiAgLSBwYXRjaAogIC0gdXBkYXRlCiAgLSB3YXRjaAotIGFwaUdyb3VwczoKICAtIHNlYXdheS5jdHguc2gKICByZXNvdXJjZXM6CiAgLSBlbnZpcm9ubWVudHMvZmluYWxpemVycwogIHZlcmJzOgogIC0gdXBkYXRlCi0gYXBpR3JvdXBzOgogIC0gc2Vhd2F5LmN0eC5zaAogIHJlc291cmNlczoKICAtIGVudmlyb25tZW50cy9zdGF0dXMKICB2ZXJiczoKICAtIGdldAogIC0gcGF0Y2gKICAtIHVwZGF0ZQotLS0KYXBpVmVyc2lvbjogdjEKa2luZDogU2VydmljZUFjY291bnQKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBzZWF3YXktc3lzdGVtCiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCi0tLQphcGlWZXJzaW9uOiByYmFjLmF1dGhvcml6YXRpb24uazhzLmlvL3YxCmtpbmQ6IENsdXN0ZXJSb2xlQmluZGluZwptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIG5hbWU6IHNlYXdheS1zeXN0ZW0Kcm9sZVJlZjoKICBhcGlHcm91cDogcmJhYy5hdXRob3JpemF0aW9uLms4cy5pbwogIGtpbmQ6IENsdXN0ZXJSb2xlCiAgbmFtZTogc2Vhd2F5LXN5c3RlbS1yb2xlCnN1YmplY3RzOgotIGtpbmQ6IFNlcnZpY2VBY2NvdW50CiAgbmFtZTogc2Vhd2F5LXN5c3RlbQogIG5hbWVzcGFjZTogc2Vhd2F5LXN5c3RlbQotLS0KYXBpVmVyc2lvbjogY2VydC1tYW5hZ2VyLmlvL3YxCmtpbmQ6IElzc3VlcgptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGNlcnQtbWFuYWdlci5pby9pbmplY3QtY2EtZnJvbTogc2Vhd2F5LXN5c3RlbS9zZWF3YXktd2ViaG9vay1jZXJ0CiAgICBjdHguc2gvYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgIGN0eC5zaC9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICAgIHN0dnouaW8vYXV0aG9yczogU2Vhd2F5IEF1dGhvcnMKICAgIHN0dnouaW8vbGljZW5zZTogQXBhY2hlCiAgICBzdHZ6LmlvL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIGxhYmVsczoKICAgIGFwcC5rdWJlcm5ldGVzLmlvL25hbWU6IHNlYXdheQogIG5hbWU6IHNlYXdheS1zZWxmc2lnbmVkLWlzc3VlcgogIG5hbWVzcGFjZTogc2Vhd2F5LXN5c3RlbQpzcGVjOgogIHNlbGZTaWduZWQ6IHt9Ci0tLQphcGlWZXJzaW9uOiBjZXJ0LW1hbmFnZXIuaW8vdjEKa2luZDogQ2VydGlmaWNhdGUKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBsYWJlbHM6CiAgICBhcHAua3ViZXJuZXRlcy5pby9uYW1lOiBzZWF3YXkKICBuYW1lOiBzZWF3YXktd2ViaG9vay1jZXJ0CiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCnNwZWM6CiAgZG5zTmFtZXM6CiAgLSBzZWF3YXktd2ViaG9vay1zZXJ2aWNlLnNlYXdheS1zeXN0ZW0uc3ZjCiAgLSBzZWF3YXktd2ViaG9vay1zZXJ2aWNlLnNlYXdheS1zeXN0ZW0uc3ZjLmNsdXN0ZXIubG9jYWwKICBpc3N1ZXJSZWY6CiAgICBraW5kOiBJc3N1ZXIKICAgIG5hbWU6IHNlYXdheS1zZWxmc2lnbmVkLWlzc3VlcgogIHByaXZhdGVLZXk6CiAgICByb3RhdGlvblBvbGljeTogTmV2ZXIKICBzZWNyZXROYW1lOiBzZWF3YXktd2ViaG9vay1jZXJ0Ci0tLQphcGlWZXJzaW9uOiBhZG1pc3Npb25yZWdpc3RyYXRpb24uazhzLmlvL3YxCmtpbmQ6IE11dGF0aW5nV2ViaG9va0NvbmZpZ3VyYXRpb24KbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBuYW1lOiBzZWF3YXktd2ViaG9vawp3ZWJob29rczoKLSBhZG1pc3Npb25SZXZpZXdWZXJzaW9uczoKICAtIHYxCiAgY2xpZW50Q29uZmlnOgogICAgc2VydmljZToKICAgICAgbmFtZTogc2Vhd2F5LXdlYmhvb2stc2VydmljZQogICAgICBuYW1lc3BhY2U6IHNlYXdheS1zeXN0ZW0KICAgICAgcGF0aDogL211dGF0ZS1zZWF3YXktY3R4LXNoLXYxYmV0YTEtZW52aXJvbm1lbnQKICBmYWlsdXJlUG9saWN5OiBGYWlsCiAgbmFtZTogbWVudmlyb25tZW50LnNlYXdheS5jdHguc2gKICBydWxlczoKICAtIGFwaUdyb3VwczoKICAgIC0gc2Vhd2F5LmN0eC5zaAogICAgYXBpVmVyc2lvbnM6CiAgICAtIHYxYmV0YTEKICAgIG9wZXJhdGlvbnM6CiAgICAtIENSRUFURQogICAgLSBVUERBVEUKICAgIHJlc291cmNlczoKICAgIC0gZW52aXJvbm1lbnRzCiAgc2lkZUVmZmVjdHM6IE5vbmUKLS0tCmFwaVZlcnNpb246IGFkbWlzc2lvbnJlZ2lzdHJhdGlvbi5rOHMuaW8vdjEKa2luZDogVmFsaWRhdGluZ1dlYmhvb2tDb25maWd1cmF0aW9uCm1ldGFkYXRhOgogIGFubm90YXRpb25zOgogICAgY2VydC1tYW5hZ2VyLmlvL2luamVjdC1jYS1mcm9tOiBzZWF3YXktc3lzdGVtL3NlYXdheS13ZWJob29rLWNlcnQKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogICAgc3R2ei5pby9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgc3R2ei5pby9saWNlbnNlOiBBcGFjaGUKICAgIHN0dnouaW8vc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgbmFtZTogc2Vhd2F5LXdlYmhvb2sKd2ViaG9va3M6Ci0gYWRtaXNzaW9uUmV2aWV3VmVyc2lvbnM6CiAgLSB2MQogIGNsaWVudENvbmZpZzoKICAgIHNlcnZpY2U6CiAgICAgIG5hbWU6IHNlYXdheS13ZWJob29rLXNlcnZpY2UKICAgICAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCiAgICAgIHBhdGg6IC92YWxpZGF0ZS1zZWF3YXktY3R4LXNoLXYxYmV0YTEtZW52aXJvbm1lbnQKICBmYWlsdXJlUG9saWN5OiBGYWlsCiAgbmFtZTogdmVudmlyb25tZW50LnNlYXdheS5jdHguc2gKICBydWxlczoKICAtIGFwaUdyb3VwczoKICAgIC0gc2Vhd2F5LmN0eC5zaAogICAgYXBpVmVyc2lvbnM6CiAgICAtIHYxYmV0YTEKICAgIG9wZXJhdGlvbnM6CiAgICAtIENSRUFURQogICAgLSBVUERBVEUKICAgIHJlc291cmNlczoKICAgIC0gZW52aXJvbm1lbnRzCiAgc2lkZUVmZmVjdHM6IE5vbmUKLS0tCmFwaVZlcnNpb246IHYxCmtpbmQ6IFNlcnZpY2UKbWV0YWRhdGE6CiAgYW5ub3RhdGlvbnM6CiAgICBjZXJ0LW1hbmFnZXIuaW8vaW5qZWN0LWNhLWZyb206IHNlYXdheS1zeXN0ZW0vc2Vhd2F5LXdlYmhvb2stY2VydAogICAgY3R4LnNoL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBjdHguc2gvbGljZW5zZTogQXBhY2hlCiAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICBzdHZ6LmlvL2F1dGhvcnM6IFNlYXdheSBBdXRob3JzCiAgICBzdHZ6LmlvL2xpY2Vuc2U6IEFwYWNoZQogICAgc3R2ei5pby9zdXBwb3J0OiBodHRwczovL2dpdGh1Yi5jb20vY3R4c3dpdGNoL3NlYXdheS9pc3N1ZXMKICBsYWJlbHM6CiAgICBhcHA6IHNlYXdheQogIG5hbWU6IHNlYXdheS13ZWJob29rLXNlcnZpY2UKICBuYW1lc3BhY2U6IHNlYXdheS1zeXN0ZW0Kc3BlYzoKICBwb3J0czoKICAtIHBvcnQ6IDQ0MwogICAgcHJvdG9jb2w6IFRDUAogICAgdGFyZ2V0UG9ydDogOTQ0MwogIHNlbGVjdG9yOgogICAgYXBwOiBzZWF3YXktb3BlcmF0b3IKICB0eXBlOiBDbHVzdGVySVAKLS0tCmFwaVZlcnNpb246IGFwcHMvdjEKa2luZDogRGVwbG95bWVudAptZXRhZGF0YToKICBhbm5vdGF0aW9uczoKICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgY3R4LnNoL2xpY2Vuc2U6IEFwYWNoZQogICAgY3R4LnNoL3N1cHBvcnQ6IGh0dHBzOi8vZ2l0aHViLmNvbS9jdHhzd2l0Y2gvc2Vhd2F5L2lzc3VlcwogIGxhYmVsczoKICAgIGFwcDogc2Vhd2F5LW9wZXJhdG9yCiAgbmFtZTogc2Vhd2F5LW9wZXJhdG9yCiAgbmFtZXNwYWNlOiBzZWF3YXktc3lzdGVtCnNwZWM6CiAgcmVwbGljYXM6IDEKICBzZWxlY3RvcjoKICAgIG1hdGNoTGFiZWxzOgogICAgICBhcHA6IHNlYXdheS1vcGVyYXRvcgogIHRlbXBsYXRlOgogICAgbWV0YWRhdGE6CiAgICAgIGFubm90YXRpb25zOgogICAgICAgIGN0eC5zaC9hdXRob3JzOiBTZWF3YXkgQXV0aG9ycwogICAgICAgIGN0eC5zaC9saWNlbnNlOiBBcGFjaGUKICAgICAgICBjdHguc2gvc3VwcG9ydDogaHR0cHM6Ly9naXRodWIuY29tL2N0eHN3aXRjaC9zZWF3YXkvaXNzdWVzCiAgICAgIGxhYmVsczoKICAgICAgICBhcHA6IHNlYXdheS1vcGVyYXRvcgogICAgc3BlYzoKICAgICAgY29udGFpbmVyczoKICAgICAgLSBpbWFnZTogZG9ja2VyLmlvL2N0eHNoL3NlYXdheTpsYXRlc3QKICAgICAgICBpbWFnZVB1bGxQb2xpY3k6IElmTm90UHJlc2VudAogICAgICAgIG5hbWU6IG9wZXJhdG9yCiAgICAgICAgcG9ydHM6CiAgICAgICAgLSBjb250YWluZXJQb3J0OiA5MDkwCiAgICAgICAgLSBjb250YWluZXJQb3J0OiA5NDQzCiAgICAgICAgc2VjdXJpdHlDb250ZXh0OgogICAgICAgICAgcnVuQXNHcm91cDogMAogICAgICAgICAgcnVuQXNOb25Sb290OiBmYWxzZQogICAgICAgICAgcnVuQXNVc2VyOiAwCiAgICAgICAgdm9sdW1lTW91bnRzOgogICAgICAgIC0gbW91bnRQYXRoOiAvZXRjL3dlYmhvb2svdGxzCiAgICAgICAgICBuYW1lOiB0bHMKICAgICAgICAgIHJlYWRPbmx5OiB0cnVlCiAgICAgIHNlcnZpY2VBY2NvdW50TmFtZTogc2Vhd2F5LXN5c3RlbQogICAgICB2b2x1bWVzOgogICAgICAtIG5hbWU6IHRscwogICAgICAgIHNlY3JldDoKICAgICAgICAgIHNlY3JldE5hbWU6IHNlYXdheS13ZWJob29rLWNlcnQK`
 
 // Generated YAML for a simple localstack installation.
 var localstackYaml = `
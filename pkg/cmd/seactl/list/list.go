@@ -0,0 +1,143 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/util"
+	"ctx.sh/seaway/pkg/console"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ShortRevision is the number of characters of the revisions that are shown.
+const ShortRevision = 12
+
+type Command struct {
+	Namespace string
+	Selector  string
+	SyncedBy  string
+	Mine      bool
+}
+
+// RunE is the main function for the list command.  Unlike the other commands it
+// doesn't need a manifest, it lists every environment the user is allowed to see.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+	ctx := cmd.Context()
+
+	if len(args) != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, " "))
+	}
+
+	client, err := kube.NewKubectlCmd("", kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	syncedBy := c.SyncedBy
+	if c.Mine {
+		syncedBy, err = client.Username(ctx)
+		if err != nil {
+			console.Fatal("Unable to determine the current user: %s", err)
+		}
+	}
+
+	list := &v1beta1.EnvironmentList{}
+	list.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind("EnvironmentList"))
+	err = client.List(ctx, list, c.Namespace, metav1.ListOptions{
+		LabelSelector: c.Selector,
+	})
+	if err != nil {
+		console.Fatal("Unable to list environments: %s", err)
+	}
+
+	envs := filter(list.Items, syncedBy)
+	if len(envs) == 0 {
+		console.Info("No environments found")
+		return nil
+	}
+
+	return write(os.Stdout, envs, time.Now())
+}
+
+// filter returns the environments last synced by the user, or all of them if user is
+// empty, sorted by namespace and name.
+func filter(items []v1beta1.Environment, user string) []v1beta1.Environment {
+	envs := make([]v1beta1.Environment, 0, len(items))
+	for _, env := range items {
+		if user == "" || env.GetSyncedBy() == user {
+			envs = append(envs, env)
+		}
+	}
+
+	sort.Slice(envs, func(i, j int) bool {
+		if envs[i].Namespace != envs[j].Namespace {
+			return envs[i].Namespace < envs[j].Namespace
+		}
+		return envs[i].Name < envs[j].Name
+	})
+
+	return envs
+}
+
+func write(out io.Writer, envs []v1beta1.Environment, now time.Time) error {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tSTAGE\tEXPECTED\tDEPLOYED\tAGE\tSYNCED BY")
+	for i := range envs {
+		env := &envs[i]
+
+		synced := "-"
+		if user := env.GetSyncedBy(); user != "" {
+			synced = user
+			if at := env.GetSyncedAt(); !at.IsZero() {
+				synced += " (" + util.Age(at, now) + " ago)"
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			env.Namespace,
+			env.Name,
+			env.GetStageString(),
+			short(env.Status.ExpectedRevision),
+			short(env.Status.DeployedRevision),
+			util.Age(env.CreationTimestamp.Time, now),
+			synced,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// short truncates content digests, shorter revisions are shown as they are.
+func short(revision string) string {
+	switch {
+	case revision == "":
+		return "-"
+	case len(revision) > ShortRevision:
+		return revision[:ShortRevision]
+	}
+
+	return revision
+}
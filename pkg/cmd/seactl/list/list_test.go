@@ -0,0 +1,86 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package list
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func environment(ns, name, user string, created time.Time) v1beta1.Environment {
+	env := v1beta1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         ns,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	if user != "" {
+		env.SetSyncedBy(user, created)
+	}
+
+	return env
+}
+
+func TestFilter(t *testing.T) {
+	now := time.Now()
+	items := []v1beta1.Environment{
+		environment("team-b", "api", "bob", now),
+		environment("team-a", "web", "alice", now),
+		environment("team-a", "api", "alice", now),
+		environment("team-a", "worker", "", now),
+	}
+
+	names := func(envs []v1beta1.Environment) []string {
+		out := make([]string, 0, len(envs))
+		for _, env := range envs {
+			out = append(out, env.Namespace+"/"+env.Name)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"team-a/api", "team-a/web", "team-a/worker", "team-b/api"}, names(filter(items, "")))
+	assert.Equal(t, []string{"team-a/api", "team-a/web"}, names(filter(items, "alice")))
+	assert.Empty(t, filter(items, "carol"))
+}
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	digest := "3f6a8d0c4b1e2a7f9c5d8e0b1a2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c"
+
+	synced := environment("dev", "api", "alice", now.Add(-2*time.Hour))
+	synced.Status = v1beta1.EnvironmentStatus{
+		Stage:            v1beta1.EnvironmentStageDeployed,
+		ExpectedRevision: digest,
+		DeployedRevision: "1",
+	}
+	synced.SetSyncedBy("alice", now.Add(-5*time.Minute))
+	pending := environment("dev", "web", "", now.Add(-30*time.Second))
+
+	var buf bytes.Buffer
+	require.NoError(t, write(&buf, []v1beta1.Environment{synced, pending}, now))
+
+	expected := "" +
+		"NAMESPACE  NAME  STAGE              EXPECTED      DEPLOYED  AGE  SYNCED BY\n" +
+		"dev        api   Revision deployed  3f6a8d0c4b1e  1         2h   alice (5m ago)\n" +
+		"dev        web   Initializing       -             -         30s  -\n"
+	assert.Equal(t, expected, buf.String())
+}
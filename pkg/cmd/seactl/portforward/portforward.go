@@ -0,0 +1,200 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/util"
+	"ctx.sh/seaway/pkg/console"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/portforward"
+)
+
+// ReconnectDelay is the time to wait before reconnecting after the connection to a
+// pod that is still ready was lost.
+const ReconnectDelay = 2 * time.Second
+
+type Command struct{}
+
+// RunE is the main function for the port-forward command.  It forwards the ports to
+// a ready application pod and moves the forward to a new pod whenever the current one
+// goes away, for example when a new revision rolls out.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if len(args) < 1 {
+		return fmt.Errorf("expected environment name and optional ports")
+	}
+
+	for _, port := range args[1:] {
+		if err := validatePort(port); err != nil {
+			return err
+		}
+	}
+
+	var manifest v1beta1.Manifest
+	err := manifest.Load("manifest.yaml")
+	if err != nil {
+		console.Fatal("Unable to load manifest")
+	}
+
+	env, err := manifest.GetEnvironment(args[0])
+	if err != nil {
+		console.Fatal("Build environment '%s' not found in the manifest", args[0])
+	}
+
+	ports := args[1:]
+	if len(ports) == 0 {
+		client, err := kube.NewKubectlCmd("", kubeContext)
+		if err != nil {
+			console.Fatal(err.Error())
+		}
+
+		obj := util.GetEnvironment(manifest.Name, env.Namespace)
+		if err := client.Get(ctx, obj, metav1.GetOptions{}); err != nil {
+			console.Fatal("Unable to get environment: %s", err)
+		}
+
+		ports = servicePorts(obj)
+		if len(ports) == 0 {
+			console.Fatal("The environment has no TCP service ports, pass the ports to forward instead")
+		}
+	}
+
+	exec, err := kube.NewPodExec(kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	labels := fmt.Sprintf("app=%s,group=application", manifest.Name)
+	if err := forward(ctx, exec, env.Namespace, labels, ports); err != nil {
+		console.Fatal(err.Error())
+	}
+
+	return nil
+}
+
+// forward keeps the ports forwarded to a ready pod until the context is done.  Errors
+// before the ports were ever bound, like a local port that is already in use, are
+// returned instead of retried.
+func forward(ctx context.Context, exec *kube.PodExec, ns, labels string, ports []string) error {
+	var bound atomic.Bool
+	for {
+		pod, err := readyPod(ctx, exec, ns, labels)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		podCtx, cancel := context.WithCancel(ctx)
+		go func() {
+			if exec.WaitUntilUnavailable(podCtx, ns, pod.Name) == nil {
+				cancel()
+			}
+		}()
+
+		err = exec.PortForward(podCtx, ns, pod.Name, ports, os.Stderr, func(forwarded []portforward.ForwardedPort) {
+			bound.Store(true)
+			console.Info("Forwarding to %s", pod.Name)
+			for _, p := range forwarded {
+				console.ListItem("localhost:%d -> %d", p.Local, p.Remote)
+			}
+		})
+		cancel()
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil && !bound.Load():
+			return err
+		case err != nil:
+			console.ListWarning("Lost connection to %s: %s", pod.Name, err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(ReconnectDelay):
+			}
+		default:
+			console.ListWarning("%s is no longer available", pod.Name)
+		}
+	}
+}
+
+// readyPod returns a ready pod, waiting for one if there isn't one yet.
+func readyPod(ctx context.Context, exec *kube.PodExec, ns, labels string) (*corev1.Pod, error) {
+	pod, err := exec.ReadyPod(ctx, ns, labels)
+	if err != nil || pod != nil {
+		return pod, err
+	}
+
+	console.Info("Waiting for a ready pod")
+	return exec.WaitForReadyPod(ctx, ns, labels)
+}
+
+// servicePorts returns the TCP ports of the environment's service, forwarded to the
+// same local ports.  Port forwarding doesn't support other protocols.  Disabled
+// services have no ports.
+func servicePorts(env *v1beta1.Environment) []string {
+	v1beta1.Defaulted(env)
+
+	ports := make([]string, 0, len(env.Spec.Network.Service.Ports))
+	for _, port := range env.Spec.Network.Service.Ports {
+		if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		ports = append(ports, fmt.Sprintf("%d:%d", port.Port, port.Port))
+	}
+
+	return ports
+}
+
+// validatePort checks that the port is in the [local]:remote form that kubectl uses.
+// An empty or zero local port picks a random one.
+func validatePort(spec string) error {
+	local, remote, found := strings.Cut(spec, ":")
+	if !found {
+		local, remote = remote, local
+	}
+
+	if local != "" {
+		if _, err := strconv.ParseUint(local, 10, 16); err != nil {
+			return fmt.Errorf("invalid local port in %q", spec)
+		}
+	}
+
+	if p, err := strconv.ParseUint(remote, 10, 16); err != nil || p == 0 {
+		return fmt.Errorf("invalid remote port in %q", spec)
+	}
+
+	return nil
+}
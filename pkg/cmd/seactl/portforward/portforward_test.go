@@ -0,0 +1,73 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package portforward
+
+import (
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidatePort(t *testing.T) {
+	tests := []struct {
+		spec  string
+		valid bool
+	}{
+		{"8080", true},
+		{"9000:8080", true},
+		{":8080", true},
+		{"0:8080", true},
+		{"8080:0", false},
+		{"http", false},
+		{"8080:http", false},
+		{"70000:8080", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			err := validatePort(tt.spec)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestServicePorts(t *testing.T) {
+	env := &v1beta1.Environment{}
+	assert.Empty(t, servicePorts(env))
+
+	env.Spec.Network = &v1beta1.EnvironmentNetwork{
+		Service: &v1beta1.EnvironmentService{Enabled: true},
+	}
+	assert.Equal(t, []string{"9000:9000"}, servicePorts(env))
+
+	env.Spec.Network = &v1beta1.EnvironmentNetwork{
+		Service: &v1beta1.EnvironmentService{
+			Enabled: true,
+			Ports: []v1beta1.EnvironmentPort{
+				{Name: "http", Port: 8080, Protocol: corev1.ProtocolTCP},
+				{Name: "metrics", Port: 9090},
+				{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			},
+		},
+	}
+	assert.Equal(t, []string{"8080:8080", "9090:9090"}, servicePorts(env))
+}
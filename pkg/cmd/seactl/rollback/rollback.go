@@ -0,0 +1,117 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/util"
+	"ctx.sh/seaway/pkg/console"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Command struct {
+	LogLevel int8
+}
+
+// RunE is the main function for the rollback command which redeploys a revision
+// from the environment's history using the image that is already in the registry.
+// If no revision is given, the last deployed revision before the current one is used.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("expected environment name and an optional revision")
+	}
+
+	var manifest v1beta1.Manifest
+	err := manifest.Load("manifest.yaml")
+	if err != nil {
+		console.Fatal("Unable to load manifest")
+	}
+
+	env, err := manifest.GetEnvironment(args[0])
+	if err != nil {
+		console.Fatal("Build environment '%s' not found in the manifest", args[0])
+	}
+
+	client, err := kube.NewKubectlCmd("", kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	obj := util.GetEnvironment(manifest.Name, env.Namespace)
+	if err := client.Get(ctx, obj, metav1.GetOptions{}); err != nil {
+		console.Fatal("Unable to get environment: %s", err)
+	}
+
+	var target *v1beta1.EnvironmentRevision
+	if len(args) == 2 {
+		target = obj.Status.FindRevision(args[1])
+		if target == nil {
+			console.Fatal("Revision '%s' was not found in the environment history", args[1])
+		}
+	} else {
+		target = obj.Status.LastDeployed(obj.GetRevision())
+		if target == nil {
+			console.Fatal("No previously deployed revision was found in the environment history")
+		}
+	}
+
+	if target.Revision == obj.GetRevision() {
+		console.Info("Revision %s is already the current revision", target.Revision)
+		return nil
+	}
+
+	console.Info("Rolling back to revision %s", target.Revision)
+	if target.Image != "" {
+		console.ListNotice("Image: %s", target.Image)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[v1beta1.RollbackAnnotation] = target.Revision
+	obj.SetAnnotations(annotations)
+	obj.Spec.Revision = target.Revision
+
+	user, err := client.Username(ctx)
+	if err != nil {
+		console.ListWarning("Unable to determine the current user: %s", err)
+	} else {
+		obj.SetSyncedBy(user, time.Now())
+	}
+
+	if err := client.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		console.Fatal("Unable to update environment: %s", err)
+	}
+
+	sclient, err := util.NewSeawayClient(env, kubeContext)
+	if err != nil {
+		console.Fatal("Unable to create the seaway client: %s", err)
+	}
+
+	return util.TrackEnvironment(ctx, sclient, manifest.Name, env.Namespace, target.Revision)
+}
@@ -15,11 +15,21 @@
 package main
 
 import (
+	"time"
+
 	"ctx.sh/seaway/pkg/build"
 	"ctx.sh/seaway/pkg/cmd/seactl/clean"
+	"ctx.sh/seaway/pkg/cmd/seactl/dev"
+	"ctx.sh/seaway/pkg/cmd/seactl/exec"
+	"ctx.sh/seaway/pkg/cmd/seactl/initialize"
 	"ctx.sh/seaway/pkg/cmd/seactl/install"
+	"ctx.sh/seaway/pkg/cmd/seactl/list"
 	"ctx.sh/seaway/pkg/cmd/seactl/logs"
+	"ctx.sh/seaway/pkg/cmd/seactl/portforward"
+	"ctx.sh/seaway/pkg/cmd/seactl/rollback"
+	"ctx.sh/seaway/pkg/cmd/seactl/status"
 	"ctx.sh/seaway/pkg/cmd/seactl/sync"
+	"ctx.sh/seaway/pkg/cmd/seactl/validate"
 	"github.com/spf13/cobra"
 )
 
@@ -37,9 +47,48 @@ const (
 	SyncShortDesc    = "Sync to the target object storage using the configuration context"
 	SyncLongDesc     = `Sync the code to the target object storage based on the configuration context
 provided in the manifest.  This will trigger a new development deployment if there was a change.`
-	CleanUsage     = "clean"
-	CleanShortDesc = "Clean all development environment resources."
-	CleanLongDesc  = `Cleans all development environment resources for the specified context.`
+	DevUsage     = "dev [environment]"
+	DevShortDesc = "Watch the build context and sync every change."
+	DevLongDesc  = `Syncs the environment, then watches the files in the build context and syncs again
+whenever they change.  Changes are debounced, and a sync that is still running when newer changes
+arrive is cancelled.  The stages of each revision and the application logs are shown inline.`
+	CleanUsage        = "clean"
+	CleanShortDesc    = "Clean all development environment resources."
+	CleanLongDesc     = `Cleans all development environment resources for the specified context.`
+	RollbackUsage     = "rollback [environment] [revision]"
+	RollbackShortDesc = "Roll back to a previously deployed revision."
+	RollbackLongDesc  = `Redeploys a revision from the environment's history using the image that is
+already in the registry.  If no revision is given, the last deployed revision before the current
+one is used.`
+	ListUsage     = "list"
+	ListShortDesc = "List the environments in the cluster."
+	ListLongDesc  = `Lists the environments in every namespace, or in the namespace given with --namespace,
+along with their stage, revisions, age and the user that last synced them.  The manifest isn't needed.`
+	PortForwardUsage     = "port-forward [environment] [[local]:remote...]"
+	PortForwardShortDesc = "Forward local ports to the application."
+	PortForwardLongDesc  = `Forwards local ports to a ready application pod.  Without ports, the service ports of
+the environment are forwarded to the same local ports.  When the pod goes away, for example during
+a rollout, the ports are forwarded to a new ready pod.`
+	ExecUsage     = "exec [environment] -- [command...]"
+	ExecShortDesc = "Run a command in the application container."
+	ExecLongDesc  = `Runs a command in the app container of a ready application pod.  A terminal is attached
+when stdin is one.  If the pod goes away while the command is running, for example during a
+rollout, the command is started again in a new ready pod.`
+	InitUsage     = "init"
+	InitShortDesc = "Generate a manifest for the project in the current directory."
+	InitLongDesc  = `Generates manifest.yaml with a local environment from the project in the current directory.
+The build context, the service ports, the working directory and the command are taken from the
+Dockerfile, kustomizations in the k8s directory become dependencies, and ignore files are noted.`
+	ValidateUsage     = "validate [manifest]"
+	ValidateShortDesc = "Check the manifest for mistakes."
+	ValidateLongDesc  = `Checks the manifest, manifest.yaml by default, against the manifest schema and reports every
+problem with its line and column: unknown or misspelled fields, values of the wrong type, invalid
+resource quantities and include or exclude patterns, duplicate service ports, and dependency paths
+that don't exist.`
+	StatusUsage     = "status [environment]"
+	StatusShortDesc = "Show the state of the environment and its resources."
+	StatusLongDesc  = `Shows the stage, the expected and deployed revisions, the readiness and restarts of
+the application pods, the service and ingress endpoints and the latest events for the environment.`
 
 	DefaultInstallCrds        = true
 	DefaultInstallCertManager = false
@@ -49,6 +98,18 @@ provided in the manifest.  This will trigger a new development deployment if the
 	DefaultSyncOnlyDeps       = false
 	DefaultSyncWithDeps       = false
 	DefaultSyncForce          = false
+	DefaultSyncFull           = false
+	DefaultSyncDryRun         = false
+	DefaultSyncChunkSize      = 64 * 1024
+	DefaultSyncPartSize       = 8 * 1024 * 1024
+	DefaultSyncParallel       = 4
+	DefaultDevDebounce        = 500 * time.Millisecond
+	DefaultDevLogs            = true
+	DefaultInitForce          = false
+	DefaultStatusEvents       = 10
+	DefaultExecContainer      = "app"
+	DefaultExecStdin          = true
+	DefaultExecTTY            = true
 )
 
 type Root struct{}
@@ -74,10 +135,18 @@ func (r *Root) Command() *cobra.Command {
 		SilenceErrors: false,
 	}
 
+	rootCmd.AddCommand(InitCommand())
 	rootCmd.AddCommand(SyncCommand())
+	rootCmd.AddCommand(ValidateCommand())
+	rootCmd.AddCommand(DevCommand())
 	rootCmd.AddCommand(CleanCommand())
 	rootCmd.AddCommand(LogsCommand())
 	rootCmd.AddCommand(InstallCommand())
+	rootCmd.AddCommand(RollbackCommand())
+	rootCmd.AddCommand(StatusCommand())
+	rootCmd.AddCommand(ListCommand())
+	rootCmd.AddCommand(PortForwardCommand())
+	rootCmd.AddCommand(ExecCommand())
 
 	rootCmd.PersistentFlags().StringP("context", "", "", "set the Kubernetes context")
 	return rootCmd
@@ -131,6 +200,31 @@ func SyncCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&s.Force, "force", "", DefaultSyncForce, "force a resync even if no changes are detected")
 	cmd.PersistentFlags().BoolVarP(&s.WithDeps, "with-deps", "", DefaultSyncWithDeps, "apply dependencies before syncing the application")
 	cmd.PersistentFlags().BoolVarP(&s.OnlyDeps, "only-deps", "", DefaultSyncOnlyDeps, "apply the dependencies without syncing the application")
+	cmd.PersistentFlags().BoolVarP(&s.Full, "full", "", DefaultSyncFull, "upload the whole build context as a single archive")
+	cmd.PersistentFlags().BoolVarP(&s.DryRun, "dry-run", "", DefaultSyncDryRun, "print the files in the build context without uploading them")
+	cmd.PersistentFlags().StringVarP(&s.Resume, "resume", "", "", "resume an interrupted archive upload with the given id")
+	cmd.PersistentFlags().IntVarP(&s.ChunkSize, "chunk-size", "", DefaultSyncChunkSize, "size in bytes of each message sent while uploading")
+	cmd.PersistentFlags().Int64VarP(&s.PartSize, "part-size", "", DefaultSyncPartSize, "size in bytes of each part of an archive upload")
+	cmd.PersistentFlags().IntVarP(&s.Parallel, "parallel", "", DefaultSyncParallel, "number of archive parts uploaded at the same time")
+	return cmd
+}
+
+func DevCommand() *cobra.Command {
+	d := dev.Command{}
+	cmd := &cobra.Command{
+		Use:   DevUsage,
+		Short: DevShortDesc,
+		Long:  DevLongDesc,
+		RunE:  d.RunE,
+	}
+
+	cmd.PersistentFlags().DurationVarP(&d.Debounce, "debounce", "", DefaultDevDebounce, "time to wait for more changes before syncing")
+	cmd.PersistentFlags().BoolVarP(&d.Logs, "logs", "", DefaultDevLogs, "stream the application logs after each deploy")
+	cmd.PersistentFlags().BoolVarP(&d.Sync.Force, "force", "", DefaultSyncForce, "recreate the environment on the first sync")
+	cmd.PersistentFlags().BoolVarP(&d.Sync.Full, "full", "", DefaultSyncFull, "upload the whole build context as a single archive")
+	cmd.PersistentFlags().IntVarP(&d.Sync.ChunkSize, "chunk-size", "", DefaultSyncChunkSize, "size in bytes of each message sent while uploading")
+	cmd.PersistentFlags().Int64VarP(&d.Sync.PartSize, "part-size", "", DefaultSyncPartSize, "size in bytes of each part of an archive upload")
+	cmd.PersistentFlags().IntVarP(&d.Sync.Parallel, "parallel", "", DefaultSyncParallel, "number of archive parts uploaded at the same time")
 	return cmd
 }
 
@@ -148,3 +242,111 @@ func CleanCommand() *cobra.Command {
 
 	return cmd
 }
+
+func RollbackCommand() *cobra.Command {
+	r := rollback.Command{}
+
+	cmd := &cobra.Command{
+		Use:   RollbackUsage,
+		Short: RollbackShortDesc,
+		Long:  RollbackLongDesc,
+		RunE:  r.RunE,
+	}
+
+	cmd.PersistentFlags().Int8VarP(&r.LogLevel, "log-level", "", DefaultLogLevel, "set the log level (integer value)")
+
+	return cmd
+}
+
+func StatusCommand() *cobra.Command {
+	s := status.Command{}
+
+	cmd := &cobra.Command{
+		Use:   StatusUsage,
+		Short: StatusShortDesc,
+		Long:  StatusLongDesc,
+		RunE:  s.RunE,
+	}
+
+	cmd.PersistentFlags().IntVarP(&s.Events, "events", "", DefaultStatusEvents, "number of recent events to show")
+
+	return cmd
+}
+
+func InitCommand() *cobra.Command {
+	i := initialize.Command{}
+
+	cmd := &cobra.Command{
+		Use:   InitUsage,
+		Short: InitShortDesc,
+		Long:  InitLongDesc,
+		RunE:  i.RunE,
+	}
+
+	cmd.PersistentFlags().StringVarP(&i.Name, "name", "", "", "name of the application, defaults to the name of the directory")
+	cmd.PersistentFlags().StringVarP(&i.Namespace, "namespace", "n", "", "namespace of the environment, defaults to the name")
+	cmd.PersistentFlags().BoolVarP(&i.Force, "force", "", DefaultInitForce, "overwrite an existing manifest")
+
+	return cmd
+}
+
+func ValidateCommand() *cobra.Command {
+	v := validate.Command{}
+
+	cmd := &cobra.Command{
+		Use:   ValidateUsage,
+		Short: ValidateShortDesc,
+		Long:  ValidateLongDesc,
+		RunE:  v.RunE,
+	}
+
+	return cmd
+}
+
+func ListCommand() *cobra.Command {
+	l := list.Command{}
+
+	cmd := &cobra.Command{
+		Use:   ListUsage,
+		Short: ListShortDesc,
+		Long:  ListLongDesc,
+		RunE:  l.RunE,
+	}
+
+	cmd.PersistentFlags().StringVarP(&l.Namespace, "namespace", "n", "", "only list the environments in the namespace")
+	cmd.PersistentFlags().StringVarP(&l.Selector, "selector", "l", "", "label selector to filter the environments")
+	cmd.PersistentFlags().StringVarP(&l.SyncedBy, "synced-by", "", "", "only list the environments last synced by the user")
+	cmd.PersistentFlags().BoolVarP(&l.Mine, "mine", "", false, "only list the environments last synced by the current user")
+
+	return cmd
+}
+
+func PortForwardCommand() *cobra.Command {
+	p := portforward.Command{}
+
+	cmd := &cobra.Command{
+		Use:   PortForwardUsage,
+		Short: PortForwardShortDesc,
+		Long:  PortForwardLongDesc,
+		RunE:  p.RunE,
+	}
+
+	return cmd
+}
+
+func ExecCommand() *cobra.Command {
+	e := exec.Command{}
+
+	cmd := &cobra.Command{
+		Use:   ExecUsage,
+		Short: ExecShortDesc,
+		Long:  ExecLongDesc,
+		RunE:  e.RunE,
+	}
+
+	cmd.PersistentFlags().StringVarP(&e.Container, "container", "c", DefaultExecContainer, "container to run the command in")
+	cmd.PersistentFlags().BoolVarP(&e.Stdin, "stdin", "i", DefaultExecStdin, "pass stdin to the command")
+	cmd.PersistentFlags().BoolVarP(&e.TTY, "tty", "t", DefaultExecTTY, "attach a terminal when stdin is one")
+
+	return cmd
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// report holds the objects that make up an environment.  The deployment, service and
+// ingress are nil when the controller hasn't created them (yet).
+type report struct {
+	env        *v1beta1.Environment
+	deployment *appsv1.Deployment
+	pods       []corev1.Pod
+	service    *corev1.Service
+	ingress    *networkingv1.Ingress
+	events     []corev1.Event
+}
+
+// collect gets the objects the controller creates for the environment.  They share
+// the name of the environment and the application pods are selected by label.  Only
+// the latest events that involve one of the objects are kept.
+func collect(ctx context.Context, cs kubernetes.Interface, env *v1beta1.Environment, labels string, limit int) (*report, error) {
+	r := &report{env: env}
+	ns := env.GetNamespace()
+	name := env.GetName()
+
+	deployment, err := cs.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierr.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("unable to get the deployment: %w", err)
+	default:
+		r.deployment = deployment
+	}
+
+	service, err := cs.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierr.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("unable to get the service: %w", err)
+	default:
+		r.service = service
+	}
+
+	ingress, err := cs.NetworkingV1().Ingresses(ns).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierr.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("unable to get the ingress: %w", err)
+	default:
+		r.ingress = ingress
+	}
+
+	pods, err := cs.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: labels})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the pods: %w", err)
+	}
+	r.pods = pods.Items
+	sort.Slice(r.pods, func(i, j int) bool {
+		return r.pods[i].Name < r.pods[j].Name
+	})
+
+	// The replica sets carry the pod template labels, their events explain pods
+	// that were never created.
+	replicaSets, err := cs.AppsV1().ReplicaSets(ns).List(ctx, metav1.ListOptions{LabelSelector: labels})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the replica sets: %w", err)
+	}
+
+	involved := map[string]bool{
+		"Environment/" + name: true,
+		"Deployment/" + name:  true,
+		"Service/" + name:     true,
+		"Ingress/" + name:     true,
+	}
+	for _, rs := range replicaSets.Items {
+		involved["ReplicaSet/"+rs.Name] = true
+	}
+	for _, pod := range r.pods {
+		involved["Pod/"+pod.Name] = true
+	}
+
+	events, err := cs.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the events: %w", err)
+	}
+	r.events = latestEvents(events.Items, involved, limit)
+
+	return r, nil
+}
+
+// latestEvents returns up to limit of the most recent events for the involved objects,
+// oldest first.  The objects are keyed by kind and name.
+func latestEvents(events []corev1.Event, involved map[string]bool, limit int) []corev1.Event {
+	out := make([]corev1.Event, 0, len(events))
+	for _, e := range events {
+		if involved[e.InvolvedObject.Kind+"/"+e.InvolvedObject.Name] {
+			out = append(out, e)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return eventTime(out[i]).Before(eventTime(out[j]))
+	})
+
+	if limit >= 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+
+	return out
+}
+
+// eventTime returns the last time the event was seen.  Depending on the reporter
+// only some of the timestamps are set.
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	}
+
+	return e.CreationTimestamp.Time
+}
+
+// podState returns the number of ready containers, the number of containers and the
+// total number of restarts for the pod.
+func podState(pod corev1.Pod) (int, int, int32) {
+	var ready int
+	var restarts int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Ready {
+			ready++
+		}
+		restarts += cs.RestartCount
+	}
+
+	return ready, len(pod.Spec.Containers), restarts
+}
+
+// podPhase returns the phase of the pod, or the reason a container is waiting or
+// terminated which is more useful for pods that are failing to start.
+func podPhase(pod corev1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		switch {
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason != "":
+			return cs.State.Waiting.Reason
+		case cs.State.Terminated != nil && cs.State.Terminated.Reason != "":
+			return cs.State.Terminated.Reason
+		}
+	}
+
+	return string(pod.Status.Phase)
+}
+
+// serviceEndpoints returns the addresses the service can be reached at from inside
+// of the cluster.
+func serviceEndpoints(svc *corev1.Service) []string {
+	if svc.Spec.ExternalName != "" {
+		return []string{svc.Spec.ExternalName}
+	}
+
+	host := fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace)
+	out := make([]string, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		endpoint := fmt.Sprintf("%s:%d/%s", host, port.Port, port.Protocol)
+		if port.Name != "" {
+			endpoint = port.Name + " " + endpoint
+		}
+		if port.NodePort > 0 {
+			endpoint += fmt.Sprintf(" (node port %d)", port.NodePort)
+		}
+		out = append(out, endpoint)
+	}
+
+	return out
+}
+
+// ingressEndpoints returns the urls for the hosts in the rules and the TLS config, or
+// the load balancer addresses if no hosts are set.
+func ingressEndpoints(ing *networkingv1.Ingress) []string {
+	tls := make(map[string]bool)
+	for _, t := range ing.Spec.TLS {
+		for _, host := range t.Hosts {
+			tls[host] = true
+		}
+	}
+
+	hosts := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+
+	for _, rule := range ing.Spec.Rules {
+		add(rule.Host)
+	}
+	for _, t := range ing.Spec.TLS {
+		for _, host := range t.Hosts {
+			add(host)
+		}
+	}
+
+	if len(hosts) == 0 {
+		for _, lb := range ing.Status.LoadBalancer.Ingress {
+			add(lb.Hostname)
+			add(lb.IP)
+		}
+	}
+
+	out := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		scheme := "http"
+		if tls[host] {
+			scheme = "https"
+		}
+		out = append(out, scheme+"://"+host)
+	}
+
+	return out
+}
+
+// message flattens multi-line event messages to a single line.
+func message(msg string) string {
+	return strings.Join(strings.Fields(msg), " ")
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testLabels = "app=app,group=application"
+
+func meta(kind, name string) metav1.ObjectMeta {
+	m := metav1.ObjectMeta{Name: name, Namespace: "dev"}
+	if kind == "Pod" || kind == "ReplicaSet" {
+		m.Labels = map[string]string{"app": "app", "group": "application"}
+	}
+	return m
+}
+
+func event(name, kind, obj string, seen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev"},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Name:      obj,
+			Namespace: "dev",
+		},
+		Reason:        name,
+		LastTimestamp: metav1.NewTime(seen),
+	}
+}
+
+func TestCollect(t *testing.T) {
+	now := time.Now()
+	objects := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: meta("Deployment", "app")},
+		&appsv1.ReplicaSet{ObjectMeta: meta("ReplicaSet", "app-5d8f")},
+		&corev1.Pod{ObjectMeta: meta("Pod", "app-5d8f-b")},
+		&corev1.Pod{ObjectMeta: meta("Pod", "app-5d8f-a")},
+		// Pods of other applications are left out.
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-1", Namespace: "dev"}},
+		&corev1.Service{ObjectMeta: meta("Service", "app")},
+		event("scaled", "ReplicaSet", "app-5d8f", now.Add(-3*time.Minute)),
+		event("pulled", "Pod", "app-5d8f-a", now.Add(-2*time.Minute)),
+		event("deployed", "Environment", "app", now.Add(-time.Minute)),
+		event("created", "Deployment", "app", now.Add(-4*time.Minute)),
+		event("unrelated", "Pod", "other-1", now),
+	}
+
+	cs := fake.NewSimpleClientset(objects...)
+	env := &v1beta1.Environment{ObjectMeta: meta("Environment", "app")}
+
+	r, err := collect(context.Background(), cs, env, testLabels, 3)
+	require.NoError(t, err)
+
+	assert.NotNil(t, r.deployment)
+	assert.NotNil(t, r.service)
+	assert.Nil(t, r.ingress)
+
+	pods := make([]string, 0, len(r.pods))
+	for _, pod := range r.pods {
+		pods = append(pods, pod.Name)
+	}
+	assert.Equal(t, []string{"app-5d8f-a", "app-5d8f-b"}, pods)
+
+	reasons := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		reasons = append(reasons, e.Reason)
+	}
+	assert.Equal(t, []string{"scaled", "pulled", "deployed"}, reasons)
+}
+
+func TestPodState(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", Ready: false, RestartCount: 4, State: corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				}},
+				{Name: "sidecar", Ready: true, RestartCount: 1},
+			},
+		},
+	}
+
+	ready, total, restarts := podState(pod)
+	assert.Equal(t, 1, ready)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, int32(5), restarts)
+	assert.Equal(t, "CrashLoopBackOff", podPhase(pod))
+
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{}
+	assert.Equal(t, "Running", podPhase(pod))
+}
+
+func TestServiceEndpoints(t *testing.T) {
+	svc := &corev1.Service{
+		ObjectMeta: meta("Service", "app"),
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 8080, Protocol: corev1.ProtocolTCP},
+				{Port: 9090, Protocol: corev1.ProtocolUDP, NodePort: 30090},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"http app.dev.svc:8080/TCP",
+		"app.dev.svc:9090/UDP (node port 30090)",
+	}, serviceEndpoints(svc))
+
+	external := "db.example.com"
+	svc.Spec.ExternalName = external
+	assert.Equal(t, []string{external}, serviceEndpoints(svc))
+}
+
+func TestIngressEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		ingress  networkingv1.Ingress
+		expected []string
+	}{
+		{
+			name: "rules and tls hosts",
+			ingress: networkingv1.Ingress{
+				Spec: networkingv1.IngressSpec{
+					Rules: []networkingv1.IngressRule{{Host: "app.local"}, {Host: "api.local"}},
+					TLS:   []networkingv1.IngressTLS{{Hosts: []string{"api.local", "secure.local"}}},
+				},
+			},
+			expected: []string{"http://app.local", "https://api.local", "https://secure.local"},
+		},
+		{
+			name: "load balancer",
+			ingress: networkingv1.Ingress{
+				Status: networkingv1.IngressStatus{
+					LoadBalancer: networkingv1.IngressLoadBalancerStatus{
+						Ingress: []networkingv1.IngressLoadBalancerIngress{{IP: "10.0.0.1"}},
+					},
+				},
+			},
+			expected: []string{"http://10.0.0.1"},
+		},
+		{
+			name:     "no address",
+			ingress:  networkingv1.Ingress{},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ingressEndpoints(&tt.ingress))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"fmt"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/cmd/util"
+	"ctx.sh/seaway/pkg/console"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Command struct {
+	Events int
+}
+
+// RunE is the main function for the status command.  It reads the environment and the
+// objects the controller created for it and shows them in a single view.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	kubeContext := cmd.Root().Flags().Lookup("context").Value.String()
+	ctx := cmd.Context()
+
+	if len(args) != 1 {
+		return fmt.Errorf("expected environment name")
+	}
+
+	var manifest v1beta1.Manifest
+	err := manifest.Load("manifest.yaml")
+	if err != nil {
+		console.Fatal("Unable to load manifest")
+	}
+
+	env, err := manifest.GetEnvironment(args[0])
+	if err != nil {
+		console.Fatal("Build environment '%s' not found in the manifest", args[0])
+	}
+
+	client, err := kube.NewKubectlCmd("", kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	obj := util.GetEnvironment(manifest.Name, env.Namespace)
+	if err := client.Get(ctx, obj, metav1.GetOptions{}); err != nil {
+		console.Fatal("Unable to get environment: %s", err)
+	}
+
+	kc, err := kube.NewClient("", kubeContext)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	config, err := kc.ToRESTConfig()
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	labels := fmt.Sprintf("app=%s,group=application", manifest.Name)
+	r, err := collect(ctx, clientset, obj, labels, c.Events)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	r.print(time.Now())
+	return nil
+}
+
+func (r *report) print(now time.Time) {
+	env := r.env
+	status := env.Status
+
+	console.Section("%s (%s)", env.GetName(), env.GetNamespace())
+	console.Info("Stage: %s", env.GetStageString())
+	console.ListItem("Expected revision: %s", orNone(status.ExpectedRevision))
+	console.ListItem("Deployed revision: %s", orNone(status.DeployedRevision))
+	if status.Reason != "" {
+		console.ListItem("Reason: %s", status.Reason)
+	}
+	if !status.LastUpdated.IsZero() {
+		console.ListItem("Last updated: %s (%s ago)", status.LastUpdated.Format(time.RFC3339), util.Age(status.LastUpdated.Time, now))
+	}
+
+	console.Newline()
+	if r.deployment == nil {
+		console.Info("Replicas: no deployment")
+	} else {
+		var desired int32 = 1
+		if r.deployment.Spec.Replicas != nil {
+			desired = *r.deployment.Spec.Replicas
+		}
+		ds := r.deployment.Status
+		console.Info("Replicas: %d/%d ready, %d up to date, %d available", ds.ReadyReplicas, desired, ds.UpdatedReplicas, ds.AvailableReplicas)
+	}
+
+	for _, pod := range r.pods {
+		ready, total, restarts := podState(pod)
+		line := fmt.Sprintf("%s  %d/%d  %s  restarts: %d  age: %s", pod.Name, ready, total, podPhase(pod), restarts, util.Age(pod.CreationTimestamp.Time, now))
+		if ready == total && pod.Status.Phase == corev1.PodRunning {
+			console.ListSuccess("%s", line)
+		} else {
+			console.ListWarning("%s", line)
+		}
+	}
+
+	console.Newline()
+	console.Info("Endpoints")
+	if r.service == nil && r.ingress == nil {
+		console.ListNotice("none")
+	}
+	if r.service != nil {
+		for _, endpoint := range serviceEndpoints(r.service) {
+			console.ListItem("service: %s", endpoint)
+		}
+	}
+	if r.ingress != nil {
+		endpoints := ingressEndpoints(r.ingress)
+		if len(endpoints) == 0 {
+			console.ListNotice("ingress: waiting for an address")
+		}
+		for _, endpoint := range endpoints {
+			console.ListItem("ingress: %s", endpoint)
+		}
+	}
+
+	console.Newline()
+	console.Info("Events")
+	if len(r.events) == 0 {
+		console.ListNotice("none")
+	}
+	for _, e := range r.events {
+		line := fmt.Sprintf("%s ago  %s/%s  %s: %s", util.Age(eventTime(e), now), e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Reason, message(e.Message))
+		if e.Type == corev1.EventTypeWarning {
+			console.ListWarning("%s", line)
+		} else {
+			console.ListNotice("%s", line)
+		}
+	}
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
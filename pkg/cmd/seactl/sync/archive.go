@@ -0,0 +1,180 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/console"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/source"
+)
+
+// uploadArchive sends the whole build context as a single archive.  The archive is
+// sent in parts that can be resumed if the upload is interrupted.  Servers without
+// multipart support are sent the archive in a single stream.  Either way the revision
+// is the digest of the manifest, the same as for an incremental sync.
+func uploadArchive(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	opts uploadOptions,
+) (string, int64, error) {
+	console.Info("Creating archive")
+	archive, revision, err := create(name, env)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to create archive: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(archive)
+	}()
+
+	console.Info("Uploading archive")
+	etag, size, err := uploadMultipart(ctx, sclient, name, env, archive, revision, opts)
+	if connect.CodeOf(err) == connect.CodeUnimplemented {
+		return uploadStream(ctx, sclient, name, env, archive, revision, opts.ChunkSize)
+	}
+
+	return etag, size, err
+}
+
+// uploadStream sends the archive in a single stream.
+func uploadStream(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	archive, revision string,
+	chunkSize int,
+) (string, int64, error) {
+	etag, digest, err := checksum(archive)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to calculate the archive checksum: %w", err)
+	}
+
+	stream := sclient.Upload(ctx)
+	err = stream.Send(&seawayv1beta1.UploadRequest{
+		Payload: &seawayv1beta1.UploadRequest_ArtifactInfo{
+			ArtifactInfo: &seawayv1beta1.ArtifactInfo{
+				Name:       name,
+				Namespace:  env.Namespace,
+				Etag:       etag,
+				Sha256:     digest,
+				Revision:   revision,
+				Config:     env.Config,
+				Dockerfile: env.Dockerfile(),
+			},
+		},
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to send the artifact info: %w", err)
+	}
+
+	file, err := os.Open(archive)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to open the archive: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, rerr := file.Read(buf)
+		if n > 0 {
+			serr := stream.Send(&seawayv1beta1.UploadRequest{
+				Payload: &seawayv1beta1.UploadRequest_Chunk{
+					Chunk: buf[:n],
+				},
+			})
+			if serr != nil {
+				return "", 0, fmt.Errorf("unable to send the archive: %w", serr)
+			}
+		}
+
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return "", 0, fmt.Errorf("unable to read the archive: %w", rerr)
+		}
+	}
+
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return "", 0, err
+	}
+
+	return resp.Msg.GetEtag(), resp.Msg.GetSize(), nil
+}
+
+// create builds the tar/gzip archive that will be uploaded to the object storage and
+// returns it with the revision of its manifest.  It is written from the same manifest
+// as an incremental sync, so the archive is reproducible: the same files always
+// produce the same bytes.
+func create(name string, env v1beta1.ManifestEnvironmentSpec) (string, string, error) {
+	files, blobs, err := scan(env)
+	if err != nil {
+		return "", "", err
+	}
+
+	out, err := os.CreateTemp("", name+"-*.tar.gz")
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	err = source.WriteArchive(out, files, func(digest string) (io.ReadCloser, error) {
+		return os.Open(blobs[digest])
+	})
+	if err != nil {
+		_ = os.Remove(out.Name())
+		return "", "", err
+	}
+
+	return out.Name(), source.Digest(files), out.Close()
+}
+
+// checksum returns the md5 and sha256 sums of the file.  Servers that predate sha256
+// revisions only understand the md5 etag.
+func checksum(filename string) (string, string, error) {
+	md5sum := md5.New() //nolint:gosec
+	sha256sum := sha256.New()
+	file, err := os.Open(filename)
+	if err != nil {
+		return "", "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = io.Copy(io.MultiWriter(md5sum, sha256sum), file)
+	if err != nil {
+		return "", "", err
+	}
+
+	return fmt.Sprintf("%x", md5sum.Sum(nil)), fmt.Sprintf("%x", sha256sum.Sum(nil)), nil
+}
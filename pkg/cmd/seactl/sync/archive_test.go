@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate_Reproducible(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll("cmd", 0o755))
+	require.NoError(t, os.WriteFile("Dockerfile", []byte("FROM scratch"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join("cmd", "main.go"), []byte("package main"), 0o644))
+
+	env := v1beta1.ManifestEnvironmentSpec{
+		EnvironmentSpec: v1beta1.EnvironmentSpec{
+			Build: &v1beta1.EnvironmentBuild{Include: []string{`\.go$`}},
+		},
+	}
+
+	archive := func() []byte {
+		name, revision, err := create("test", env)
+		require.NoError(t, err)
+		defer func() {
+			_ = os.Remove(name)
+		}()
+
+		// The revision is the one an incremental sync of the same files would use.
+		files, _, err := scan(env)
+		require.NoError(t, err)
+		assert.Equal(t, source.Digest(files), revision)
+
+		data, err := os.ReadFile(name)
+		require.NoError(t, err)
+		return data
+	}
+
+	first := archive()
+
+	// Touching the files or checking them out with a different umask doesn't change
+	// the archive.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes("Dockerfile", later, later))
+	require.NoError(t, os.Chmod(filepath.Join("cmd", "main.go"), 0o664))
+	assert.Equal(t, first, archive())
+
+	require.NoError(t, os.WriteFile("Dockerfile", []byte("FROM alpine"), 0o644))
+	assert.NotEqual(t, first, archive())
+}
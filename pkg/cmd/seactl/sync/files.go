@@ -0,0 +1,164 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/ignore"
+)
+
+const (
+	DockerIgnoreFile = ".dockerignore"
+	SeawayIgnoreFile = ".seawayignore"
+)
+
+// Filter decides which files are part of the build context.  The default ignores are
+// applied first, then .dockerignore and .seawayignore, and finally the include and
+// exclude patterns from the manifest.  Like the Docker CLI, the Dockerfile and the
+// .dockerignore file are always part of the context, since the build can't run
+// without the Dockerfile.
+type Filter struct {
+	ignores  *ignore.Matcher
+	includes *regexp.Regexp
+	excludes *regexp.Regexp
+	keep     map[string]struct{}
+}
+
+// NewFilter reads the ignore files in the current directory and compiles the
+// patterns from the manifest.
+func NewFilter(env v1beta1.ManifestEnvironmentSpec) (*Filter, error) {
+	includes, err := env.Includes()
+	if err != nil {
+		return nil, err
+	}
+
+	excludes, err := env.Excludes()
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := ignore.New(v1beta1.DefaultIgnores...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := matcher.AddFile(DockerIgnoreFile, true); err != nil {
+		return nil, err
+	}
+
+	if err := matcher.AddFile(SeawayIgnoreFile, false); err != nil {
+		return nil, err
+	}
+
+	keep := map[string]struct{}{DockerIgnoreFile: {}}
+	if dockerfile := env.Dockerfile(); dockerfile != "" {
+		keep[path.Clean(filepath.ToSlash(dockerfile))] = struct{}{}
+	}
+
+	return &Filter{
+		ignores:  matcher,
+		includes: includes,
+		excludes: excludes,
+		keep:     keep,
+	}, nil
+}
+
+// SkipDir returns true if nothing inside of the directory can be part of the build
+// context.
+func (f *Filter) SkipDir(name string) bool {
+	for k := range f.keep {
+		if strings.HasPrefix(k, name+"/") {
+			return false
+		}
+	}
+
+	return f.ignores.Skip(name)
+}
+
+// Included returns true if the file is part of the build context.  The name is the
+// slash separated path relative to the root of the context.
+func (f *Filter) Included(name string) bool {
+	if _, ok := f.keep[name]; ok {
+		return true
+	}
+
+	if f.ignores.Ignored(name, false) || !f.includes.MatchString(name) {
+		return false
+	}
+
+	return f.excludes == nil || !f.excludes.MatchString(name)
+}
+
+// contextFiles walks the current directory and returns the sorted, slash separated
+// paths of the files in the build context.
+func contextFiles(env v1beta1.ManifestEnvironmentSpec) ([]string, error) {
+	filter, err := NewFilter(env)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	err = filepath.WalkDir(".", func(f string, d fs.DirEntry, e error) error {
+		if e != nil {
+			return e
+		}
+
+		if f == "." {
+			return nil
+		}
+
+		name := filepath.ToSlash(f)
+		if d.IsDir() {
+			if filter.SkipDir(name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filter.Included(name) {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// dryRun prints the files that would be uploaded, one per line and nothing else, so
+// the output can be piped to other tools.
+func dryRun(env v1beta1.ManifestEnvironmentSpec) error {
+	files, err := contextFiles(env)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		fmt.Println(f)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestContextFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Dockerfile":           "FROM scratch",
+		"manifest.yaml":        "name: test",
+		"main.go":              "package main",
+		"debug.log":            "",
+		"keep.log":             "",
+		"build/out":            "",
+		"docs/README.md":       "",
+		"docs/guide.md":        "",
+		"vendor/pkg/a.go":      "",
+		".git/HEAD":            "",
+		"pkg/app/app.go":       "",
+		"pkg/app/app_test.go":  "",
+		"pkg/app/tmp/cache.go": "",
+		SeawayIgnoreFile:       "*.log\n!keep.log\n**/tmp/\n",
+		DockerIgnoreFile:       "build\ndocs\n!docs/README.md\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	t.Chdir(dir)
+
+	env := v1beta1.ManifestEnvironmentSpec{
+		EnvironmentSpec: v1beta1.EnvironmentSpec{
+			Build: &v1beta1.EnvironmentBuild{
+				Include: []string{`\.go$`, `\.md$`, `\.log$`},
+				Exclude: []string{`_test\.go$`},
+			},
+		},
+	}
+
+	files, err := contextFiles(env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		DockerIgnoreFile,
+		"Dockerfile",
+		"docs/README.md",
+		"keep.log",
+		"main.go",
+		"manifest.yaml",
+		"pkg/app/app.go",
+	}, files)
+
+	env.Build.Include = append(env.Build.Include, "(")
+	_, err = contextFiles(env)
+	assert.ErrorContains(t, err, `invalid include pattern "("`)
+}
+
+func TestContextFiles_AllowList(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"Dockerfile":             "FROM scratch",
+		"build/Dockerfile":       "FROM scratch",
+		"manifest.yaml":          "name: test",
+		"app.py":                 "",
+		"notes.txt":              "",
+		"src/main.py":            "",
+		DockerIgnoreFile:         "*\n!app.py\n!src\n",
+		"build/other.Dockerfile": "",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	t.Chdir(dir)
+
+	env := v1beta1.ManifestEnvironmentSpec{
+		EnvironmentSpec: v1beta1.EnvironmentSpec{
+			Build: &v1beta1.EnvironmentBuild{
+				Include: []string{`\.py$`},
+			},
+		},
+	}
+
+	// The Dockerfile and .dockerignore are kept even though the allow list doesn't
+	// mention them.
+	files, err := contextFiles(env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DockerIgnoreFile, "Dockerfile", "app.py", "src/main.py"}, files)
+
+	env.Build.Dockerfile = ptr.To("./build/Dockerfile")
+	files, err = contextFiles(env)
+	require.NoError(t, err)
+	assert.Equal(t, []string{DockerIgnoreFile, "app.py", "build/Dockerfile", "src/main.py"}, files)
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/console"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"ctx.sh/seaway/pkg/source"
+)
+
+// syncSource uploads the build context and returns the revision and size of the
+// assembled archive.  Only the files the server is missing are sent.  Servers that
+// don't support incremental syncs are sent the whole archive instead.
+func syncSource(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	opts uploadOptions,
+) (string, int64, error) {
+	console.Info("Scanning files")
+	files, blobs, err := scan(env)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to scan the build context: %w", err)
+	}
+
+	console.Info("Uploading changes")
+	count, size, err := uploadMissing(ctx, sclient, name, env, files, blobs, opts.ChunkSize)
+	if connect.CodeOf(err) == connect.CodeUnimplemented {
+		console.ListNotice("Incremental syncs are not supported by the server")
+		return uploadArchive(ctx, sclient, name, env, opts)
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to upload the changed files: %w", err)
+	}
+	console.ListNotice("Uploaded %d of %d files (%d bytes)", count, len(files), size)
+
+	resp, err := sclient.Assemble(ctx, connect.NewRequest(&seawayv1beta1.AssembleRequest{
+		Name:       name,
+		Namespace:  env.Namespace,
+		Config:     env.Config,
+		Dockerfile: env.Dockerfile(),
+		Files:      files,
+	}))
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to assemble the build context: %w", err)
+	}
+
+	return resp.Msg.GetEtag(), resp.Msg.GetSize(), nil
+}
+
+// scan returns the manifest of the files in the build context.  The paths of the blobs
+// are returned so they can be uploaded if the server is missing them.
+func scan(env v1beta1.ManifestEnvironmentSpec) ([]*seawayv1beta1.FileEntry, map[string]string, error) {
+	paths, err := contextFiles(env)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make([]*seawayv1beta1.FileEntry, 0, len(paths))
+	blobs := make(map[string]string)
+	for _, f := range paths {
+		console.ListItem(f)
+		entry, err := hashFile(f)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, entry)
+		blobs[entry.GetSha256()] = f
+	}
+
+	source.Sort(files)
+	return files, blobs, nil
+}
+
+// hashFile returns the manifest entry for the file.  Symlinks are followed.
+func hashFile(filename string) (*seawayv1beta1.FileEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &seawayv1beta1.FileEntry{
+		Path:   filepath.ToSlash(filename),
+		Sha256: hex.EncodeToString(h.Sum(nil)),
+		Size:   n,
+		Mode:   source.Mode(info.Mode()),
+	}, nil
+}
+
+// uploadMissing asks the server which blobs it doesn't have and uploads them.  It
+// returns the number of blobs and bytes that were uploaded.
+func uploadMissing(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	files []*seawayv1beta1.FileEntry,
+	blobs map[string]string,
+	chunkSize int,
+) (int, int64, error) {
+	resp, err := sclient.MissingBlobs(ctx, connect.NewRequest(&seawayv1beta1.MissingBlobsRequest{
+		Name:      name,
+		Namespace: env.Namespace,
+		Config:    env.Config,
+		Digests:   source.Digests(files),
+	}))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var total int64
+	for _, digest := range resp.Msg.GetDigests() {
+		size, err := uploadBlob(ctx, sclient, &seawayv1beta1.BlobInfo{
+			Name:      name,
+			Namespace: env.Namespace,
+			Config:    env.Config,
+			Sha256:    digest,
+		}, blobs[digest], chunkSize)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += size
+	}
+
+	return len(resp.Msg.GetDigests()), total, nil
+}
+
+func uploadBlob(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	info *seawayv1beta1.BlobInfo,
+	filename string,
+	chunkSize int,
+) (int64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	stream := sclient.UploadBlob(ctx)
+	err = stream.Send(&seawayv1beta1.UploadBlobRequest{
+		Payload: &seawayv1beta1.UploadBlobRequest_BlobInfo{
+			BlobInfo: info,
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, rerr := file.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&seawayv1beta1.UploadBlobRequest{
+				Payload: &seawayv1beta1.UploadBlobRequest_Chunk{
+					Chunk: buf[:n],
+				},
+			}); err != nil {
+				return 0, err
+			}
+		}
+
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return 0, rerr
+		}
+	}
+
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Msg.GetSize(), nil
+}
@@ -15,32 +15,21 @@
 package sync
 
 import (
-	"archive/tar"
-	"compress/gzip"
 	"context"
-	"crypto/md5" //nolint:gosec
-	"crypto/tls"
 	"fmt"
-	"io"
-	"io/fs"
-	"net/http"
-	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 
-	"connectrpc.com/connect"
 	"ctx.sh/seaway/pkg/cmd/util"
-	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
-	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
-	"golang.org/x/net/http2"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/console"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
 	kube "ctx.sh/seaway/pkg/kube/client"
 	"github.com/spf13/cobra"
 
@@ -61,10 +50,16 @@ var (
 )
 
 type Command struct {
-	OnlyDeps bool
-	WithDeps bool
-	LogLevel int8
-	Force    bool
+	OnlyDeps  bool
+	WithDeps  bool
+	LogLevel  int8
+	Force     bool
+	Full      bool
+	DryRun    bool
+	Resume    string
+	ChunkSize int
+	PartSize  int64
+	Parallel  int
 }
 
 // RunE is the main function for the sync command which syncs the code to the target
@@ -93,6 +88,10 @@ func (c *Command) RunE(cmd *cobra.Command, args []string) error { //nolint:funle
 		console.Fatal("Build context '%s' not found in the manifest", args[0])
 	}
 
+	if c.DryRun {
+		return dryRun(env)
+	}
+
 	client, err := kube.NewKubectlCmd("", kubeContext)
 	if err != nil {
 		console.Fatal(err.Error())
@@ -105,111 +104,76 @@ func (c *Command) RunE(cmd *cobra.Command, args []string) error { //nolint:funle
 		}
 	}
 
-	return doSync(ctx, client, manifest.Name, env, c.Force)
-}
-
-func doApply(ctx context.Context, client *kube.KubectlCmd, env v1beta1.ManifestEnvironmentSpec) error {
-	return apply(ctx, client, env)
-}
-
-//nolint:funlen,gocognit
-func doSync(ctx context.Context, client *kube.KubectlCmd, name string, env v1beta1.ManifestEnvironmentSpec, force bool) error {
-	console.Info("Creating archive")
-	archive, err := create(name, env)
+	sclient, err := util.NewSeawayClient(env, kubeContext)
 	if err != nil {
-		console.Fatal("Unable to create archive: %s", err)
+		console.Fatal("Unable to create the seaway client: %s", err)
 	}
-	defer func() {
-		_ = os.Remove(archive)
-	}()
-
-	console.Info("Uploading archive")
 
-	etag, err := checksum(archive)
-	if err != nil {
-		console.Fatal("Unable to calculate the archive checksum: %s", err)
-	}
-
-	hc := &http.Client{
-		// TODO: timeout is passed to the server and is used in relation to the request
-		//  context.  We can configure this as part of the manifest.  The problem is that
-		// 	when the server cancels we quietly exit instead of announcing that we timed
-		//  out.  Note that the timeout is enforced between responses/requests (on Receive).
-		// Timeout: 30 * time.Seconds
-		Transport: &http2.Transport{
-			AllowHTTP: true,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, //nolint:gosec
-			},
-		},
+	if err := c.Sync(ctx, client, sclient, manifest.Name, env); err != nil {
+		console.Fatal(err.Error())
 	}
 
-	sclient := seawayv1beta1connect.NewSeawayServiceClient(hc, env.Endpoint)
-	stream := sclient.Upload(ctx)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	err = stream.Send(&seawayv1beta1.UploadRequest{
-		Payload: &seawayv1beta1.UploadRequest_ArtifactInfo{
-			ArtifactInfo: &seawayv1beta1.ArtifactInfo{
-				Name:      name,
-				Namespace: env.Namespace,
-				Etag:      etag,
-			},
-		},
+// Sync uploads the build context, updates the environment and tracks the revision
+// until it's deployed or has failed.  Errors are returned rather than exiting, so the
+// pipeline can be cancelled and run again.
+func (c *Command) Sync(
+	ctx context.Context,
+	client *kube.KubectlCmd,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+) error {
+	return doSync(ctx, client, sclient, name, env, c.Force, c.Full || c.Resume != "", uploadOptions{
+		ChunkSize: c.ChunkSize,
+		PartSize:  c.PartSize,
+		Parallel:  c.Parallel,
+		Resume:    c.Resume,
 	})
-	if err != nil {
-		console.Fatal("Unable to send the artifact info: %s", err)
-	}
-
-	// TODO: make the chunk size configurable.
-	// TODO: implement a parallel upload. I'll need to track the chunk
-	// positions and make sure to reconstruct them on the server.
-	file, err := os.Open(archive)
-	if err != nil {
-		console.Fatal("Unable to open the archive: %s", err)
-	}
-	defer func() {
-		_ = file.Close()
-	}()
+}
 
-	buf := make([]byte, 1024)
-	for {
-		n, rerr := file.Read(buf)
-		if rerr != nil {
-			if rerr == io.EOF {
-				break
-			}
-			console.Fatal("Unable to read the archive: %v", rerr)
-		}
+func doApply(ctx context.Context, client *kube.KubectlCmd, env v1beta1.ManifestEnvironmentSpec) error {
+	return apply(ctx, client, env)
+}
 
-		serr := stream.Send(&seawayv1beta1.UploadRequest{
-			Payload: &seawayv1beta1.UploadRequest_Chunk{
-				Chunk: buf[:n],
-			},
-		})
-		if serr != nil {
-			console.Fatal("Unable to send the archive: %s", err)
-		}
+//nolint:funlen,gocognit
+func doSync(
+	ctx context.Context,
+	client *kube.KubectlCmd,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	force, full bool,
+	opts uploadOptions,
+) error {
+	if opts.ChunkSize <= 0 {
+		return fmt.Errorf("chunk size must be greater than zero")
+	}
+
+	var etag string
+	var size int64
+	var err error
+	if full {
+		etag, size, err = uploadArchive(ctx, sclient, name, env, opts)
+	} else {
+		etag, size, err = syncSource(ctx, sclient, name, env, opts)
 	}
-
-	resp, err := stream.CloseAndReceive()
 	if err != nil {
-		console.Fatal("Unable to close the connection: %s", err)
+		return err
 	}
 
-	console.ListNotice("Size: %d", resp.Msg.Size)
-	console.ListNotice("Revision: %s", resp.Msg.Etag)
+	console.ListNotice("Size: %d", size)
+	console.ListNotice("Revision: %s", etag)
 
 	if force {
 		obj := util.GetEnvironment(name, env.Namespace)
 		derr := client.Delete(ctx, obj, metav1.DeleteOptions{})
-		if err != nil && !errors.IsNotFound(derr) {
-			console.Fatal("Error deleting environment: %s", derr.Error())
-		} else {
-			console.Info("Removing existing environment")
+		if derr != nil && !errors.IsNotFound(derr) {
+			return fmt.Errorf("error deleting environment: %w", derr)
 		}
+		console.Info("Removing existing environment")
 	}
 
 	// Create namespace if it does not exist
@@ -224,23 +188,33 @@ func doSync(ctx context.Context, client *kube.KubectlCmd, name string, env v1bet
 		return nil
 	})
 	if err != nil {
-		console.Fatal("Unable to create namespace: %s", err.Error())
+		return fmt.Errorf("unable to create namespace: %w", err)
 	}
 	switch op { // nolint:gocritic
 	case kube.OperationResultCreated:
 		console.Info("Environment created")
 	}
 
+	// The user is only recorded when the spec changes, so syncing the same revision
+	// again is still reported as unchanged.
+	user, err := client.Username(ctx)
+	if err != nil {
+		console.ListWarning("Unable to determine the current user: %s", err)
+	}
+
 	console.Info("Deploying")
 	obj := util.GetEnvironment(name, env.Namespace)
 	op, err = client.CreateOrUpdate(ctx, obj, func() error {
+		before := obj.Spec.DeepCopy()
 		env.EnvironmentSpec.DeepCopyInto(&obj.Spec)
-		obj.Spec.Revision = resp.Msg.Etag
-		obj.Spec.Config = env.Name
+		obj.Spec.Revision = etag
+		if user != "" && !equality.Semantic.DeepEqual(before, &obj.Spec) {
+			obj.SetSyncedBy(user, time.Now())
+		}
 		return nil
 	})
 	if err != nil {
-		console.Fatal("error modifying environment: %s", err.Error())
+		return fmt.Errorf("error modifying environment: %w", err)
 	}
 
 	switch op {
@@ -253,135 +227,5 @@ func doSync(ctx context.Context, client *kube.KubectlCmd, name string, env v1bet
 		console.ListNotice("Environment created")
 	}
 
-	track, err := sclient.EnvironmentTracker(ctx, connect.NewRequest(&seawayv1beta1.EnvironmentRequest{
-		Namespace: env.Namespace,
-		Name:      name,
-	}))
-	if err != nil {
-		console.Fatal("Unable to connect to server: %s", err.Error())
-	}
-
-	// TODO: set up a client timeout.  We should probably pass it to the server for
-	// 	an adjustable timeout on that end.
-
-	for {
-		more := track.Receive()
-		if !more {
-			// TODO: if we aren't deployed, keep on trying - the server has shut down.
-			break
-		}
-
-		info := track.Msg()
-		switch info.Status {
-		case "deployed":
-			console.ListSuccess(info.Stage)
-			return nil
-		case "failing":
-			console.ListWarning(info.Stage)
-		case "failed":
-			console.ListFailed(info.Stage)
-			return nil
-		default:
-			console.ListNotice(info.Stage)
-		}
-	}
-
-	return nil
-}
-
-// create builds the tar/gzip archive that will be uploaded to the object storage.
-func create(name string, env v1beta1.ManifestEnvironmentSpec) (string, error) {
-	out, err := os.CreateTemp("", name+"-*.tar.gz")
-	if err != nil {
-		console.Fatal("Unable to create the temporary archive: %s", err)
-	}
-	defer func() {
-		_ = out.Close()
-	}()
-
-	gw := gzip.NewWriter(out)
-	defer func() {
-		_ = gw.Close()
-	}()
-	tw := tar.NewWriter(gw)
-	defer func() {
-		_ = tw.Close()
-	}()
-
-	includes := env.Includes()
-	excludes := env.Excludes()
-
-	err = filepath.WalkDir(".", func(f string, d fs.DirEntry, e error) error {
-		include := includes.MatchString(f)
-		exclude := excludes.MatchString(f)
-		if include && !exclude {
-			console.ListItem(f)
-			if aerr := add(tw, f); aerr != nil {
-				return aerr
-			}
-		}
-
-		return nil
-	})
-	if err != nil {
-		return "", err
-	}
-
-	return out.Name(), nil
-}
-
-// add adds a file to the archive.
-func add(tw *tar.Writer, filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	info, err := file.Stat()
-	if err != nil {
-		return err
-	}
-
-	if info.IsDir() {
-		return nil
-	}
-
-	header, err := tar.FileInfoHeader(info, info.Name())
-	if err != nil {
-		return err
-	}
-
-	header.Name = filename
-	err = tw.WriteHeader(header)
-	if err != nil {
-		return err
-	}
-
-	_, err = io.Copy(tw, file)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func checksum(filename string) (string, error) {
-	h := md5.New() //nolint:gosec
-	file, err := os.Open(filename)
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		_ = file.Close()
-	}()
-
-	_, err = io.Copy(h, file)
-	if err != nil {
-		return "", err
-	}
-
-	return fmt.Sprintf("%x", h.Sum(nil)), nil
+	return util.TrackEnvironment(ctx, sclient, name, env.Namespace, etag)
 }
@@ -0,0 +1,311 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/console"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+)
+
+const (
+	// PartRetries is the number of times a part is retried before the upload is
+	// abandoned.
+	PartRetries = 3
+	// PartRetryInterval is the delay before the first retry.  It doubles on every
+	// retry.
+	PartRetryInterval = time.Second
+)
+
+// uploadOptions controls how the build context is sent to the server.
+type uploadOptions struct {
+	// ChunkSize is the size of each message sent on an upload stream.
+	ChunkSize int
+	// PartSize is the requested size of each part of a multipart upload.
+	PartSize int64
+	// Parallel is the number of parts that are uploaded at the same time.
+	Parallel int
+	// Resume is the id of an interrupted upload to continue.
+	Resume string
+}
+
+// uploadMultipart sends the archive in parts over several concurrent streams.  Parts
+// that fail are retried, and if the upload still can't finish the upload id is
+// returned in the error so it can be resumed with --resume.
+func uploadMultipart(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name string,
+	env v1beta1.ManifestEnvironmentSpec,
+	archive, revision string,
+	opts uploadOptions,
+) (string, int64, error) {
+	file, err := os.Open(archive)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to open the archive: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(h, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to calculate the archive checksum: %w", err)
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+
+	req := &seawayv1beta1.CreateUploadRequest{
+		Name:       name,
+		Namespace:  env.Namespace,
+		Config:     env.Config,
+		Dockerfile: env.Dockerfile(),
+		Sha256:     digest,
+		Revision:   revision,
+		Size:       size,
+		PartSize:   opts.PartSize,
+		UploadId:   opts.Resume,
+	}
+
+	resp, err := sclient.CreateUpload(ctx, connect.NewRequest(req))
+	if opts.Resume != "" && (connect.CodeOf(err) == connect.CodeNotFound || connect.CodeOf(err) == connect.CodeFailedPrecondition) {
+		console.ListWarning("Unable to resume upload %s, starting over: %s", opts.Resume, err)
+		req.UploadId = ""
+		resp, err = sclient.CreateUpload(ctx, connect.NewRequest(req))
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	id := resp.Msg.GetUploadId()
+	parts := remainingParts(size, resp.Msg.GetPartSize(), resp.Msg.GetParts())
+	console.ListNotice("Upload %s: sending %d parts", id, len(parts))
+
+	info := &seawayv1beta1.PartInfo{
+		Name:      name,
+		Namespace: env.Namespace,
+		Config:    env.Config,
+		UploadId:  id,
+	}
+
+	if err := uploadParts(ctx, sclient, file, info, parts, opts); err != nil {
+		return "", 0, fmt.Errorf("upload interrupted, resume it with --resume %s: %w", id, err)
+	}
+
+	complete, err := sclient.CompleteUpload(ctx, connect.NewRequest(&seawayv1beta1.CompleteUploadRequest{
+		Name:      name,
+		Namespace: env.Namespace,
+		Config:    env.Config,
+		UploadId:  id,
+	}))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return complete.Msg.GetEtag(), complete.Msg.GetSize(), nil
+}
+
+// remainingParts splits the archive into parts and leaves out the parts the server
+// already has.
+func remainingParts(size, partSize int64, stored []*seawayv1beta1.UploadPart) []*seawayv1beta1.UploadPart {
+	done := make(map[int32]int64, len(stored))
+	for _, p := range stored {
+		done[p.GetNumber()] = p.GetSize()
+	}
+
+	parts := make([]*seawayv1beta1.UploadPart, 0)
+	for number, offset := int32(1), int64(0); offset < size; number, offset = number+1, offset+partSize {
+		n := min(partSize, size-offset)
+		if done[number] == n {
+			continue
+		}
+		parts = append(parts, &seawayv1beta1.UploadPart{
+			Number: number,
+			Offset: offset,
+			Size:   n,
+		})
+	}
+
+	return parts
+}
+
+// uploadParts uploads the parts concurrently.  The first part that fails after all
+// of its retries cancels the rest.
+func uploadParts(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	file *os.File,
+	info *seawayv1beta1.PartInfo,
+	parts []*seawayv1beta1.UploadPart,
+	opts uploadOptions,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(parts))
+	sem := make(chan struct{}, max(1, opts.Parallel))
+	for i, part := range parts {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			wg.Add(1)
+			go func(i int, part *seawayv1beta1.UploadPart) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				if err := retryPart(ctx, sclient, file, info, part, opts.ChunkSize); err != nil {
+					errs[i] = fmt.Errorf("part %d: %w", part.GetNumber(), err)
+					cancel()
+				}
+			}(i, part)
+		}
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+func retryPart(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	file *os.File,
+	info *seawayv1beta1.PartInfo,
+	part *seawayv1beta1.UploadPart,
+	chunkSize int,
+) error {
+	interval := PartRetryInterval
+
+	var err error
+	for attempt := 0; attempt <= PartRetries; attempt++ {
+		err = uploadPart(ctx, sclient, io.NewSectionReader(file, part.GetOffset(), part.GetSize()), info, part, chunkSize)
+		switch connect.CodeOf(err) {
+		case connect.CodeInvalidArgument, connect.CodeNotFound, connect.CodeFailedPrecondition:
+			// The request itself is wrong, sending it again won't help.
+			return err
+		}
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+
+	return err
+}
+
+// uploadPart streams a single part.  The part and every chunk carry their sha256
+// digest so the server can reject anything that was corrupted on the way.
+func uploadPart(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	section *io.SectionReader,
+	info *seawayv1beta1.PartInfo,
+	part *seawayv1beta1.UploadPart,
+	chunkSize int,
+) error {
+	h := sha256.New()
+	if _, err := io.Copy(h, section); err != nil {
+		return err
+	}
+
+	if _, err := section.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	stream := sclient.UploadPart(ctx)
+	err := stream.Send(&seawayv1beta1.UploadPartRequest{
+		Payload: &seawayv1beta1.UploadPartRequest_PartInfo{
+			PartInfo: &seawayv1beta1.PartInfo{
+				Name:      info.GetName(),
+				Namespace: info.GetNamespace(),
+				Config:    info.GetConfig(),
+				UploadId:  info.GetUploadId(),
+				Part: &seawayv1beta1.UploadPart{
+					Number: part.GetNumber(),
+					Offset: part.GetOffset(),
+					Size:   part.GetSize(),
+					Sha256: hex.EncodeToString(h.Sum(nil)),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return closeStream(stream, err)
+	}
+
+	offset := part.GetOffset()
+	buf := make([]byte, chunkSize)
+	for {
+		n, rerr := section.Read(buf)
+		if n > 0 {
+			sum := sha256.Sum256(buf[:n])
+			serr := stream.Send(&seawayv1beta1.UploadPartRequest{
+				Payload: &seawayv1beta1.UploadPartRequest_Chunk{
+					Chunk: &seawayv1beta1.Chunk{
+						Offset: offset,
+						Data:   buf[:n],
+						Sha256: hex.EncodeToString(sum[:]),
+					},
+				},
+			})
+			if serr != nil {
+				return closeStream(stream, serr)
+			}
+			offset += int64(n)
+		}
+
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return closeStream(stream, rerr)
+		}
+	}
+
+	_, err = stream.CloseAndReceive()
+	return err
+}
+
+// closeStream closes the stream after a failed send.  The server's reason for closing
+// the stream is only available on receive, so it's returned when there is one.
+func closeStream(stream *connect.ClientStreamForClient[seawayv1beta1.UploadPartRequest, seawayv1beta1.UploadPartResponse], err error) error {
+	if _, cerr := stream.CloseAndReceive(); cerr != nil {
+		return cerr
+	}
+
+	return err
+}
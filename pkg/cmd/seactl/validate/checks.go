@@ -0,0 +1,250 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/util/jsonschema"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Problem is something wrong in the manifest.  The line is zero when the position
+// isn't known.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Format returns the problem prefixed with its position in the file.
+func (p Problem) Format(file string) string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", file, p.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", file, p.Line, p.Column, p.Message)
+}
+
+// yamlLine matches the position in the errors of the YAML parser.
+var yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`) //nolint:gochecknoglobals
+
+// validate checks the manifest against the schema and checks the values that the
+// schema can't describe.  Dependency paths are relative to the directory of the file.
+func validate(file string, data []byte) ([]Problem, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []Problem{parseError(err)}, nil
+	}
+
+	if len(doc.Content) == 0 {
+		return []Problem{{Message: "the manifest is empty"}}, nil
+	}
+
+	schema, err := jsonschema.Parse(v1beta1.ManifestSchema())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the manifest schema: %w", err)
+	}
+
+	c := &checker{dir: filepath.Dir(file)}
+	for _, e := range schema.Validate(&doc) {
+		c.problems = append(c.problems, Problem(e))
+	}
+
+	for _, env := range items(lookup(doc.Content[0], "environments")) {
+		c.resources(lookup(env, "resources"))
+
+		build := lookup(env, "build")
+		c.patterns("include", lookup(build, "include"))
+		c.patterns("exclude", lookup(build, "exclude"))
+
+		c.ports(lookup(lookup(lookup(env, "network"), "service"), "ports"))
+		c.dependencies(lookup(env, "dependencies"))
+	}
+
+	if len(c.problems) == 0 {
+		// Anything else the manifest types reject while loading, like live update
+		// paths outside of the build context.
+		var manifest v1beta1.Manifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			c.problems = append(c.problems, parseError(err))
+		}
+	}
+
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		if c.problems[i].Line != c.problems[j].Line {
+			return c.problems[i].Line < c.problems[j].Line
+		}
+		return c.problems[i].Column < c.problems[j].Column
+	})
+
+	return c.problems, nil
+}
+
+type checker struct {
+	dir      string
+	problems []Problem
+}
+
+func (c *checker) errorf(node *yaml.Node, format string, a ...any) {
+	c.problems = append(c.problems, Problem{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+// resources checks that the values are quantities like 100m or 2Gi.
+func (c *checker) resources(node *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isScalar(value) {
+			continue
+		}
+
+		if _, err := resource.ParseQuantity(value.Value); err != nil {
+			c.errorf(value, "invalid quantity %q for %s", value.Value, key.Value)
+		}
+	}
+}
+
+// patterns checks that the include or exclude patterns are regular expressions.
+func (c *checker) patterns(kind string, node *yaml.Node) {
+	for _, item := range items(node) {
+		if !isScalar(item) {
+			continue
+		}
+
+		if _, err := regexp.Compile(item.Value); err != nil {
+			c.errorf(item, "invalid %s pattern: %s", kind, err)
+		}
+	}
+}
+
+// ports checks that the service ports and their names are unique.  The same port
+// number may be used with different protocols.
+func (c *checker) ports(node *yaml.Node) {
+	ports := make(map[string]*yaml.Node)
+	names := make(map[string]*yaml.Node)
+
+	for _, item := range items(node) {
+		if port := lookup(item, "port"); isScalar(port) {
+			protocol := string(corev1.ProtocolTCP)
+			if p := lookup(item, "protocol"); isScalar(p) {
+				protocol = p.Value
+			}
+
+			key := port.Value + "/" + protocol
+			if first, ok := ports[key]; ok {
+				c.errorf(port, "duplicate port %s, also used on line %d", key, first.Line)
+			} else {
+				ports[key] = port
+			}
+		}
+
+		if name := lookup(item, "name"); isScalar(name) {
+			if first, ok := names[name.Value]; ok {
+				c.errorf(name, "duplicate port name %q, also used on line %d", name.Value, first.Line)
+			} else {
+				names[name.Value] = name
+			}
+		}
+	}
+}
+
+// dependencies checks that the dependency paths are directories.
+func (c *checker) dependencies(node *yaml.Node) {
+	for _, item := range items(node) {
+		path := lookup(item, "path")
+		if !isScalar(path) {
+			continue
+		}
+
+		name := filepath.FromSlash(path.Value)
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(c.dir, name)
+		}
+
+		info, err := os.Stat(name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			c.errorf(path, "dependency path %q does not exist", path.Value)
+		case err != nil:
+			c.errorf(path, "unable to read dependency path %q: %s", path.Value, err)
+		case !info.IsDir():
+			c.errorf(path, "dependency path %q is not a directory", path.Value)
+		}
+	}
+}
+
+// parseError returns the problem for an error of the YAML parser or decoder.  Only the
+// first error is reported when there are several.
+func parseError(err error) Problem {
+	msg := err.Error()
+
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+
+	if m := yamlLine.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return Problem{Line: line, Column: 1, Message: m[2]}
+	}
+
+	return Problem{Message: msg}
+}
+
+// lookup returns the value of the key in the mapping.
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// items returns the items of the sequence.
+func items(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+func isScalar(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Tag != "!!null"
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"ctx.sh/seaway/pkg/console"
+	"github.com/spf13/cobra"
+)
+
+// ManifestFile is the manifest that is validated when no file is given.
+const ManifestFile = "manifest.yaml"
+
+type Command struct{}
+
+// RunE is the main function for the validate command.  It reports every problem in
+// the manifest with its position instead of stopping at the first one, and exits with
+// an error if there were any.
+func (c *Command) RunE(cmd *cobra.Command, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("expected at most one manifest")
+	}
+
+	file := ManifestFile
+	if len(args) > 0 {
+		file = args[0]
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		console.Fatal("Unable to read the manifest: %s", err)
+	}
+
+	problems, err := validate(file, data)
+	if err != nil {
+		console.Fatal(err.Error())
+	}
+
+	if len(problems) == 0 {
+		console.Success("%s is valid", file)
+		return nil
+	}
+
+	if err := write(os.Stdout, file, problems); err != nil {
+		return err
+	}
+
+	if len(problems) == 1 {
+		console.Fatal("Found 1 problem in %s", file)
+	}
+	console.Fatal("Found %d problems in %s", len(problems), file)
+
+	return nil
+}
+
+// write prints one problem per line in the file:line:column form that editors and
+// terminals link to.
+func write(out io.Writer, file string, problems []Problem) error {
+	for _, p := range problems {
+		if _, err := fmt.Fprintln(out, p.Format(file)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExample(t *testing.T) {
+	file := filepath.Join("..", "..", "..", "..", "example", "manifest.yaml")
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	problems, err := validate(file, data)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "k8s", "base"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "k8s", "crds.yaml"), nil, 0o600))
+	file := filepath.Join(dir, "manifest.yaml")
+
+	tests := []struct {
+		name     string
+		manifest string
+		expected []string
+	}{
+		{
+			name: "valid",
+			manifest: `name: app
+environments:
+  - name: local
+    resources:
+      cpu: 100m
+      memory: 1
+    build:
+      include: ['^app/', '\.py$']
+    network:
+      service:
+        ports:
+          - {name: http, port: 8080}
+          - {name: dns, port: 8080, protocol: UDP}
+    dependencies:
+      - {name: base, path: k8s/base}
+`,
+		},
+		{
+			name: "unknown fields",
+			manifest: `name: app
+defaultEnvironment: local
+environments:
+  - name: local
+    namspace: app
+    livenessProbe:
+      initialDelaySeconds: 5
+`,
+			expected: []string{
+				`manifest.yaml:2:1: unknown field "defaultEnvironment"`,
+				`manifest.yaml:5:5: unknown field "namspace"`,
+				`manifest.yaml:7:7: unknown field "initialDelaySeconds", did you mean "initialdelayseconds"?`,
+			},
+		},
+		{
+			name: "quantities",
+			manifest: `name: app
+environments:
+  - name: local
+    resources:
+      cpu: 0.5
+      memory: 2GB
+`,
+			expected: []string{`manifest.yaml:6:15: invalid quantity "2GB" for memory`},
+		},
+		{
+			name: "patterns",
+			manifest: `name: app
+environments:
+  - name: local
+    build:
+      include: ['^app/', '^(main']
+      exclude: ['*.pyc']
+`,
+			expected: []string{
+				"manifest.yaml:5:26: invalid include pattern: error parsing regexp: missing closing ): `^(main`",
+				"manifest.yaml:6:17: invalid exclude pattern: error parsing regexp: missing argument to repetition operator: `*`",
+			},
+		},
+		{
+			name: "ports",
+			manifest: `name: app
+environments:
+  - name: local
+    network:
+      service:
+        ports:
+          - name: http
+            port: 8080
+          - name: metrics
+            port: 8080
+            protocol: TCP
+          - name: http
+            port: 9090
+`,
+			expected: []string{
+				`manifest.yaml:10:19: duplicate port 8080/TCP, also used on line 8`,
+				`manifest.yaml:12:19: duplicate port name "http", also used on line 7`,
+			},
+		},
+		{
+			name: "dependencies",
+			manifest: `name: app
+environments:
+  - name: local
+    dependencies:
+      - name: base
+        path: k8s/base
+      - name: overlay
+        path: k8s/overlays/local
+      - name: crds
+        path: k8s/crds.yaml
+      - name: missing
+`,
+			expected: []string{
+				`manifest.yaml:8:15: dependency path "k8s/overlays/local" does not exist`,
+				`manifest.yaml:10:15: dependency path "k8s/crds.yaml" is not a directory`,
+				`manifest.yaml:11:9: missing required field "path"`,
+			},
+		},
+		{
+			name:     "syntax",
+			manifest: "name: app\nenvironments:\n  - name: local\n   namespace: app\n",
+			expected: []string{`manifest.yaml:2:1: did not find expected '-' indicator`},
+		},
+		{
+			name: "live update",
+			manifest: `name: app
+environments:
+  - name: local
+    liveUpdate:
+      sync:
+        - src: ../app
+          dest: /app
+`,
+			expected: []string{`manifest.yaml: live update src must be a path inside of the build context: "../app"`},
+		},
+		{
+			name:     "empty",
+			manifest: "",
+			expected: []string{`manifest.yaml: the manifest is empty`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := validate(file, []byte(tt.manifest))
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, write(&buf, "manifest.yaml", problems))
+
+			var lines []string
+			for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+				}
+			}
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
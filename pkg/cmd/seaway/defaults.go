@@ -23,10 +23,14 @@ const (
 	DefaultClientCAName         string = "ca.crt"
 	DefaultEnableLeaderElection bool   = false
 	DefaultSkipInsecureVerify   bool   = false
+	DefaultDisableAPIAuth       bool   = false
 	DefaultLogLevel             int8   = 0
 	DefaultNamespace            string = ""
 	DefaultConfigName           string = "default"
 	DefaulSystemNamespace       string = "seaway-system"
+	DefaultArchiveMaxSize       int64  = 1 << 30
+	DefaultArchiveMaxUnpacked   int64  = 4 << 30
+	DefaultArchiveMaxEntries    int    = 100000
 
 	ConnectionTimeout time.Duration = 30 * time.Second
 )
@@ -22,11 +22,14 @@ import (
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1/handlers/service/healthz"
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1/handlers/service/seaway"
 	"ctx.sh/seaway/pkg/controller"
+	"ctx.sh/seaway/pkg/source"
 	"ctx.sh/seaway/pkg/tracker"
 	"ctx.sh/seaway/pkg/webhook"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap/zapcore"
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -42,16 +45,24 @@ type Command struct {
 	ClientCAName          string
 	LeaderElection        bool
 	SkipInsecureVerify    bool
+	DisableAPIAuth        bool
 	LogLevel              int8
 	Namespace             string
 	DefaultConfig         string
 	RegistryURL           string
 	RegistryNodePort      uint32
+	StorageType           string
 	StorageURL            string
 	StorageBucket         string
 	StoragePrefix         string
 	StorageRegion         string
 	StorageForcePathStyle bool
+	StoragePath           string
+	StorageVolumeClaim    string
+	StorageRetention      int32
+	ArchiveMaxSize        int64
+	ArchiveMaxUnpacked    int64
+	ArchiveMaxEntries     int
 }
 
 func NewCommand() *Command {
@@ -67,6 +78,8 @@ func (c *Command) RunE(cmd *cobra.Command, args []string) error {
 	_ = appsv1.AddToScheme(scheme)
 	_ = batchv1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
+	_ = authenticationv1.AddToScheme(scheme)
+	_ = authorizationv1.AddToScheme(scheme)
 
 	// TODO: more configurations.
 	log := zap.New(
@@ -105,29 +118,55 @@ func (c *Command) RunE(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
-	track := tracker.New(mgr.GetEventRecorderFor("seaway-controller"))
+	// The tracker runs on every replica so any of them can serve the tracking API.
+	track := tracker.New(mgr.GetCache())
+	if err = mgr.Add(track); err != nil {
+		log.Error(err, "unable to add the environment tracker")
+		os.Exit(1)
+	}
 
 	if err = controller.SetupWithManager(mgr, &controller.Options{
+		DefaultConfig:         c.DefaultConfig,
 		RegistryURL:           c.RegistryURL,
 		RegistryNodePort:      c.RegistryNodePort,
+		StorageType:           v1beta1.EnvironmentStorageType(c.StorageType),
 		StorageURL:            c.StorageURL,
 		StorageBucket:         c.StorageBucket,
 		StoragePrefix:         c.StoragePrefix,
 		StorageRegion:         c.StorageRegion,
 		StorageForcePathStyle: c.StorageForcePathStyle,
-		Tracker:               track,
+		StoragePath:           c.StoragePath,
+		StorageVolumeClaim:    c.StorageVolumeClaim,
+		StorageRetention:      c.StorageRetention,
 	}); err != nil {
 		log.Error(err, "unable to setup seaway controllers")
 		os.Exit(1)
 	}
 
+	var reviewer seaway.Reviewer
+	if c.DisableAPIAuth {
+		log.Info("api authentication is disabled, any caller can upload to any environment")
+	} else {
+		reviewer = seaway.NewKubeReviewer(mgr.GetClient())
+	}
+
 	if err = seaway.RegisterWithWebhook(hookServer, &seaway.Options{
-		Client:        mgr.GetClient(),
-		StorageURL:    c.StorageURL,
-		StorageBucket: c.StorageBucket,
-		StoragePrefix: c.StoragePrefix,
-		StorageRegion: c.StorageRegion,
-		Tracker:       track,
+		Client:                mgr.GetClient(),
+		DefaultConfig:         c.DefaultConfig,
+		StorageType:           v1beta1.EnvironmentStorageType(c.StorageType),
+		StorageURL:            c.StorageURL,
+		StorageBucket:         c.StorageBucket,
+		StoragePrefix:         c.StoragePrefix,
+		StorageRegion:         c.StorageRegion,
+		StorageForcePathStyle: c.StorageForcePathStyle,
+		StoragePath:           c.StoragePath,
+		ArchiveLimits: source.Limits{
+			MaxSize:         c.ArchiveMaxSize,
+			MaxUnpackedSize: c.ArchiveMaxUnpacked,
+			MaxEntries:      c.ArchiveMaxEntries,
+		},
+		Tracker:  track,
+		Reviewer: reviewer,
 	}); err != nil {
 		log.Error(err, "unable to register upload service with webhook")
 		os.Exit(1)
@@ -79,15 +79,23 @@ func OperatorCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&c.ClientCAName, "ca-name", "", DefaultClientCAName, "specify the webhooks client ca name")
 	cmd.PersistentFlags().BoolVarP(&c.LeaderElection, "enable-leader-election", "", DefaultEnableLeaderElection, "enable leader election")
 	cmd.PersistentFlags().BoolVarP(&c.SkipInsecureVerify, "skip-insecure-verify", "", DefaultSkipInsecureVerify, "skip certificate verification for the webhooks")
+	cmd.PersistentFlags().BoolVarP(&c.DisableAPIAuth, "disable-api-auth", "", DefaultDisableAPIAuth, "accept seaway API calls without checking the caller's kubernetes identity")
 	cmd.PersistentFlags().Int8VarP(&c.LogLevel, "log-level", "", DefaultLogLevel, "set the log level (integer value)")
 	cmd.PersistentFlags().StringVarP(&c.Namespace, "namespace", "", DefaultNamespace, "limit the controller to a specific namespace")
 	cmd.PersistentFlags().StringVarP(&c.DefaultConfig, "default-config", "", DefaultConfigName, "specify the default seaway config that will be used if none is specified")
 	cmd.PersistentFlags().StringVarP(&c.RegistryURL, "registry-url", "", v1beta1.DefaultRegistryURL, "specify the url for the local registry")
 	cmd.PersistentFlags().Uint32VarP(&c.RegistryNodePort, "registry-nodeport", "", v1beta1.DefaultRegistryNodeport, "specify the node port used by the registry")
+	cmd.PersistentFlags().StringVarP(&c.StorageType, "storage-type", "", string(v1beta1.DefaultStorageType), "specify the object storage backend (s3, gcs, azure or filesystem)")
 	cmd.PersistentFlags().StringVarP(&c.StorageURL, "storage-url", "", v1beta1.DefaultStorageEndpoint, "specify the url for the object storage")
 	cmd.PersistentFlags().StringVarP(&c.StorageBucket, "storage-bucket", "", v1beta1.DefaultStorageBucket, "specify the object storage bucket")
 	cmd.PersistentFlags().StringVarP(&c.StoragePrefix, "storage-prefix", "", v1beta1.DefaultStoragePrefix, "specify the object storage prefix")
 	cmd.PersistentFlags().StringVarP(&c.StorageRegion, "storage-region", "", v1beta1.DefaultStorageRegion, "specify the object storage region")
 	cmd.PersistentFlags().BoolVarP(&c.StorageForcePathStyle, "storage-force-path-style", "", v1beta1.DefaultStorageForcePathStyle, "specify the whenther the storage uses path style")
+	cmd.PersistentFlags().StringVarP(&c.StoragePath, "storage-path", "", v1beta1.DefaultStoragePath, "specify the directory used by the filesystem storage")
+	cmd.PersistentFlags().StringVarP(&c.StorageVolumeClaim, "storage-volume-claim", "", "", "specify the volume claim that backs the filesystem storage")
+	cmd.PersistentFlags().Int32VarP(&c.StorageRetention, "storage-retention", "", v1beta1.DefaultStorageRetention, "specify the number of revisions whose source archives are kept for each environment")
+	cmd.PersistentFlags().Int64VarP(&c.ArchiveMaxSize, "archive-max-size", "", DefaultArchiveMaxSize, "specify the largest compressed build context accepted, in bytes (0 disables the limit)")
+	cmd.PersistentFlags().Int64VarP(&c.ArchiveMaxUnpacked, "archive-max-unpacked-size", "", DefaultArchiveMaxUnpacked, "specify the largest combined size of the files in a build context, in bytes (0 disables the limit)")
+	cmd.PersistentFlags().IntVarP(&c.ArchiveMaxEntries, "archive-max-entries", "", DefaultArchiveMaxEntries, "specify the largest number of entries in a build context (0 disables the limit)")
 	return cmd
 }
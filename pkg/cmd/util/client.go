@@ -0,0 +1,268 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/console"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	kube "ctx.sh/seaway/pkg/kube/client"
+	"golang.org/x/net/http2"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// TrackerRetries is the number of times the tracker reconnects without receiving
+	// any new transitions before giving up.
+	TrackerRetries = 5
+	// TrackerRetryInterval is the delay between reconnects.
+	TrackerRetryInterval = 2 * time.Second
+)
+
+// NewSeawayClient returns a client for the seaway API served at the environment's
+// endpoint.  Calls carry the credentials from the kubernetes context so the server
+// can check them against the cluster's RBAC.
+func NewSeawayClient(env v1beta1.ManifestEnvironmentSpec, kubeContext string) (seawayv1beta1connect.SeawayServiceClient, error) {
+	tlsConfig, err := clientTLSConfig(env)
+	if err != nil {
+		return nil, err
+	}
+
+	kc, err := kube.NewClient("", kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := kc.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the credentials are taken from the kubernetes config.  Impersonation and
+	// the like don't apply to the seaway API.
+	transport, err := rest.HTTPWrappersForConfig(&rest.Config{
+		BearerToken:     config.BearerToken,
+		BearerTokenFile: config.BearerTokenFile,
+		AuthProvider:    config.AuthProvider,
+		ExecProvider:    config.ExecProvider,
+	}, &http2.Transport{
+		AllowHTTP:       true,
+		TLSClientConfig: tlsConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hc := &http.Client{
+		// TODO: timeout is passed to the server and is used in relation to the request
+		//  context.  We can configure this as part of the manifest.  The problem is that
+		// 	when the server cancels we quietly exit instead of announcing that we timed
+		//  out.  Note that the timeout is enforced between responses/requests (on Receive).
+		// Timeout: 30 * time.Seconds
+		Transport: transport,
+	}
+
+	var opts []connect.ClientOption
+	if !hasBearerCredentials(config) {
+		opts = append(opts, connect.WithInterceptors(&credentialsHint{}))
+	}
+
+	return seawayv1beta1connect.NewSeawayServiceClient(hc, env.Endpoint, opts...), nil
+}
+
+// hasBearerCredentials returns true if the kubernetes config has credentials that can
+// be sent to the seaway API.  Client certificates only authenticate the TLS connection
+// to the kubernetes API and can't be forwarded.
+func hasBearerCredentials(config *rest.Config) bool {
+	return config.BearerToken != "" || config.BearerTokenFile != "" || config.AuthProvider != nil || config.ExecProvider != nil
+}
+
+// ErrNoBearerCredentials explains an unauthenticated call from a kubernetes context
+// without a token, like the client certificate contexts created by k3d and kind.
+var ErrNoBearerCredentials = errors.New("the kubernetes context has no bearer token or exec credentials to send to the seaway API, " +
+	"client certificates can't be used (for the local k3d cluster, run 'make localdev-token' and use --context seaway-developer)")
+
+// credentialsHint replaces unauthenticated errors with ErrNoBearerCredentials when
+// the client has nothing to authenticate with.
+type credentialsHint struct{}
+
+func (h *credentialsHint) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		return resp, hintCredentials(err)
+	}
+}
+
+func (h *credentialsHint) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		return &hintingConn{StreamingClientConn: next(ctx, spec)}
+	}
+}
+
+func (h *credentialsHint) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+type hintingConn struct {
+	connect.StreamingClientConn
+}
+
+func (c *hintingConn) Receive(msg any) error {
+	return hintCredentials(c.StreamingClientConn.Receive(msg))
+}
+
+func hintCredentials(err error) error {
+	if connect.CodeOf(err) != connect.CodeUnauthenticated {
+		return err
+	}
+
+	return connect.NewError(connect.CodeUnauthenticated, ErrNoBearerCredentials)
+}
+
+// clientTLSConfig returns the TLS settings for the endpoint.  Loopback endpoints are
+// port forwards that are already secured by the kubernetes API, so the certificate
+// isn't verified for them.
+func clientTLSConfig(env v1beta1.ManifestEnvironmentSpec) (*tls.Config, error) {
+	endpoint, err := url.Parse(env.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", env.Endpoint, err)
+	}
+
+	if env.InsecureSkipVerify || isLoopback(endpoint.Hostname()) {
+		return &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec
+		}, nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if env.CAFile != "" {
+		pem, err := os.ReadFile(env.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the ca file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", env.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// TrackEnvironment follows the environment through the reconciliation stages and
+// returns once the revision has been deployed or has failed, or the context is
+// cancelled.  If the stream is interrupted, tracking resumes from the last transition
+// that was received.
+func TrackEnvironment(ctx context.Context, sclient seawayv1beta1connect.SeawayServiceClient, name, namespace, revision string) error {
+	var after uint64
+	retries := 0
+
+	for {
+		done, last, err := trackEnvironment(ctx, sclient, name, namespace, revision, after)
+		if done {
+			return nil
+		}
+
+		if last > after {
+			after = last
+			retries = 0
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		retries++
+		if retries > TrackerRetries {
+			if err != nil {
+				return fmt.Errorf("unable to track the environment: %w", err)
+			}
+			return errors.New("unable to track the environment: the server closed the stream")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(TrackerRetryInterval):
+		}
+	}
+}
+
+// trackEnvironment reads transitions from a single tracker stream.  It returns whether
+// the revision reached a terminal state and the last sequence that was received.
+func trackEnvironment(
+	ctx context.Context,
+	sclient seawayv1beta1connect.SeawayServiceClient,
+	name, namespace, revision string,
+	after uint64,
+) (bool, uint64, error) {
+	track, err := sclient.EnvironmentTracker(ctx, connect.NewRequest(&seawayv1beta1.EnvironmentRequest{
+		Namespace: namespace,
+		Name:      name,
+		Etag:      revision,
+		After:     after,
+	}))
+	if err != nil {
+		return false, after, err
+	}
+	defer track.Close()
+
+	for track.Receive() {
+		info := track.Msg()
+		after = info.Sequence
+
+		final := revision == "" || info.Revision == revision
+		if info.Resync && !final {
+			// The current state is from an earlier revision, so it says nothing
+			// about ours.  Wait for our revision to start.
+			continue
+		}
+
+		switch info.Status {
+		case "deployed":
+			console.ListSuccess(info.Stage)
+			if final {
+				return true, after, nil
+			}
+		case "failing":
+			console.ListWarning(info.Stage)
+		case "failed":
+			console.ListFailed(info.Stage)
+			if info.Log != "" {
+				console.Log(info.Log)
+			}
+			if final {
+				return true, after, nil
+			}
+		default:
+			console.ListNotice(info.Stage)
+		}
+	}
+
+	return false, after, track.Err()
+}
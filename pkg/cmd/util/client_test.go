@@ -0,0 +1,116 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"connectrpc.com/connect"
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"ctx.sh/seaway/pkg/gen/seaway/v1beta1/seawayv1beta1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+type unauthenticatedService struct {
+	seawayv1beta1connect.UnimplementedSeawayServiceHandler
+}
+
+func (unauthenticatedService) Environment(context.Context, *connect.Request[seawayv1beta1.EnvironmentRequest]) (*connect.Response[seawayv1beta1.EnvironmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("a bearer token is required"))
+}
+
+func (unauthenticatedService) EnvironmentTracker(context.Context, *connect.Request[seawayv1beta1.EnvironmentRequest], *connect.ServerStream[seawayv1beta1.EnvironmentResponse]) error {
+	return connect.NewError(connect.CodeUnauthenticated, errors.New("a bearer token is required"))
+}
+
+func TestHasBearerCredentials(t *testing.T) {
+	assert.False(t, hasBearerCredentials(&rest.Config{TLSClientConfig: rest.TLSClientConfig{CertFile: "client.crt", KeyFile: "client.key"}}))
+	assert.True(t, hasBearerCredentials(&rest.Config{BearerToken: "token"}))
+	assert.True(t, hasBearerCredentials(&rest.Config{BearerTokenFile: "/var/run/token"}))
+	assert.True(t, hasBearerCredentials(&rest.Config{ExecProvider: &clientcmdapi.ExecConfig{Command: "aws"}}))
+}
+
+func TestCredentialsHint(t *testing.T) {
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(unauthenticatedService{})
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	sclient := seawayv1beta1connect.NewSeawayServiceClient(server.Client(), server.URL, connect.WithInterceptors(&credentialsHint{}))
+
+	_, err := sclient.Environment(context.Background(), connect.NewRequest(&seawayv1beta1.EnvironmentRequest{}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	assert.ErrorIs(t, err, ErrNoBearerCredentials)
+
+	stream, err := sclient.EnvironmentTracker(context.Background(), connect.NewRequest(&seawayv1beta1.EnvironmentRequest{}))
+	assert.NoError(t, err)
+	assert.False(t, stream.Receive())
+	assert.ErrorIs(t, stream.Err(), ErrNoBearerCredentials)
+}
+
+type resyncService struct {
+	seawayv1beta1connect.UnimplementedSeawayServiceHandler
+}
+
+func (resyncService) EnvironmentTracker(_ context.Context, _ *connect.Request[seawayv1beta1.EnvironmentRequest], stream *connect.ServerStream[seawayv1beta1.EnvironmentResponse]) error {
+	for _, msg := range []*seawayv1beta1.EnvironmentResponse{
+		{Sequence: 5, Revision: "old", Status: "deployed", Stage: "Old revision deployed", Resync: true},
+		{Sequence: 6, Revision: "new", Status: "deploying", Stage: "Building the image"},
+		{Sequence: 7, Revision: "new", Status: "deployed", Stage: "New revision deployed"},
+	} {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// captureStdout returns everything written to stdout while fn runs.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestTrackEnvironment_Resync(t *testing.T) {
+	path, handler := seawayv1beta1connect.NewSeawayServiceHandler(resyncService{})
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	sclient := seawayv1beta1connect.NewSeawayServiceClient(server.Client(), server.URL)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = TrackEnvironment(context.Background(), sclient, "app", "default", "new")
+	})
+	require.NoError(t, err)
+
+	// The previous revision's state isn't reported as the outcome of the new one.
+	assert.NotContains(t, out, "Old revision deployed")
+	assert.Contains(t, out, "Building the image")
+	assert.Contains(t, out, "New revision deployed")
+}
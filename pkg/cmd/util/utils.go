@@ -161,3 +161,20 @@ func GetNamespace(name string) *corev1.Namespace {
 
 	return ns
 }
+
+// Age formats the time since t the way kubectl does, with the largest unit only.
+func Age(t, now time.Time) string {
+	d := max(now.Sub(t), 0)
+	switch {
+	case t.IsZero():
+		return "unknown"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,18 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAge(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, "42s", Age(now.Add(-42*time.Second), now))
+	assert.Equal(t, "5m", Age(now.Add(-5*time.Minute), now))
+	assert.Equal(t, "30h", Age(now.Add(-30*time.Hour), now))
+	assert.Equal(t, "3d", Age(now.Add(-72*time.Hour), now))
+	assert.Equal(t, "0s", Age(now.Add(time.Second), now))
+	assert.Equal(t, "unknown", Age(time.Time{}, now))
+}
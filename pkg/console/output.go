@@ -106,6 +106,14 @@ func ListSuccess(format string, a ...any) {
 	fmt.Printf(format, a...)
 }
 
+// Log prints a block of log output, indented and dimmed so it stands apart from the
+// rest of the output.
+func Log(out string) {
+	for _, line := range strings.Split(out, "\n") {
+		fmt.Println("    " + chalk.Dim.TextStyle(line))
+	}
+}
+
 func Newline() {
 	fmt.Println()
 }
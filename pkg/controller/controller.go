@@ -15,20 +15,24 @@
 package controller
 
 import (
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment"
-	"ctx.sh/seaway/pkg/tracker"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 type Options struct {
+	DefaultConfig         string
 	RegistryURL           string
 	RegistryNodePort      uint32
+	StorageType           v1beta1.EnvironmentStorageType
 	StorageURL            string
 	StorageBucket         string
 	StoragePrefix         string
 	StorageRegion         string
 	StorageForcePathStyle bool
-	Tracker               *tracker.Tracker
+	StoragePath           string
+	StorageVolumeClaim    string
+	StorageRetention      int32
 }
 
 type Controller struct{}
@@ -38,13 +42,17 @@ type Controller struct{}
 // SetupWithManager sets up any known controllers.
 func SetupWithManager(mgr ctrl.Manager, opts *Options) error {
 	return environment.SetupWithManager(mgr, &environment.Options{
+		DefaultConfig:         opts.DefaultConfig,
 		RegistryURL:           opts.RegistryURL,
 		RegistryNodePort:      opts.RegistryNodePort,
+		StorageType:           opts.StorageType,
 		StorageURL:            opts.StorageURL,
 		StorageBucket:         opts.StorageBucket,
 		StoragePrefix:         opts.StoragePrefix,
 		StorageRegion:         opts.StorageRegion,
 		StorageForcePathStyle: opts.StorageForcePathStyle,
-		Tracker:               opts.Tracker,
+		StoragePath:           opts.StoragePath,
+		StorageVolumeClaim:    opts.StorageVolumeClaim,
+		StorageRetention:      opts.StorageRetention,
 	})
 }
@@ -0,0 +1,174 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/storage"
+	"ctx.sh/seaway/pkg/util"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ArchiveGracePeriod is how long an archive that isn't in the history is kept.  The
+	// client uploads the archive before it updates the environment revision, so recent
+	// archives may belong to a revision that hasn't been recorded yet.
+	ArchiveGracePeriod = 15 * time.Minute
+	// UploadExpiry is how long an unfinished upload is kept.  Uploads that haven't
+	// been completed by then have been abandoned by the client.
+	UploadExpiry = 24 * time.Hour
+)
+
+// uploadState is the part of the state that the seaway service keeps for a multipart
+// upload that's needed to abort it.
+type uploadState struct {
+	Key string `json:"key"`
+}
+
+// pruneArchives removes the source archives of the environment that don't belong to
+// one of the most recent revisions in the history, along with the uploads that have
+// expired.
+func (h *Handler) pruneArchives(ctx context.Context, status *v1beta1.EnvironmentStatus) error {
+	env := h.collection.Observed.Env
+	storage := h.collection.Observed.Config.Spec.Storage
+
+	objects, err := h.storage.List(ctx, util.ArchivePrefix(storage.Prefix, env.GetNamespace(), env.GetName()))
+	if err != nil {
+		return err
+	}
+
+	keep := retainedRevisions(status, int(storage.Retention))
+	deadline := time.Now().Add(-ArchiveGracePeriod)
+
+	for _, obj := range objects {
+		revision := util.ArchiveRevision(obj.Key)
+		if revision == "" || keep[revision] || obj.LastModified.After(deadline) {
+			continue
+		}
+
+		if err := h.storage.Delete(ctx, obj.Key); err != nil {
+			return fmt.Errorf("unable to delete %s: %w", obj.Key, err)
+		}
+		log.FromContext(ctx).V(4).Info("removed source archive", "key", obj.Key, "revision", revision)
+	}
+
+	return h.pruneUploads(ctx, time.Now().Add(-UploadExpiry))
+}
+
+// deleteArchives removes all of the source archives and unfinished uploads of the
+// environment.
+func (h *Handler) deleteArchives(ctx context.Context) error {
+	env := h.collection.Observed.Env
+	prefix := h.collection.Observed.Config.Spec.Storage.Prefix
+
+	objects, err := h.storage.List(ctx, util.ArchivePrefix(prefix, env.GetNamespace(), env.GetName()))
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if err := h.storage.Delete(ctx, obj.Key); err != nil {
+			return fmt.Errorf("unable to delete %s: %w", obj.Key, err)
+		}
+	}
+
+	return h.pruneUploads(ctx, time.Time{})
+}
+
+// pruneUploads removes the uploads of the environment that were last written before
+// the deadline, or all of them if the deadline is zero.  Multipart uploads are aborted
+// before their state is removed so the backend drops the parts that were stored.
+func (h *Handler) pruneUploads(ctx context.Context, deadline time.Time) error {
+	env := h.collection.Observed.Env
+	prefix := h.collection.Observed.Config.Spec.Storage.Prefix
+
+	objects, err := h.storage.List(ctx, util.UploadPrefix(prefix, env.GetNamespace(), env.GetName()))
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objects {
+		if !deadline.IsZero() && obj.LastModified.After(deadline) {
+			continue
+		}
+
+		if id := util.UploadID(obj.Key); id != "" {
+			if err := h.abortUpload(ctx, obj.Key, id); err != nil {
+				return err
+			}
+		}
+
+		if err := h.storage.Delete(ctx, obj.Key); err != nil {
+			return fmt.Errorf("unable to delete %s: %w", obj.Key, err)
+		}
+		log.FromContext(ctx).V(4).Info("removed upload", "key", obj.Key)
+	}
+
+	return nil
+}
+
+// abortUpload aborts the multipart upload described by the state at the key.  Uploads
+// that have already been completed or aborted are ignored.
+func (h *Handler) abortUpload(ctx context.Context, key, id string) error {
+	mp, ok := h.storage.(storage.Multipart)
+	if !ok {
+		return nil
+	}
+
+	rc, err := h.storage.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("unable to read %s: %w", key, err)
+	}
+	defer rc.Close()
+
+	var state uploadState
+	if err := json.NewDecoder(rc).Decode(&state); err != nil || state.Key == "" {
+		// Without the key there's nothing to abort, the state is removed regardless.
+		log.FromContext(ctx).V(4).Info("unable to read upload state", "key", key, "error", err)
+		return nil
+	}
+
+	if err := mp.AbortUpload(ctx, state.Key, id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("unable to abort upload %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// retainedRevisions returns the expected revision and the most recent distinct
+// revisions in the history, up to the limit.
+func retainedRevisions(status *v1beta1.EnvironmentStatus, limit int) map[string]bool {
+	keep := make(map[string]bool, limit+1)
+	if status.ExpectedRevision != "" {
+		keep[status.ExpectedRevision] = true
+	}
+
+	for _, rev := range status.History {
+		if len(keep) >= limit {
+			break
+		}
+		keep[rev.Revision] = true
+	}
+
+	return keep
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// WorkspacePath is where the source archive is unpacked for engines that can't
+	// read the build context directly from object storage.
+	WorkspacePath = "/workspace"
+	// FetchImage is the image used to download and unpack the source archive from S3.
+	FetchImage = "amazon/aws-cli:latest"
+)
+
+// BuildOptions holds the engine independent settings for a build.
+type BuildOptions struct {
+	// Name is the name of the environment.
+	Name string
+	// Key is the key of the source archive in object storage.
+	Key string
+	// Context is the url of the source archive in the scheme of the storage backend.
+	Context string
+	// Destination is the fully qualified image reference that will be pushed.
+	Destination string
+	// CacheRepo is the repository used to store build cache layers.
+	CacheRepo string
+	// RegistryHost is the host (and port) of the registry.
+	RegistryHost string
+	// Insecure is true when the registry is served over plain http.
+	Insecure bool
+	// Dockerfile is the path of the Dockerfile relative to the build context.
+	Dockerfile string
+	// Platform is the target platform of the image.
+	Platform string
+}
+
+// BuildEngine renders the engine specific parts of the build job.  The builder takes
+// care of the parts that are shared between engines: the storage environment, the
+// credentials, the workspace volume and the user overrides for the command and args.
+type BuildEngine interface {
+	// Command returns the entrypoint for the build container.  Nil uses the image's
+	// entrypoint.
+	Command() []string
+	// Args returns the default arguments for the build container.
+	Args(opts BuildOptions) []string
+	// Env returns any additional environment variables the engine needs.
+	Env(opts BuildOptions) []corev1.EnvVar
+	// SecurityContext returns the security context for the build container.
+	SecurityContext() *corev1.SecurityContext
+	// FetchContext is true if the source archive needs to be unpacked into the
+	// workspace before the build is started.
+	FetchContext() bool
+}
+
+// NewBuildEngine returns the build engine for the given engine type.  Kaniko is used
+// when the engine isn't recognized.
+func NewBuildEngine(engine v1beta1.EnvironmentBuildEngine) BuildEngine {
+	switch engine {
+	case v1beta1.EnvironmentBuildEngineBuildKit:
+		return &buildKit{}
+	case v1beta1.EnvironmentBuildEngineBuildpacks:
+		return &buildpacks{}
+	default:
+		return &kaniko{}
+	}
+}
+
+// kaniko builds directly from the archive in object storage.
+type kaniko struct{}
+
+func (k *kaniko) Command() []string {
+	return nil
+}
+
+func (k *kaniko) Args(opts BuildOptions) []string {
+	args := []string{
+		fmt.Sprintf("--dockerfile=%s", opts.Dockerfile),
+		fmt.Sprintf("--context=%s", opts.Context),
+		fmt.Sprintf("--destination=%s", opts.Destination),
+		// TODO: toggle caching
+		"--cache=true",
+		fmt.Sprintf("--cache-repo=%s", opts.CacheRepo),
+		fmt.Sprintf("--custom-platform=%s", opts.Platform),
+	}
+
+	if opts.Insecure {
+		args = append(args, "--insecure", "--insecure-pull")
+	}
+
+	// TODO: Make this configurable
+	return append(args, "--verbosity=info")
+}
+
+func (k *kaniko) Env(_ BuildOptions) []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+func (k *kaniko) SecurityContext() *corev1.SecurityContext {
+	return nil
+}
+
+func (k *kaniko) FetchContext() bool {
+	return false
+}
+
+// buildKit runs buildkitd and buildctl in the same container using the rootless
+// image.  It supports the newer Dockerfile syntax like cache mounts.
+type buildKit struct{}
+
+func (b *buildKit) Command() []string {
+	return []string{"buildctl-daemonless.sh"}
+}
+
+func (b *buildKit) Args(opts BuildOptions) []string {
+	var insecure string
+	if opts.Insecure {
+		insecure = ",registry.insecure=true"
+	}
+
+	cache := fmt.Sprintf("type=registry,ref=%s:%s-buildkit", opts.CacheRepo, opts.Name)
+
+	return []string{
+		"build",
+		"--frontend=dockerfile.v0",
+		fmt.Sprintf("--local=context=%s", WorkspacePath),
+		fmt.Sprintf("--local=dockerfile=%s", path.Join(WorkspacePath, path.Dir(opts.Dockerfile))),
+		fmt.Sprintf("--opt=filename=%s", path.Base(opts.Dockerfile)),
+		fmt.Sprintf("--opt=platform=%s", opts.Platform),
+		fmt.Sprintf("--output=type=image,name=%s,push=true%s", opts.Destination, insecure),
+		fmt.Sprintf("--export-cache=%s,mode=max%s", cache, insecure),
+		fmt.Sprintf("--import-cache=%s%s", cache, insecure),
+	}
+}
+
+func (b *buildKit) Env(_ BuildOptions) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			// The rootless daemon can't create a new pid namespace without privileges.
+			Name:  "BUILDKITD_FLAGS",
+			Value: "--oci-worker-no-process-sandbox",
+		},
+	}
+}
+
+func (b *buildKit) SecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		RunAsUser:  ptr.To(int64(1000)),
+		RunAsGroup: ptr.To(int64(1000)),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeUnconfined,
+		},
+		AppArmorProfile: &corev1.AppArmorProfile{
+			Type: corev1.AppArmorProfileTypeUnconfined,
+		},
+	}
+}
+
+func (b *buildKit) FetchContext() bool {
+	return true
+}
+
+// buildpacks runs the lifecycle creator from the builder image.  The Dockerfile and
+// platform are ignored as the buildpacks decide how the app is built.
+type buildpacks struct{}
+
+func (b *buildpacks) Command() []string {
+	return []string{"/cnb/lifecycle/creator"}
+}
+
+func (b *buildpacks) Args(opts BuildOptions) []string {
+	args := []string{
+		fmt.Sprintf("-app=%s", WorkspacePath),
+		fmt.Sprintf("-cache-image=%s:%s-buildpacks", opts.CacheRepo, opts.Name),
+		"-log-level=info",
+	}
+
+	if opts.Insecure {
+		args = append(args, fmt.Sprintf("-insecure-registry=%s", opts.RegistryHost))
+	}
+
+	return append(args, opts.Destination)
+}
+
+func (b *buildpacks) Env(_ BuildOptions) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			// Insecure registries require platform API 0.12 or later.
+			Name:  "CNB_PLATFORM_API",
+			Value: "0.12",
+		},
+	}
+}
+
+func (b *buildpacks) SecurityContext() *corev1.SecurityContext {
+	return nil
+}
+
+func (b *buildpacks) FetchContext() bool {
+	return true
+}
+
+// fetchScript returns the shell script used by the init container to download and
+// unpack the source archive into the workspace.  The workspace is opened up after
+// unpacking since the build containers don't necessarily run as root.
+func fetchScript(opts BuildOptions, bs BuildStorage) string {
+	lines := []string{"set -e"}
+	lines = append(lines, bs.FetchCommands(opts.Key, "/tmp/context.tar.gz")...)
+	lines = append(lines,
+		fmt.Sprintf("tar -xzf /tmp/context.tar.gz --no-same-owner -C %s", WorkspacePath),
+		fmt.Sprintf("chmod -R a+rwX %s", WorkspacePath),
+	)
+
+	return strings.Join(lines, "\n")
+}
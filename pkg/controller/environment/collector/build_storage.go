@@ -0,0 +1,305 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/storage"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// StorageVolume is the name of the volume holding the storage credentials or, for
+	// the filesystem storage, the archives themselves.
+	StorageVolume = "storage"
+	// StorageCredentialsPath is where credentials files from the storage secret are
+	// mounted.
+	StorageCredentialsPath = "/var/run/secrets/seaway/storage"
+	// GCSFetchImage and AzureFetchImage download the archive for engines that can't
+	// read the build context directly from object storage.
+	GCSFetchImage   = "google/cloud-sdk:slim"
+	AzureFetchImage = "mcr.microsoft.com/azure-cli:latest"
+	// FilesystemFetchImage unpacks the archive from the mounted volume.
+	FilesystemFetchImage = "busybox:stable"
+)
+
+// BuildStorage renders the parts of the build job that depend on where the source
+// archive is stored: the build context url for kaniko, the credentials and volumes
+// that the build and fetch containers need, and how the archive is fetched.
+type BuildStorage interface {
+	// Context returns the url kaniko reads the build context from.
+	Context(key string) string
+	// Env returns the environment variables that configure the storage client.
+	Env() []corev1.EnvVar
+	// EnvFrom returns the sources the credentials are loaded from.
+	EnvFrom() []corev1.EnvFromSource
+	// Volumes returns the volumes the storage needs in the pod.
+	Volumes() []corev1.Volume
+	// VolumeMounts returns where the volumes are mounted in the containers.
+	VolumeMounts() []corev1.VolumeMount
+	// FetchImage returns the image used to download the archive.
+	FetchImage() string
+	// FetchCommands returns the shell commands that download the archive with the
+	// given key to the destination file.
+	FetchCommands(key, dest string) []string
+}
+
+// NewBuildStorage returns the build storage for the storage config.  S3 is used when
+// the type isn't recognized.
+func NewBuildStorage(config v1beta1.EnvironmentConfigStorage) BuildStorage {
+	switch config.Type {
+	case v1beta1.EnvironmentStorageTypeGCS:
+		return &gcsBuildStorage{config: config}
+	case v1beta1.EnvironmentStorageTypeAzure:
+		return &azureBuildStorage{config: config}
+	case v1beta1.EnvironmentStorageTypeFilesystem:
+		return &filesystemBuildStorage{config: config}
+	default:
+		return &s3BuildStorage{config: config}
+	}
+}
+
+// secretEnvFrom loads every key in the credentials secret as an environment variable.
+func secretEnvFrom(name string) []corev1.EnvFromSource {
+	return []corev1.EnvFromSource{
+		{
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: name,
+				},
+			},
+		},
+	}
+}
+
+// s3BuildStorage reads the archive from an S3 compatible bucket.  Kaniko and the aws
+// cli both pick up the credentials from the environment.
+type s3BuildStorage struct {
+	config v1beta1.EnvironmentConfigStorage
+}
+
+func (s *s3BuildStorage) Context(key string) string {
+	return fmt.Sprintf("s3://%s/%s", s.config.Bucket, key)
+}
+
+func (s *s3BuildStorage) Env() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "AWS_REGION",
+			Value: s.config.Region,
+		},
+		{
+			Name: "S3_ENDPOINT",
+			// Need to add the protocol...  Either force it and strip it when setting
+			// up the client or add it here.
+			Value: s.config.Endpoint,
+		},
+		{
+			Name:  "S3_FORCE_PATH_STYLE",
+			Value: strconv.FormatBool(s.config.ForcePathStyle),
+		},
+	}
+}
+
+func (s *s3BuildStorage) EnvFrom() []corev1.EnvFromSource {
+	return secretEnvFrom(s.config.Credentials)
+}
+
+func (s *s3BuildStorage) Volumes() []corev1.Volume {
+	return []corev1.Volume{}
+}
+
+func (s *s3BuildStorage) VolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{}
+}
+
+func (s *s3BuildStorage) FetchImage() string {
+	return FetchImage
+}
+
+func (s *s3BuildStorage) FetchCommands(key, dest string) []string {
+	lines := []string{}
+	if s.config.ForcePathStyle {
+		lines = append(lines, "aws configure set default.s3.addressing_style path")
+	}
+
+	return append(lines, fmt.Sprintf(`aws s3 cp --endpoint-url "$S3_ENDPOINT" "%s" %s`, s.Context(key), dest))
+}
+
+// gcsBuildStorage reads the archive from a GCS bucket.  The service account key from
+// the credentials secret is mounted as a file since that's the only form kaniko and
+// gcloud accept.
+type gcsBuildStorage struct {
+	config v1beta1.EnvironmentConfigStorage
+}
+
+func (g *gcsBuildStorage) Context(key string) string {
+	return fmt.Sprintf("gs://%s/%s", g.config.Bucket, key)
+}
+
+func (g *gcsBuildStorage) Env() []corev1.EnvVar {
+	credentials := path.Join(StorageCredentialsPath, storage.GCSCredentials)
+	return []corev1.EnvVar{
+		{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: credentials,
+		},
+		{
+			Name:  "CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE",
+			Value: credentials,
+		},
+	}
+}
+
+func (g *gcsBuildStorage) EnvFrom() []corev1.EnvFromSource {
+	return []corev1.EnvFromSource{}
+}
+
+func (g *gcsBuildStorage) Volumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: StorageVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: g.config.Credentials,
+				},
+			},
+		},
+	}
+}
+
+func (g *gcsBuildStorage) VolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      StorageVolume,
+			MountPath: StorageCredentialsPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+func (g *gcsBuildStorage) FetchImage() string {
+	return GCSFetchImage
+}
+
+func (g *gcsBuildStorage) FetchCommands(key, dest string) []string {
+	return []string{
+		fmt.Sprintf(`gcloud storage cp "%s" %s`, g.Context(key), dest),
+	}
+}
+
+// azureBuildStorage reads the archive from an Azure blob container.  Kaniko takes the
+// blob url as the context and the account key from AZURE_STORAGE_ACCESS_KEY.
+type azureBuildStorage struct {
+	config v1beta1.EnvironmentConfigStorage
+}
+
+func (a *azureBuildStorage) Context(key string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(a.config.Endpoint, "/"), a.config.Bucket, key)
+}
+
+func (a *azureBuildStorage) Env() []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+func (a *azureBuildStorage) EnvFrom() []corev1.EnvFromSource {
+	return secretEnvFrom(a.config.Credentials)
+}
+
+func (a *azureBuildStorage) Volumes() []corev1.Volume {
+	return []corev1.Volume{}
+}
+
+func (a *azureBuildStorage) VolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{}
+}
+
+func (a *azureBuildStorage) FetchImage() string {
+	return AzureFetchImage
+}
+
+func (a *azureBuildStorage) FetchCommands(key, dest string) []string {
+	return []string{
+		fmt.Sprintf(`az storage blob download --blob-url "%s" --account-key "$%s" --file %s --only-show-errors`,
+			a.Context(key), storage.AzureAccessKey, dest),
+	}
+}
+
+// filesystemBuildStorage reads the archive from the volume claim the operator stores
+// it on.  The claim is mounted read only at the same path as in the operator.
+type filesystemBuildStorage struct {
+	config v1beta1.EnvironmentConfigStorage
+}
+
+func (f *filesystemBuildStorage) Context(key string) string {
+	return "tar://" + f.archive(key)
+}
+
+func (f *filesystemBuildStorage) Env() []corev1.EnvVar {
+	return []corev1.EnvVar{}
+}
+
+func (f *filesystemBuildStorage) EnvFrom() []corev1.EnvFromSource {
+	return []corev1.EnvFromSource{}
+}
+
+// Volumes returns the claim that the archives are stored on.  The claim name is
+// resolved in the namespace of the build job, which is always the controller
+// namespace, so the claim can't live anywhere else.  The observer checks that it
+// exists before any job is created.
+func (f *filesystemBuildStorage) Volumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: StorageVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: f.config.VolumeClaim,
+					ReadOnly:  true,
+				},
+			},
+		},
+	}
+}
+
+func (f *filesystemBuildStorage) VolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      StorageVolume,
+			MountPath: f.config.Path,
+			ReadOnly:  true,
+		},
+	}
+}
+
+func (f *filesystemBuildStorage) FetchImage() string {
+	return FilesystemFetchImage
+}
+
+func (f *filesystemBuildStorage) FetchCommands(key, dest string) []string {
+	return []string{
+		fmt.Sprintf("cp %s %s", f.archive(key), dest),
+	}
+}
+
+// archive returns the path of the archive on the mounted volume.  It matches the
+// layout used by the filesystem backend in the operator.
+func (f *filesystemBuildStorage) archive(key string) string {
+	return path.Join(f.config.Path, f.config.Bucket, key)
+}
@@ -3,10 +3,8 @@ package collector
 import (
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
-	"ctx.sh/seaway/pkg/util"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -15,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/ptr"
 )
 
@@ -38,14 +37,6 @@ type Builder struct {
 	observed *ObservedState
 	scheme   *runtime.Scheme
 
-	registryURL           string
-	registryNodePort      uint32
-	storageURL            string
-	storageBucket         string
-	storagePrefix         string
-	storageRegion         string
-	storageForcePathStyle bool
-
 	registry *url.URL
 	storage  *url.URL
 }
@@ -58,12 +49,14 @@ func (b *Builder) desired(d *DesiredState) error {
 
 	var err error
 
-	b.storage, err = url.Parse(b.storageURL)
+	config := b.observed.Config.Spec
+
+	b.storage, err = url.Parse(config.Storage.Endpoint)
 	if err != nil {
 		return err
 	}
 
-	b.registry, err = url.Parse(b.registryURL)
+	b.registry, err = url.Parse(config.Registry.URL)
 	if err != nil {
 		return err
 	}
@@ -83,6 +76,7 @@ func (b *Builder) desired(d *DesiredState) error {
 
 func (b *Builder) buildJob() *batchv1.Job { //nolint:funlen
 	env := b.observed.Env
+	storage := b.observed.Config.Spec.Storage
 
 	metatdata := metav1.ObjectMeta{
 		// TODO: This will need to be more specific.
@@ -94,56 +88,78 @@ func (b *Builder) buildJob() *batchv1.Job { //nolint:funlen
 		// TODO: Use the controller as an owner reference.
 	}
 
+	bs := NewBuildStorage(storage)
+	key := b.observed.ArchiveKey()
+
+	opts := BuildOptions{
+		Name:         env.GetName(),
+		Key:          key,
+		Context:      bs.Context(key),
+		Destination:  fmt.Sprintf("%s/%s:%s", b.registry.Host, env.ImageRepository(), env.GetRevision()),
+		CacheRepo:    fmt.Sprintf("%s/build-cache", b.registry.Host),
+		RegistryHost: b.registry.Host,
+		// TODO: The in-cluster registry is plain http, but we should also allow insecure
+		// https registries.
+		Insecure:   b.registry.Scheme != "https",
+		Dockerfile: *env.Spec.Build.Dockerfile,
+		Platform:   *env.Spec.Build.Platform,
+	}
+
+	engine := NewBuildEngine(env.Spec.Build.Engine)
+
+	command := env.Spec.Build.Command
+	if command == nil {
+		command = engine.Command()
+	}
+
 	args := env.Spec.Build.Args
 	if args == nil {
-		args = []string{
-			fmt.Sprintf("--dockerfile=%s", *env.Spec.Build.Dockerfile),
-			fmt.Sprintf("--context=s3://%s/%s", b.storageBucket, util.ArchiveKey(b.storagePrefix, env.GetNamespace(), env.GetName())),
-			fmt.Sprintf("--destination=%s/%s:%s", b.registry.Host, env.GetName(), env.GetRevision()),
-			// TODO: toggle caching
-			"--cache=true",
-			fmt.Sprintf("--cache-repo=%s/build-cache", b.registry.Host),
-			fmt.Sprintf("--custom-platform=%s", *env.Spec.Build.Platform),
-			// TODO: Allow secure as well based on the registry uri parsing.
-			"--insecure",
-			"--insecure-pull",
-			// TODO: Make this configurable
-			"--verbosity=info",
-		}
+		args = engine.Args(opts)
 	}
 
-	vars := []corev1.EnvVar{
-		{
-			Name:  "AWS_REGION",
-			Value: b.storageRegion,
-		},
-		{
-			Name: "S3_ENDPOINT",
-			// Need to add the protocol...  Either force it and strip it when setting
-			// up the client or add it here.
-			Value: b.storageURL,
-		},
-		{
-			Name:  "S3_FORCE_PATH_STYLE",
-			Value: strconv.FormatBool(b.storageForcePathStyle),
-		},
-	}
+	vars := bs.Env()
+	envFrom := bs.EnvFrom()
 
 	container := corev1.Container{
-		Name:    "builder",
-		Image:   *env.Spec.Build.Image,
-		Command: env.Spec.Build.Command,
-		Args:    args,
-		Env:     mergeEnvVar(vars, env.Spec.Vars.Env),
-		EnvFrom: []corev1.EnvFromSource{
+		Name:            "builder",
+		Image:           *env.Spec.Build.Image,
+		Command:         command,
+		Args:            args,
+		Env:             mergeEnvVar(append(vars, engine.Env(opts)...), env.Spec.Vars.Env),
+		EnvFrom:         envFrom,
+		VolumeMounts:    bs.VolumeMounts(),
+		SecurityContext: engine.SecurityContext(),
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers:    []corev1.Container{container},
+		Volumes:       bs.Volumes(),
+	}
+
+	if engine.FetchContext() {
+		mount := corev1.VolumeMount{
+			Name:      "workspace",
+			MountPath: WorkspacePath,
+		}
+
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "workspace",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		podSpec.InitContainers = []corev1.Container{
 			{
-				SecretRef: &corev1.SecretEnvSource{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: "storage-credentials",
-					},
-				},
+				Name:         "fetch",
+				Image:        bs.FetchImage(),
+				Command:      []string{"/bin/sh", "-c", fetchScript(opts, bs)},
+				Env:          vars,
+				EnvFrom:      envFrom,
+				VolumeMounts: append(bs.VolumeMounts(), mount),
 			},
-		},
+		}
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, mount)
 	}
 
 	spec := batchv1.JobSpec{
@@ -167,14 +183,11 @@ func (b *Builder) buildJob() *batchv1.Job { //nolint:funlen
 			ObjectMeta: metav1.ObjectMeta{
 				Labels: map[string]string{
 					"app":   env.GetName(),
-					"etag":  env.GetRevision(),
+					"etag":  revisionLabel(env.GetRevision()),
 					"group": "build",
 				},
 			},
-			Spec: corev1.PodSpec{
-				RestartPolicy: corev1.RestartPolicyNever,
-				Containers:    []corev1.Container{container},
-			},
+			Spec: podSpec,
 		},
 	}
 
@@ -200,7 +213,7 @@ func (b *Builder) buildDeployment() *appsv1.Deployment {
 		}, deployment.Annotations),
 		Labels: mergeMap(map[string]string{
 			"app":  env.GetName(),
-			"etag": env.GetRevision(),
+			"etag": revisionLabel(env.GetRevision()),
 		}, deployment.Labels),
 		OwnerReferences: []metav1.OwnerReference{
 			env.GetControllerReference(),
@@ -209,7 +222,7 @@ func (b *Builder) buildDeployment() *appsv1.Deployment {
 
 	container := corev1.Container{
 		Name:           "app",
-		Image:          fmt.Sprintf("localhost:%d/%s:%s", b.registryNodePort, env.GetName(), env.GetRevision()),
+		Image:          fmt.Sprintf("localhost:%d/%s:%s", b.observed.Config.Spec.Registry.NodePort, env.ImageRepository(), env.GetRevision()),
 		Command:        env.Spec.Command,
 		Args:           env.Spec.Args,
 		WorkingDir:     env.Spec.WorkingDir,
@@ -350,6 +363,17 @@ func (b *Builder) buildIngress() *networkingv1.Ingress {
 	}
 }
 
+// revisionLabel shortens the revision so it fits in a label value.  Content digests
+// are longer than the 63 characters allowed, the full revision is kept in the
+// annotations.
+func revisionLabel(revision string) string {
+	if len(revision) > validation.LabelValueMaxLength {
+		return revision[:validation.LabelValueMaxLength]
+	}
+
+	return revision
+}
+
 func mergeMap(source, target map[string]string) map[string]string {
 	if target == nil {
 		target = make(map[string]string)
@@ -0,0 +1,189 @@
+package collector
+
+import (
+	"net/url"
+	"testing"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuilder_buildJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   v1beta1.EnvironmentBuildEngine
+		command  []string
+		contains string
+		fetch    bool
+	}{
+		{
+			name:     "kaniko",
+			engine:   v1beta1.EnvironmentBuildEngineKaniko,
+			command:  nil,
+			contains: "--context=s3://seaway/artifacts/archives/default/test/1.tar.gz",
+			fetch:    false,
+		},
+		{
+			name:     "buildkit",
+			engine:   v1beta1.EnvironmentBuildEngineBuildKit,
+			command:  []string{"buildctl-daemonless.sh"},
+			contains: "--output=type=image,name=registry:5000/default/test:1,push=true,registry.insecure=true",
+			fetch:    true,
+		},
+		{
+			name:     "buildpacks",
+			engine:   v1beta1.EnvironmentBuildEngineBuildpacks,
+			command:  []string{"/cnb/lifecycle/creator"},
+			contains: "registry:5000/default/test:1",
+			fetch:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := &v1beta1.Environment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "default",
+				},
+				Spec: v1beta1.EnvironmentSpec{
+					Revision: "1",
+					Build: &v1beta1.EnvironmentBuild{
+						Engine: tt.engine,
+					},
+				},
+			}
+			v1beta1.Defaulted(env)
+
+			b := &Builder{
+				observed: &ObservedState{
+					Env: env,
+					Config: &v1beta1.EnvironmentConfig{
+						Spec: v1beta1.EnvironmentConfigSpec{
+							Storage: v1beta1.EnvironmentConfigStorage{
+								Bucket:      "seaway",
+								Prefix:      "artifacts",
+								Credentials: "storage-credentials",
+							},
+						},
+					},
+				},
+				registry: &url.URL{Scheme: "http", Host: "registry:5000"},
+			}
+
+			job := b.buildJob()
+			spec := job.Spec.Template.Spec
+
+			assert.Len(t, spec.Containers, 1)
+			assert.Equal(t, tt.command, spec.Containers[0].Command)
+			assert.Contains(t, spec.Containers[0].Args, tt.contains)
+
+			if tt.fetch {
+				assert.Len(t, spec.InitContainers, 1)
+				assert.Equal(t, WorkspacePath, spec.Containers[0].VolumeMounts[0].MountPath)
+			} else {
+				assert.Empty(t, spec.InitContainers)
+				assert.Empty(t, spec.Volumes)
+			}
+		})
+	}
+}
+
+func TestBuilder_buildJobStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage v1beta1.EnvironmentConfigStorage
+		context string
+		volume  string
+		fetch   string
+	}{
+		{
+			name: "s3",
+			storage: v1beta1.EnvironmentConfigStorage{
+				Type: v1beta1.EnvironmentStorageTypeS3,
+			},
+			context: "s3://seaway/artifacts/archives/default/test/1.tar.gz",
+			fetch:   `aws s3 cp --endpoint-url "$S3_ENDPOINT" "s3://seaway/artifacts/archives/default/test/1.tar.gz" /tmp/context.tar.gz`,
+		},
+		{
+			name: "gcs",
+			storage: v1beta1.EnvironmentConfigStorage{
+				Type: v1beta1.EnvironmentStorageTypeGCS,
+			},
+			context: "gs://seaway/artifacts/archives/default/test/1.tar.gz",
+			volume:  StorageCredentialsPath,
+			fetch:   `gcloud storage cp "gs://seaway/artifacts/archives/default/test/1.tar.gz" /tmp/context.tar.gz`,
+		},
+		{
+			name: "azure",
+			storage: v1beta1.EnvironmentConfigStorage{
+				Type:     v1beta1.EnvironmentStorageTypeAzure,
+				Endpoint: "https://dev.blob.core.windows.net/",
+			},
+			context: "https://dev.blob.core.windows.net/seaway/artifacts/archives/default/test/1.tar.gz",
+			fetch:   `--blob-url "https://dev.blob.core.windows.net/seaway/artifacts/archives/default/test/1.tar.gz"`,
+		},
+		{
+			name: "filesystem",
+			storage: v1beta1.EnvironmentConfigStorage{
+				Type:        v1beta1.EnvironmentStorageTypeFilesystem,
+				Path:        "/var/lib/seaway",
+				VolumeClaim: "seaway-storage",
+			},
+			context: "tar:///var/lib/seaway/seaway/artifacts/archives/default/test/1.tar.gz",
+			volume:  "/var/lib/seaway",
+			fetch:   "cp /var/lib/seaway/seaway/artifacts/archives/default/test/1.tar.gz /tmp/context.tar.gz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.storage
+			config.Bucket = "seaway"
+			config.Prefix = "artifacts"
+			config.Credentials = "storage-credentials"
+
+			b := &Builder{
+				observed: &ObservedState{
+					Config: &v1beta1.EnvironmentConfig{
+						Spec: v1beta1.EnvironmentConfigSpec{
+							Storage: config,
+						},
+					},
+				},
+				registry: &url.URL{Scheme: "http", Host: "registry:5000"},
+			}
+
+			for _, engine := range []v1beta1.EnvironmentBuildEngine{v1beta1.EnvironmentBuildEngineKaniko, v1beta1.EnvironmentBuildEngineBuildKit} {
+				env := &v1beta1.Environment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "default",
+					},
+					Spec: v1beta1.EnvironmentSpec{
+						Revision: "1",
+						Build: &v1beta1.EnvironmentBuild{
+							Engine: engine,
+						},
+					},
+				}
+				v1beta1.Defaulted(env)
+				b.observed.Env = env
+
+				spec := b.buildJob().Spec.Template.Spec
+				if engine == v1beta1.EnvironmentBuildEngineKaniko {
+					assert.Contains(t, spec.Containers[0].Args, "--context="+tt.context)
+				} else {
+					assert.Len(t, spec.InitContainers, 1)
+					assert.Contains(t, spec.InitContainers[0].Command[2], tt.fetch)
+				}
+
+				if tt.volume != "" {
+					assert.Equal(t, StorageVolume, spec.Volumes[0].Name)
+					assert.Equal(t, tt.volume, spec.Containers[0].VolumeMounts[0].MountPath)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package collector
 import (
 	"context"
 
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/lister"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,20 +18,28 @@ type Collection struct {
 type StateCollector struct {
 	Client                client.Client
 	Scheme                *runtime.Scheme
+	DefaultConfig         string
 	RegistryURL           string
 	RegistryNodePort      uint32
+	StorageType           v1beta1.EnvironmentStorageType
 	StorageURL            string
 	StorageBucket         string
 	StoragePrefix         string
 	StorageRegion         string
 	StorageForcePathStyle bool
+	StoragePath           string
+	StorageVolumeClaim    string
+	StorageRetention      int32
 }
 
 func (sc *StateCollector) ObserveAndBuild(ctx context.Context, req ctrl.Request, c *Collection) error {
 	observed := NewObservedState()
 	observer := &StateObserver{
-		Client:  sc.Client,
-		Request: req,
+		Client:        sc.Client,
+		Request:       req,
+		Configs:       lister.NewEnvironmentConfigLister(sc.Client, v1beta1.DefaultControllerNamespace),
+		DefaultConfig: sc.DefaultConfig,
+		Fallback:      sc.fallbackConfig(),
 	}
 
 	err := observer.observe(ctx, observed)
@@ -41,15 +51,8 @@ func (sc *StateCollector) ObserveAndBuild(ctx context.Context, req ctrl.Request,
 
 	desired := NewDesiredState()
 	build := &Builder{
-		observed:              observed,
-		scheme:                sc.Scheme,
-		registryURL:           sc.RegistryURL,
-		registryNodePort:      sc.RegistryNodePort,
-		storageURL:            sc.StorageURL,
-		storageBucket:         sc.StorageBucket,
-		storagePrefix:         sc.StoragePrefix,
-		storageRegion:         sc.StorageRegion,
-		storageForcePathStyle: sc.StorageForcePathStyle,
+		observed: observed,
+		scheme:   sc.Scheme,
 	}
 	err = build.desired(desired)
 	if err != nil {
@@ -60,3 +63,25 @@ func (sc *StateCollector) ObserveAndBuild(ctx context.Context, req ctrl.Request,
 
 	return nil
 }
+
+// fallbackConfig returns the config spec built from the controller options.  It's used
+// when neither the config referenced by the environment or the default config exist.
+func (sc *StateCollector) fallbackConfig() v1beta1.EnvironmentConfigSpec {
+	return v1beta1.EnvironmentConfigSpec{
+		Registry: v1beta1.EnvironmentConfigRegistry{
+			URL:      sc.RegistryURL,
+			NodePort: int32(sc.RegistryNodePort), //nolint:gosec
+		},
+		Storage: v1beta1.EnvironmentConfigStorage{
+			Type:           sc.StorageType,
+			Bucket:         sc.StorageBucket,
+			Endpoint:       sc.StorageURL,
+			ForcePathStyle: sc.StorageForcePathStyle,
+			Path:           sc.StoragePath,
+			Prefix:         sc.StoragePrefix,
+			Region:         sc.StorageRegion,
+			VolumeClaim:    sc.StorageVolumeClaim,
+			Retention:      sc.StorageRetention,
+		},
+	}
+}
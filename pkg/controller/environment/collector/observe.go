@@ -3,13 +3,17 @@ package collector
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/lister"
+	"ctx.sh/seaway/pkg/util"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -17,6 +21,7 @@ import (
 
 type ObservedState struct {
 	Env                *v1beta1.Environment
+	Config             *v1beta1.EnvironmentConfig
 	Job                *batchv1.Job
 	Deployment         *appsv1.Deployment
 	Service            *corev1.Service
@@ -28,6 +33,7 @@ type ObservedState struct {
 func NewObservedState() *ObservedState {
 	return &ObservedState{
 		Env:         nil,
+		Config:      nil,
 		Job:         nil,
 		Deployment:  nil,
 		Service:     nil,
@@ -36,9 +42,37 @@ func NewObservedState() *ObservedState {
 	}
 }
 
+// ImageReference returns the registry reference of the image built for the observed
+// environment revision.
+func (o *ObservedState) ImageReference() string {
+	if o.Env == nil || o.Config == nil {
+		return ""
+	}
+
+	registry, err := url.Parse(o.Config.Spec.Registry.URL)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s:%s", registry.Host, o.Env.ImageRepository(), o.Env.GetRevision())
+}
+
+// ArchiveKey returns the storage key of the source archive for the observed
+// environment revision.
+func (o *ObservedState) ArchiveKey() string {
+	if o.Env == nil || o.Config == nil {
+		return ""
+	}
+
+	return util.ArchiveKey(o.Config.Spec.Storage.Prefix, o.Env.GetNamespace(), o.Env.GetName(), o.Env.GetRevision())
+}
+
 type StateObserver struct {
-	Client  client.Client
-	Request ctrl.Request
+	Client        client.Client
+	Request       ctrl.Request
+	Configs       *lister.EnvironmentConfigLister
+	DefaultConfig string
+	Fallback      v1beta1.EnvironmentConfigSpec
 }
 
 func (o *StateObserver) observe(ctx context.Context, observed *ObservedState) error {
@@ -52,6 +86,14 @@ func (o *StateObserver) observe(ctx context.Context, observed *ObservedState) er
 
 	observed.Env = env
 
+	// Resolve the config that provides the registry and storage settings.
+	config, err := o.observeConfig(ctx, env.Spec.Config)
+	if err != nil {
+		return err
+	}
+
+	observed.Config = config
+
 	// Observe the job
 	job, err := o.observeJob(ctx, o.Request.Name+"-build")
 	if err != nil {
@@ -84,9 +126,18 @@ func (o *StateObserver) observe(ctx context.Context, observed *ObservedState) er
 
 	observed.Ingress = ingress
 
-	// TODO: Make the name configurable. I actually don't think that I need this other
-	// than for verification/validation.
-	storageCredentials, err := o.observeStorageCredentials(ctx, v1beta1.DefaultControllerNamespace, "storage-credentials")
+	if err := config.Spec.Storage.Validate(); err != nil {
+		return err
+	}
+
+	// The filesystem storage doesn't use credentials so there's no secret to look for,
+	// but the build jobs can't start without the claim.
+	if !config.Spec.Storage.RequiresCredentials() {
+		return o.observeStorageVolumeClaim(ctx, v1beta1.DefaultControllerNamespace, config.Spec.Storage.VolumeClaim)
+	}
+
+	// TODO: I actually don't think that I need this other than for verification/validation.
+	storageCredentials, err := o.observeStorageCredentials(ctx, v1beta1.DefaultControllerNamespace, config.Spec.Storage.Credentials)
 	if err != nil {
 		return err
 	}
@@ -105,6 +156,27 @@ func (o *StateObserver) observeEnvironment(ctx context.Context) (*v1beta1.Enviro
 	return &env, nil
 }
 
+func (o *StateObserver) observeConfig(ctx context.Context, name string) (*v1beta1.EnvironmentConfig, error) {
+	if o.Configs != nil {
+		config, err := o.Configs.Resolve(ctx, name, o.DefaultConfig)
+		if err != nil || config != nil {
+			return config, err
+		}
+	}
+
+	// Neither the named config or the default config exist so fall back to the
+	// settings the controller was started with.
+	config := &v1beta1.EnvironmentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.DefaultConfig,
+			Namespace: v1beta1.DefaultControllerNamespace,
+		},
+		Spec: *o.Fallback.DeepCopy(),
+	}
+	v1beta1.Defaulted(config)
+	return config, nil
+}
+
 func (o *StateObserver) observeJob(ctx context.Context, name string) (*batchv1.Job, error) {
 	var job batchv1.Job
 	if err := o.Client.Get(ctx, types.NamespacedName{
@@ -165,3 +237,20 @@ func (o *StateObserver) observeStorageCredentials(ctx context.Context, ns, name
 	}
 	return &secret, nil
 }
+
+// observeStorageVolumeClaim makes sure that the claim backing the filesystem storage
+// exists in the namespace the build jobs run in.  Without it the build pods would sit
+// in pending rather than fail.
+func (o *StateObserver) observeStorageVolumeClaim(ctx context.Context, ns, name string) error {
+	var claim corev1.PersistentVolumeClaim
+	if err := o.Client.Get(ctx, types.NamespacedName{
+		Namespace: ns,
+		Name:      name,
+	}, &claim); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return fmt.Errorf("missing storage volume claim %s/%s", ns, name)
+		}
+		return err
+	}
+	return nil
+}
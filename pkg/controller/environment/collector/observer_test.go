@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/lister"
 	"ctx.sh/seaway/pkg/mock"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -126,3 +128,100 @@ func (s *ObserverTestSuite) TestStateObserver_observeEnvironment() {
 
 	s.Equal(expected.Spec, env.Spec)
 }
+
+func (s *ObserverTestSuite) TestStateObserver_observeConfig() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	observer := &StateObserver{
+		Client:        s.client,
+		Configs:       lister.NewEnvironmentConfigLister(s.client, v1beta1.DefaultControllerNamespace),
+		DefaultConfig: "default",
+		Fallback: v1beta1.EnvironmentConfigSpec{
+			Registry: v1beta1.EnvironmentConfigRegistry{
+				URL:      "http://registry.fallback.svc.cluster.local:5000",
+				NodePort: 31557,
+			},
+		},
+	}
+
+	// Nothing exists yet so the controller settings are used.
+	config, err := observer.observeConfig(ctx, "team")
+	s.NoError(err)
+	s.Equal("default", config.GetName())
+	s.Equal("http://registry.fallback.svc.cluster.local:5000", config.Spec.Registry.URL)
+	s.Equal(v1beta1.DefaultStorageCredentials, config.Spec.Storage.Credentials)
+
+	s.client.ApplyFixtureOrDie(
+		"controller_environment_collector",
+		"test_state_observer_observe_config.yaml",
+	)
+
+	config, err = observer.observeConfig(ctx, "team")
+	s.NoError(err)
+	s.Equal("team", config.GetName())
+	s.Equal("team-credentials", config.Spec.Storage.Credentials)
+	s.Equal(int32(31556), config.Spec.Registry.NodePort)
+
+	// Missing and empty config names use the default config.
+	config, err = observer.observeConfig(ctx, "missing")
+	s.NoError(err)
+	s.Equal("default", config.GetName())
+	s.Equal(v1beta1.DefaultStoragePrefix, config.Spec.Storage.Prefix)
+
+	config, err = observer.observeConfig(ctx, "")
+	s.NoError(err)
+	s.Equal("default", config.GetName())
+}
+
+func (s *ObserverTestSuite) TestStateObserver_observeFilesystemStorage() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.client.ApplyFixtureOrDie(
+		"controller_environment_collector",
+		"test_state_observer_observe_1.yaml",
+	)
+
+	observer := &StateObserver{
+		Client: s.client,
+		Request: ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "test",
+			},
+		},
+		DefaultConfig: "default",
+		Fallback: v1beta1.EnvironmentConfigSpec{
+			Storage: v1beta1.EnvironmentConfigStorage{
+				Type:        v1beta1.EnvironmentStorageTypeFilesystem,
+				VolumeClaim: "seaway-storage",
+			},
+		},
+	}
+
+	// The claim has to be in the controller namespace, where the build jobs run.
+	s.NoError(s.client.Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "seaway-storage",
+			Namespace: "default",
+		},
+	}))
+
+	err := observer.observe(ctx, NewObservedState())
+	s.ErrorContains(err, "missing storage volume claim seaway-system/seaway-storage")
+
+	s.NoError(s.client.Create(ctx, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "seaway-storage",
+			Namespace: v1beta1.DefaultControllerNamespace,
+		},
+	}))
+
+	observed := NewObservedState()
+	s.NoError(observer.observe(ctx, observed))
+	s.Nil(observed.StorageCredentials)
+
+	observer.Fallback.Storage.VolumeClaim = ""
+	s.ErrorContains(observer.observe(ctx, NewObservedState()), "requires a volume claim")
+}
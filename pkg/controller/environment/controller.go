@@ -17,11 +17,14 @@ package environment
 import (
 	"context"
 
-	"ctx.sh/seaway/pkg/tracker"
+	"ctx.sh/seaway/pkg/registry"
+	"ctx.sh/seaway/pkg/storage"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
+	"ctx.sh/seaway/pkg/controller/environment/stage"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,29 +33,40 @@ import (
 )
 
 type Options struct {
+	DefaultConfig         string
 	RegistryURL           string
 	RegistryNodePort      uint32
+	StorageType           v1beta1.EnvironmentStorageType
 	StorageURL            string
 	StorageBucket         string
 	StoragePrefix         string
 	StorageRegion         string
 	StorageForcePathStyle bool
-	Tracker               *tracker.Tracker
+	StoragePath           string
+	StorageVolumeClaim    string
+	StorageRetention      int32
 }
 
 type Controller struct {
 	Options  *Options
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	Logs     stage.LogReader
 	client.Client
 }
 
 func SetupWithManager(mgr ctrl.Manager, opts *Options) error {
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+
 	c := &Controller{
 		Options:  opts,
 		Scheme:   mgr.GetScheme(),
 		Client:   mgr.GetClient(),
 		Recorder: mgr.GetEventRecorderFor("seaway-operator"),
+		Logs:     NewPodLogs(clientset),
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -75,7 +89,10 @@ func SetupWithManager(mgr ctrl.Manager, opts *Options) error {
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses/status,verbs=get
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses/status,verbs=get
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;update
 
 func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -87,13 +104,18 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	sc := &collector.StateCollector{
 		Client:                c.Client,
 		Scheme:                c.Scheme,
+		DefaultConfig:         c.Options.DefaultConfig,
 		RegistryURL:           c.Options.RegistryURL,
 		RegistryNodePort:      c.Options.RegistryNodePort,
+		StorageType:           c.Options.StorageType,
 		StorageURL:            c.Options.StorageURL,
 		StorageBucket:         c.Options.StorageBucket,
 		StoragePrefix:         c.Options.StoragePrefix,
 		StorageRegion:         c.Options.StorageRegion,
 		StorageForcePathStyle: c.Options.StorageForcePathStyle,
+		StoragePath:           c.Options.StoragePath,
+		StorageVolumeClaim:    c.Options.StorageVolumeClaim,
+		StorageRetention:      c.Options.StorageRetention,
 	}
 	if err := sc.ObserveAndBuild(ctx, req, &collection); err != nil {
 		return ctrl.Result{}, err
@@ -104,12 +126,42 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, nil
 	}
 
+	backend, err := newStorage(collection.Observed)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	handler := &Handler{
-		collection:  &collection,
-		client:      c.Client,
-		registryURL: c.Options.RegistryURL,
-		tracker:     c.Options.Tracker,
+		collection: &collection,
+		client:     c.Client,
+		registry:   newRegistry(collection.Observed),
+		storage:    backend,
+		logs:       c.Logs,
+		recorder:   c.Recorder,
+	}
+
+	if !collection.Observed.Env.GetDeletionTimestamp().IsZero() {
+		return handler.finalize(ctx)
+	}
+
+	if err := handler.ensureFinalizer(ctx); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	return handler.reconcile(ctx)
 }
+
+func newRegistry(observed *collector.ObservedState) registry.API {
+	return registry.NewClient(registry.NewHTTPClient()).WithRegistry(observed.Config.Spec.Registry.URL)
+}
+
+// newStorage returns the storage backend for the environment's config using the
+// credentials from the storage secret.
+func newStorage(observed *collector.ObservedState) (storage.Storage, error) {
+	var credentials map[string][]byte
+	if secret := observed.StorageCredentials; secret != nil {
+		credentials = secret.Data
+	}
+
+	return storage.New(observed.Config.Spec.Storage, credentials)
+}
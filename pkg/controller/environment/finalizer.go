@@ -0,0 +1,108 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+	"fmt"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ensureFinalizer adds the finalizer to the environment if it's missing.  A merge
+// patch is used so the defaulted spec isn't written back.
+func (h *Handler) ensureFinalizer(ctx context.Context) error {
+	env := h.collection.Observed.Env
+	if controllerutil.ContainsFinalizer(env, v1beta1.EnvironmentFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(env.DeepCopy())
+	controllerutil.AddFinalizer(env, v1beta1.EnvironmentFinalizer)
+	return h.client.Patch(ctx, env, patch)
+}
+
+// finalize cleans up the resources that live outside of the environment namespace and
+// can't be garbage collected through owner references: the build job, the source archives
+// and the image tags.  The finalizer is only removed once all of them are gone, otherwise
+// the error is returned and the cleanup is retried.
+func (h *Handler) finalize(ctx context.Context) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	env := h.collection.Observed.Env
+	if !controllerutil.ContainsFinalizer(env, v1beta1.EnvironmentFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("cleaning up environment resources")
+
+	if err := h.deleteBuildJob(ctx); err != nil {
+		logger.Error(err, "unable to delete the build job")
+		return ctrl.Result{}, err
+	}
+
+	if err := h.deleteArchives(ctx); err != nil {
+		logger.Error(err, "unable to delete the source archives")
+		return ctrl.Result{}, err
+	}
+
+	if err := h.deleteImages(); err != nil {
+		logger.Error(err, "unable to delete the image tags")
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(env.DeepCopy())
+	controllerutil.RemoveFinalizer(env, v1beta1.EnvironmentFinalizer)
+	if err := h.client.Patch(ctx, env, patch); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	logger.Info("environment resources have been cleaned up")
+	return ctrl.Result{}, nil
+}
+
+func (h *Handler) deleteBuildJob(ctx context.Context) error {
+	job := h.collection.Observed.Job
+	if job == nil {
+		return nil
+	}
+
+	err := h.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
+
+// deleteImages removes every tag in the environment's repository.  The repository is
+// scoped to the namespace, so the manifests can't be shared with other environments.
+func (h *Handler) deleteImages() error {
+	name := h.collection.Observed.Env.ImageRepository()
+
+	tags, err := h.registry.Tags(name)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if err := h.registry.DeleteTag(name, tag); err != nil {
+			return fmt.Errorf("unable to delete %s:%s: %w", name, tag, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package environment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/controller/environment/collector"
+	"ctx.sh/seaway/pkg/mock"
+	"ctx.sh/seaway/pkg/registry"
+	"ctx.sh/seaway/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+type fakeRegistry struct {
+	tags    []string
+	deleted []string
+}
+
+func (f *fakeRegistry) WithRegistry(string) registry.API { return f }
+
+func (f *fakeRegistry) HasTag(string, string) (bool, error) { return true, nil }
+
+func (f *fakeRegistry) Tags(string) ([]string, error) { return f.tags, nil }
+
+func (f *fakeRegistry) Digest(string, string) (string, error) { return "", nil }
+
+func (f *fakeRegistry) DeleteTag(name, tag string) error {
+	f.deleted = append(f.deleted, name+":"+tag)
+	return nil
+}
+
+// fakeStorage lists the objects it was given, returns their data and records deletes.
+// The other storage methods aren't used by the handler.
+type fakeStorage struct {
+	storage.Storage
+	err     error
+	objects []storage.ObjectInfo
+	data    map[string]string
+	deleted []string
+}
+
+func (f *fakeStorage) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	data, ok := f.data[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+func (f *fakeStorage) List(_ context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	objects := make([]storage.ObjectInfo, 0)
+	for _, obj := range f.objects {
+		if strings.HasPrefix(obj.Key, prefix) {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeStorage) Delete(_ context.Context, key string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+// fakeMultipart is a storage backend with multipart uploads that records the uploads
+// that are aborted.
+type fakeMultipart struct {
+	*fakeStorage
+	storage.Multipart
+	aborted []string
+}
+
+func (f *fakeMultipart) AbortUpload(_ context.Context, key, uploadID string) error {
+	f.aborted = append(f.aborted, key+":"+uploadID)
+	return nil
+}
+
+func newFinalizeHandler(t *testing.T, client *mock.Client, reg *fakeRegistry, store storage.Storage) *Handler {
+	var collection collector.Collection
+	sc := &collector.StateCollector{
+		Client:        client,
+		StoragePrefix: "artifacts",
+	}
+	err := sc.ObserveAndBuild(context.TODO(), ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}, &collection)
+	assert.NoError(t, err)
+
+	return &Handler{
+		client:     client,
+		collection: &collection,
+		registry:   reg,
+		storage:    store,
+	}
+}
+
+func TestHandler_Finalize(t *testing.T) {
+	h := mock.NewTestHarness()
+	client := mock.NewClient().
+		WithLogger(h.Logger()).
+		WithFixtureDirectory(filepath.Join("..", "..", "..", "fixtures"))
+	client.ApplyFixtureOrDie("shared", "required.yaml")
+	client.ApplyFixtureOrDie("controller_environment", "finalize.yaml")
+
+	reg := &fakeRegistry{tags: []string{"1", "2"}}
+	store := &fakeMultipart{fakeStorage: &fakeStorage{
+		objects: []storage.ObjectInfo{
+			{Key: "artifacts/archives/default/test/1.tar.gz"},
+			{Key: "artifacts/archives/default/test/2.tar.gz"},
+			{Key: "artifacts/archives/default/other/1.tar.gz"},
+			{Key: "artifacts/uploads/default/test/upload.json", LastModified: time.Now()},
+			{Key: "artifacts/uploads/default/test/staged.tar.gz", LastModified: time.Now()},
+			{Key: "artifacts/uploads/default/other/upload.json"},
+		},
+		data: map[string]string{
+			"artifacts/uploads/default/test/upload.json": `{"key":"artifacts/uploads/default/test/parts.tar.gz"}`,
+		},
+	}}
+	handler := newFinalizeHandler(t, client, reg, store)
+
+	_, err := handler.finalize(context.TODO())
+	assert.NoError(t, err)
+
+	// Unfinished uploads are removed along with the archives, however recent they are.
+	assert.Equal(t, []string{
+		"artifacts/archives/default/test/1.tar.gz",
+		"artifacts/archives/default/test/2.tar.gz",
+		"artifacts/uploads/default/test/upload.json",
+		"artifacts/uploads/default/test/staged.tar.gz",
+	}, store.deleted)
+	assert.Equal(t, []string{"artifacts/uploads/default/test/parts.tar.gz:upload"}, store.aborted)
+	assert.Equal(t, []string{"default/test:1", "default/test:2"}, reg.deleted)
+
+	var job batchv1.Job
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-build", Namespace: v1beta1.DefaultControllerNamespace}, &job)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// With the finalizer removed, the environment is gone.
+	var env v1beta1.Environment
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "default"}, &env)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestHandler_FinalizeStorageError(t *testing.T) {
+	h := mock.NewTestHarness()
+	client := mock.NewClient().
+		WithLogger(h.Logger()).
+		WithFixtureDirectory(filepath.Join("..", "..", "..", "fixtures"))
+	client.ApplyFixtureOrDie("shared", "required.yaml")
+	client.ApplyFixtureOrDie("controller_environment", "finalize.yaml")
+
+	reg := &fakeRegistry{tags: []string{"1"}}
+	store := &fakeStorage{
+		err:     errors.New("unavailable"),
+		objects: []storage.ObjectInfo{{Key: "artifacts/archives/default/test/1.tar.gz"}},
+	}
+	handler := newFinalizeHandler(t, client, reg, store)
+
+	_, err := handler.finalize(context.TODO())
+	assert.Error(t, err)
+	assert.Empty(t, reg.deleted)
+
+	// The finalizer is held until the cleanup succeeds.
+	var env v1beta1.Environment
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "default"}, &env)
+	assert.NoError(t, err)
+	assert.Contains(t, env.GetFinalizers(), v1beta1.EnvironmentFinalizer)
+}
+
+func TestHandler_PruneArchives(t *testing.T) {
+	h := mock.NewTestHarness()
+	client := mock.NewClient().
+		WithLogger(h.Logger()).
+		WithFixtureDirectory(filepath.Join("..", "..", "..", "fixtures"))
+	client.ApplyFixtureOrDie("shared", "required.yaml")
+	client.ApplyFixtureOrDie("controller_environment", "finalize.yaml")
+
+	old := time.Now().Add(-2 * ArchiveGracePeriod)
+	archive := func(revision string, modified time.Time) storage.ObjectInfo {
+		return storage.ObjectInfo{Key: "artifacts/archives/default/test/" + revision + ".tar.gz", LastModified: modified}
+	}
+
+	store := &fakeStorage{objects: []storage.ObjectInfo{
+		archive("1", old),
+		archive("2", old),
+		archive("3", old),
+		archive("4", old),
+		// Uploaded but not recorded yet.
+		archive("5", time.Now()),
+		{Key: "artifacts/archives/default/test/notes.txt", LastModified: old},
+	}}
+	handler := newFinalizeHandler(t, client, &fakeRegistry{}, store)
+	handler.collection.Observed.Config.Spec.Storage.Retention = 2
+
+	status := &v1beta1.EnvironmentStatus{
+		ExpectedRevision: "4",
+		History: []v1beta1.EnvironmentRevision{
+			{Revision: "4"},
+			{Revision: "3"},
+			{Revision: "3"},
+			{Revision: "2"},
+			{Revision: "1"},
+		},
+	}
+
+	err := handler.pruneArchives(context.TODO(), status)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"artifacts/archives/default/test/1.tar.gz",
+		"artifacts/archives/default/test/2.tar.gz",
+	}, store.deleted)
+}
+
+func TestHandler_PruneUploads(t *testing.T) {
+	h := mock.NewTestHarness()
+	client := mock.NewClient().
+		WithLogger(h.Logger()).
+		WithFixtureDirectory(filepath.Join("..", "..", "..", "fixtures"))
+	client.ApplyFixtureOrDie("shared", "required.yaml")
+	client.ApplyFixtureOrDie("controller_environment", "finalize.yaml")
+
+	expired := time.Now().Add(-2 * UploadExpiry)
+	recent := time.Now().Add(-2 * ArchiveGracePeriod)
+
+	store := &fakeMultipart{fakeStorage: &fakeStorage{
+		objects: []storage.ObjectInfo{
+			{Key: "artifacts/uploads/default/test/abandoned.json", LastModified: expired},
+			{Key: "artifacts/uploads/default/test/completed.json", LastModified: expired},
+			{Key: "artifacts/uploads/default/test/corrupt.json", LastModified: expired},
+			{Key: "artifacts/uploads/default/test/staged.tar.gz", LastModified: expired},
+			// Still in progress.
+			{Key: "artifacts/uploads/default/test/active.json", LastModified: recent},
+			{Key: "artifacts/uploads/default/test/active.tar.gz", LastModified: recent},
+			// Belongs to another environment.
+			{Key: "artifacts/uploads/default/testing/abandoned.json", LastModified: expired},
+		},
+		data: map[string]string{
+			"artifacts/uploads/default/test/abandoned.json":    `{"key":"artifacts/uploads/default/test/parts.tar.gz","size":10}`,
+			"artifacts/uploads/default/test/corrupt.json":      `{`,
+			"artifacts/uploads/default/test/active.json":       `{"key":"artifacts/uploads/default/test/active.tar.gz"}`,
+			"artifacts/uploads/default/testing/abandoned.json": `{"key":"artifacts/uploads/default/testing/parts.tar.gz"}`,
+		},
+	}}
+	handler := newFinalizeHandler(t, client, &fakeRegistry{}, store)
+
+	err := handler.pruneArchives(context.TODO(), &v1beta1.EnvironmentStatus{})
+	assert.NoError(t, err)
+
+	// The state of an upload that was completed concurrently is gone, and the state
+	// that can't be read has nothing to abort.  Both are removed regardless.
+	assert.Equal(t, []string{
+		"artifacts/uploads/default/test/abandoned.json",
+		"artifacts/uploads/default/test/completed.json",
+		"artifacts/uploads/default/test/corrupt.json",
+		"artifacts/uploads/default/test/staged.tar.gz",
+	}, store.deleted)
+	assert.Equal(t, []string{"artifacts/uploads/default/test/parts.tar.gz:abandoned"}, store.aborted)
+}
+
+func TestRetainedRevisions(t *testing.T) {
+	status := &v1beta1.EnvironmentStatus{
+		ExpectedRevision: "c",
+		History: []v1beta1.EnvironmentRevision{
+			{Revision: "b"},
+			{Revision: "a"},
+		},
+	}
+
+	assert.Equal(t, map[string]bool{"c": true}, retainedRevisions(status, 1))
+	assert.Equal(t, map[string]bool{"c": true, "b": true}, retainedRevisions(status, 2))
+	assert.Equal(t, map[string]bool{"c": true, "b": true, "a": true}, retainedRevisions(status, 5))
+}
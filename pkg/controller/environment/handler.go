@@ -2,26 +2,34 @@ package environment
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"time"
 
 	"ctx.sh/seaway/pkg/registry"
-	"ctx.sh/seaway/pkg/tracker"
+	"ctx.sh/seaway/pkg/storage"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
 	"ctx.sh/seaway/pkg/controller/environment/stage"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// Transitioning is the event reason used when the environment changes stages.
+const Transitioning = "Transitioning"
+
 type Handler struct {
-	client      client.Client
-	collection  *collector.Collection
-	registryURL string
-	tracker     *tracker.Tracker
+	client     client.Client
+	collection *collector.Collection
+	registry   registry.API
+	storage    storage.Storage
+	logs       stage.LogReader
+	recorder   record.EventRecorder
 }
 
 func (h *Handler) reconcile(ctx context.Context) (ctrl.Result, error) {
@@ -29,16 +37,13 @@ func (h *Handler) reconcile(ctx context.Context) (ctrl.Result, error) {
 
 	logger.V(5).Info("handling reconciliation for revision")
 
-	if h.collection.Observed.StorageCredentials == nil {
+	if h.collection.Observed.StorageCredentials == nil && h.collection.Observed.Config.Spec.Storage.RequiresCredentials() {
 		logger.Error(nil, "unable to reconcile environment without user secrets")
 		return ctrl.Result{}, nil
 	}
 
 	env := h.collection.Observed.Env
-	// TODO(rob): Not sure that I like this.  I'm tracking the env when it comes in and then
-	// 	again after an update.  Seems wasteful, but because of how this is structured right
-	//  now it's required.
-	h.tracker.Track(ctx, env)
+	previous := env.Status.Stage
 
 	switch {
 	case env.HasDeviated():
@@ -53,14 +58,26 @@ func (h *Handler) reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 
 	status := env.Status.DeepCopy()
+	status.ObservedGeneration = env.GetGeneration()
 
 	// TODO: Think about retry also need to wrap a timeout from the last status update.
 	s := h.getStage(status.Stage)
 	next, err := s.Do(ctx, status)
 	status.Stage = next
+	setReadyCondition(status)
+	setRevisionOutcome(status)
+
+	// The tracker picks the transition up through the environment watch.  A new
+	// revision can start in the same stage that the last one was in, so it needs a
+	// sequence of its own or the tracker won't see it.
+	if next != previous || status.ExpectedRevision != env.Status.ExpectedRevision {
+		status.Sequence++
+	}
+	if next != previous && h.recorder != nil {
+		h.recorder.Event(env, corev1.EventTypeNormal, Transitioning, string(next))
+	}
 
 	h.updateStatus(ctx, env, status)
-	h.tracker.Track(ctx, env)
 
 	if err != nil {
 		logger.Error(err, "unable to reconcile environment", "next", next, "status", status)
@@ -69,6 +86,11 @@ func (h *Handler) reconcile(ctx context.Context) (ctrl.Result, error) {
 
 	if next == v1beta1.EnvironmentStageDeployed {
 		logger.Info("revision has been deployed")
+		// The archives are only needed for rebuilds, so failing to remove the old
+		// ones shouldn't hold up the environment.
+		if err := h.pruneArchives(ctx, status); err != nil {
+			logger.Error(err, "unable to prune the source archives")
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -79,16 +101,15 @@ func (h *Handler) getStage(current v1beta1.EnvironmentStage) stage.Stage {
 	switch current {
 	// Initialize
 	case v1beta1.EnvironmentStageInitialize:
-		return stage.NewInitialize(h.client, h.collection)
+		return stage.NewInitialize(h.client, h.collection).WithRegistry(h.registry)
 	case v1beta1.EnvironmentStageBuildImage:
 		return stage.NewBuildImage(h.client, h.collection)
 	case v1beta1.EnvironmentStageBuildImageWait:
-		return stage.NewBuildImageWait(h.client, h.collection)
+		return stage.NewBuildImageWait(h.client, h.collection).WithLogs(h.logs)
 	case v1beta1.EnvironmentStageBuildImageFailing:
-		return stage.NewBuildImageWait(h.client, h.collection)
+		return stage.NewBuildImageWait(h.client, h.collection).WithLogs(h.logs)
 	case v1beta1.EnvironmentStageBuildImageVerify:
-		reg := registry.NewClient(registry.NewHTTPClient()).WithRegistry(h.registryURL)
-		return stage.NewBuildImageVerify(h.client, h.collection).WithRegistry(reg)
+		return stage.NewBuildImageVerify(h.client, h.collection).WithRegistry(h.registry)
 	case v1beta1.EnvironmentStageDeploy:
 		return stage.NewDeploy(h.client, h.collection)
 	case v1beta1.EnvironmentStageDeployVerify:
@@ -98,6 +119,53 @@ func (h *Handler) getStage(current v1beta1.EnvironmentStage) stage.Stage {
 	}
 }
 
+// setReadyCondition summarizes the stage conditions into the ready condition.
+func setReadyCondition(status *v1beta1.EnvironmentStatus) {
+	switch status.Stage {
+	case v1beta1.EnvironmentStageDeployed:
+		status.SetCondition(v1beta1.EnvironmentConditionReady, metav1.ConditionTrue,
+			v1beta1.EnvironmentReasonDeployed, fmt.Sprintf("Revision %s is ready", status.DeployedRevision))
+	case v1beta1.EnvironmentStageBuildImageFailed:
+		status.SetCondition(v1beta1.EnvironmentConditionReady, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonBuildFailed, conditionMessage(status, v1beta1.EnvironmentConditionImageBuilt))
+	case v1beta1.EnvironmentStageDeployFailed:
+		status.SetCondition(v1beta1.EnvironmentConditionReady, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonDeployFailed, conditionMessage(status, v1beta1.EnvironmentConditionDeployed))
+	case v1beta1.EnvironmentStageFailed:
+		status.SetCondition(v1beta1.EnvironmentConditionReady, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonError, string(status.Stage))
+	default:
+		status.SetCondition(v1beta1.EnvironmentConditionReady, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonProgressing, string(status.Stage))
+	}
+}
+
+// setRevisionOutcome records the outcome of the current revision in the history once
+// it has been deployed or has failed.
+func setRevisionOutcome(status *v1beta1.EnvironmentStatus) {
+	rev := status.CurrentRevision()
+	if rev == nil {
+		return
+	}
+
+	switch status.Stage {
+	case v1beta1.EnvironmentStageDeployed:
+		rev.Outcome = v1beta1.EnvironmentRevisionDeployed
+	case v1beta1.EnvironmentStageBuildImageFailed:
+		rev.Outcome = v1beta1.EnvironmentRevisionBuildFailed
+	case v1beta1.EnvironmentStageDeployFailed:
+		rev.Outcome = v1beta1.EnvironmentRevisionDeployFailed
+	}
+}
+
+func conditionMessage(status *v1beta1.EnvironmentStatus, conditionType string) string {
+	if condition := status.GetCondition(conditionType); condition != nil && condition.Message != "" {
+		return condition.Message
+	}
+
+	return string(status.Stage)
+}
+
 func (h *Handler) updateStatus(ctx context.Context, env *v1beta1.Environment, status *v1beta1.EnvironmentStatus) {
 	logger := log.FromContext(ctx)
 	logger.Info("updating environment status", "status", status)
@@ -0,0 +1,53 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package environment
+
+import (
+	"context"
+
+	"ctx.sh/seaway/pkg/controller/environment/stage"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodLogs reads container logs using the core client.  The controller-runtime client
+// doesn't support the log subresource.
+type PodLogs struct {
+	clientset kubernetes.Interface
+}
+
+// NewPodLogs returns a new log reader.
+func NewPodLogs(clientset kubernetes.Interface) *PodLogs {
+	return &PodLogs{
+		clientset: clientset,
+	}
+}
+
+// TailLogs returns the last lines of the container log, limited to the given number
+// of bytes.
+func (p *PodLogs) TailLogs(ctx context.Context, namespace, pod, container string, lines, limit int64) (string, error) {
+	out, err := p.clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+		Container:  container,
+		TailLines:  &lines,
+		LimitBytes: &limit,
+	}).DoRaw(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+var _ stage.LogReader = &PodLogs{}
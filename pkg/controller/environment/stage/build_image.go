@@ -2,6 +2,7 @@ package stage
 
 import (
 	"context"
+	"fmt"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
@@ -32,6 +33,8 @@ func (b *BuildImage) Do(ctx context.Context, status *v1beta1.EnvironmentStatus)
 
 	if equality.Semantic.DeepEqual(b.observed.Job, b.desired.Job) {
 		logger.V(4).Info("job has not changed, skipping creation")
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionTrue,
+			v1beta1.EnvironmentReasonBuildUnchanged, "The build job has not changed")
 		return v1beta1.EnvironmentStageDeploy, nil
 	}
 
@@ -45,6 +48,8 @@ func (b *BuildImage) Do(ctx context.Context, status *v1beta1.EnvironmentStatus)
 			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
 		}); err != nil {
 			if client.IgnoreNotFound(err) != nil {
+				status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+					v1beta1.EnvironmentReasonBuildFailed, fmt.Sprintf("Unable to delete build job %s: %s", b.observed.Job.GetName(), err.Error()))
 				return v1beta1.EnvironmentStageBuildImageFailed, err
 			}
 			// If the job was observed but deleted before we we could delete it, we
@@ -56,9 +61,18 @@ func (b *BuildImage) Do(ctx context.Context, status *v1beta1.EnvironmentStatus)
 	logger.V(4).Info("creating new job", "job", b.desired.Job.ObjectMeta)
 	err := b.Create(ctx, b.desired.Job)
 	if err != nil {
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonBuildFailed, fmt.Sprintf("Unable to create build job %s: %s", b.desired.Job.GetName(), err.Error()))
 		return v1beta1.EnvironmentStageBuildImageFailed, err
 	}
 
+	updateRevision(status, func(rev *v1beta1.EnvironmentRevision) {
+		rev.BuildStarted = ptr.To(metav1.Now())
+	})
+
+	status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionUnknown,
+		v1beta1.EnvironmentReasonBuilding, fmt.Sprintf("Build job %s has been created", b.desired.Job.GetName()))
+
 	return v1beta1.EnvironmentStageBuildImageWait, nil
 }
 
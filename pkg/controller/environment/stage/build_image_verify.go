@@ -2,10 +2,12 @@ package stage
 
 import (
 	"context"
+	"fmt"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
 	"ctx.sh/seaway/pkg/registry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -32,10 +34,26 @@ func (b *BuildImageVerify) WithRegistry(registry registry.API) *BuildImageVerify
 func (b *BuildImageVerify) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1beta1.EnvironmentStage, error) {
 	env := b.observed.Env
 
-	if ok, err := b.registry.HasTag(env.GetName(), env.GetRevision()); !ok || err != nil {
+	ok, err := b.registry.HasTag(env.ImageRepository(), env.GetRevision())
+	if err != nil {
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonImageNotFound, fmt.Sprintf("Unable to verify the image: %s", err.Error()))
 		return v1beta1.EnvironmentStageBuildImageFailed, err
 	}
 
+	if !ok {
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonImageNotFound, fmt.Sprintf("Image %s:%s was not found in the registry", env.ImageRepository(), env.GetRevision()))
+		return v1beta1.EnvironmentStageBuildImageFailed, nil
+	}
+
+	digest := imageDigest(ctx, b.registry, env)
+	updateRevision(status, func(rev *v1beta1.EnvironmentRevision) {
+		rev.Digest = digest
+	})
+
+	status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionTrue,
+		v1beta1.EnvironmentReasonImageVerified, fmt.Sprintf("Image %s:%s exists in the registry", env.ImageRepository(), env.GetRevision()))
 	return v1beta1.EnvironmentStageDeploy, nil
 }
 
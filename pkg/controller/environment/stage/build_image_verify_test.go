@@ -11,6 +11,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// MockDigest is the digest the mock registry returns for every image.
+const MockDigest = "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
 type MockRegistry struct {
 	HasTagRespose bool
 }
@@ -31,6 +34,18 @@ func (m *MockRegistry) HasTag(name, tag string) (bool, error) {
 	return m.HasTagRespose, nil
 }
 
+func (m *MockRegistry) Tags(name string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (m *MockRegistry) Digest(name, tag string) (string, error) {
+	return MockDigest, nil
+}
+
+func (m *MockRegistry) DeleteTag(name, tag string) error {
+	return nil
+}
+
 var _ registry.API = &MockRegistry{}
 
 func TestBuildImageVerify(t *testing.T) {
@@ -38,11 +53,13 @@ func TestBuildImageVerify(t *testing.T) {
 		name     string
 		hasTag   bool
 		expected v1beta1.EnvironmentStage
+		digest   string
 	}{
 		{
 			name:     "tag exists",
 			hasTag:   true,
 			expected: v1beta1.EnvironmentStageDeploy,
+			digest:   MockDigest,
 		},
 		{
 			name:     "tag does not exist",
@@ -71,9 +88,16 @@ func TestBuildImageVerify(t *testing.T) {
 			mockRegistry.(*MockRegistry).SetHasTag(tt.hasTag)
 
 			b := NewBuildImageVerify(nil, collection).WithRegistry(mockRegistry)
-			stage, err := b.Do(context.TODO(), &v1beta1.EnvironmentStatus{})
+			status := &v1beta1.EnvironmentStatus{
+				ExpectedRevision: "1",
+				History: []v1beta1.EnvironmentRevision{
+					{Revision: "1", Outcome: v1beta1.EnvironmentRevisionPending},
+				},
+			}
+			stage, err := b.Do(context.TODO(), status)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected, stage)
+			assert.Equal(t, tt.digest, status.CurrentRevision().Digest)
 		})
 	}
 }
@@ -3,16 +3,34 @@ package stage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	// BuildLogTailLines is the number of lines kept from the build log when a build fails.
+	BuildLogTailLines int64 = 50
+	// BuildLogLimitBytes caps the size of the captured log to keep the status small.
+	BuildLogLimitBytes int64 = 8192
+)
+
+// LogReader reads the tail of a container log.
+type LogReader interface {
+	TailLogs(ctx context.Context, namespace, pod, container string, lines, limit int64) (string, error)
+}
+
 type BuildImageWait struct {
 	observed *collector.ObservedState
 	desired  *collector.DesiredState
+	logs     LogReader
 	client.Client
 }
 
@@ -24,6 +42,12 @@ func NewBuildImageWait(client client.Client, collection *collector.Collection) *
 	}
 }
 
+// WithLogs sets the reader used to capture the build log when the build fails.
+func (b *BuildImageWait) WithLogs(logs LogReader) *BuildImageWait {
+	b.logs = logs
+	return b
+}
+
 func (b *BuildImageWait) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1beta1.EnvironmentStage, error) {
 	logger := log.FromContext(ctx)
 	logger.V(4).Info("waiting for build job to complete")
@@ -32,24 +56,96 @@ func (b *BuildImageWait) Do(ctx context.Context, status *v1beta1.EnvironmentStat
 	if job == nil {
 		// TODO: we should probably check to see if the image exists before failing.  I
 		// 		 could just do this by passing on to the verification stage.
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonBuildFailed, "The build job could not be found")
 		next := v1beta1.EnvironmentStageBuildImageFailed
 		return next, errors.New("build failed")
 	}
 
 	if job.Status.Active > 0 && job.Status.Failed > 0 {
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+			v1beta1.EnvironmentReasonBuildFailing, fmt.Sprintf("Build job %s has %d failed attempts", job.GetName(), job.Status.Failed))
 		return v1beta1.EnvironmentStageBuildImageFailing, nil
 	} else {
 		if job.Status.CompletionTime != nil {
+			updateRevision(status, func(rev *v1beta1.EnvironmentRevision) {
+				rev.BuildCompleted = job.Status.CompletionTime.DeepCopy()
+			})
+			status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionUnknown,
+				v1beta1.EnvironmentReasonVerifying, fmt.Sprintf("Build job %s has completed", job.GetName()))
 			return v1beta1.EnvironmentStageBuildImageVerify, nil
 		}
 
 		if len(job.Status.Conditions) > 0 {
+			status.Reason = fmt.Sprintf("Build job %s has failed", job.GetName())
+			status.BuildLog = b.buildLog(ctx, job)
+			status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionFalse,
+				v1beta1.EnvironmentReasonBuildFailed, fmt.Sprintf("Build job %s has failed", job.GetName()))
 			next := v1beta1.EnvironmentStageBuildImageFailed
 			return next, errors.New("build failed")
 		}
 	}
 
+	status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionUnknown,
+		v1beta1.EnvironmentReasonBuilding, fmt.Sprintf("Waiting for build job %s to complete", job.GetName()))
 	return v1beta1.EnvironmentStageBuildImageWait, nil
 }
 
+// buildLog returns the tail of the log of the container that failed in the most recent
+// pod of the job.  Failing to read the log shouldn't hide the build failure, so errors
+// are only logged.
+func (b *BuildImageWait) buildLog(ctx context.Context, job *batchv1.Job) string {
+	logger := log.FromContext(ctx)
+
+	if b.logs == nil {
+		return ""
+	}
+
+	var pods corev1.PodList
+	if err := b.List(ctx, &pods, client.InNamespace(job.GetNamespace()), client.MatchingLabels{
+		"job-name": job.GetName(),
+	}); err != nil {
+		logger.Error(err, "unable to list the build pods", "job", job.GetName())
+		return ""
+	}
+
+	if len(pods.Items) == 0 {
+		return ""
+	}
+
+	latest := pods.Items[0]
+	for _, pod := range pods.Items[1:] {
+		if latest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			latest = pod
+		}
+	}
+
+	container := failedContainer(&latest)
+	out, err := b.logs.TailLogs(ctx, latest.GetNamespace(), latest.GetName(), container, BuildLogTailLines, BuildLogLimitBytes)
+	if err != nil {
+		logger.Error(err, "unable to read the build log", "pod", latest.GetName(), "container", container)
+		return ""
+	}
+
+	return strings.TrimRight(out, "\n")
+}
+
+// failedContainer returns the name of the container that failed the pod.  The init
+// containers run first, so a failure to fetch the source is found before the builder,
+// which never started.  If no container has exited with an error the builder is used.
+func failedContainer(pod *corev1.Pod) string {
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	for _, cs := range statuses {
+		for _, state := range []corev1.ContainerState{cs.State, cs.LastTerminationState} {
+			if state.Terminated != nil && state.Terminated.ExitCode != 0 {
+				return cs.Name
+			}
+		}
+	}
+
+	return "builder"
+}
+
 var _ Stage = &BuildImageWait{}
@@ -3,6 +3,7 @@ package stage
 import (
 	"context"
 	"testing"
+	"time"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
@@ -19,6 +20,7 @@ func TestBuildImageWait(t *testing.T) {
 		name     string
 		status   batchv1.JobStatus
 		expected v1beta1.EnvironmentStage
+		reason   string
 	}{
 		{
 			name: "job started, but not yet active",
@@ -30,6 +32,7 @@ func TestBuildImageWait(t *testing.T) {
 				Conditions:     []batchv1.JobCondition{},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageWait,
+			reason:   v1beta1.EnvironmentReasonBuilding,
 		},
 		{
 			name: "job started, is active but not ready",
@@ -41,6 +44,7 @@ func TestBuildImageWait(t *testing.T) {
 				Conditions:     []batchv1.JobCondition{},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageWait,
+			reason:   v1beta1.EnvironmentReasonBuilding,
 		},
 		{
 			name: "job started, is active and ready",
@@ -52,6 +56,7 @@ func TestBuildImageWait(t *testing.T) {
 				Conditions:     []batchv1.JobCondition{},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageWait,
+			reason:   v1beta1.EnvironmentReasonBuilding,
 		},
 		{
 			name: "job started, is active and ready",
@@ -63,6 +68,7 @@ func TestBuildImageWait(t *testing.T) {
 				Conditions:     []batchv1.JobCondition{},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageVerify,
+			reason:   v1beta1.EnvironmentReasonVerifying,
 		},
 		{
 			name: "job started, is active and failing",
@@ -75,6 +81,7 @@ func TestBuildImageWait(t *testing.T) {
 				Conditions:     []batchv1.JobCondition{},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageFailing,
+			reason:   v1beta1.EnvironmentReasonBuildFailing,
 		},
 		{
 			name: "job has failed",
@@ -91,6 +98,7 @@ func TestBuildImageWait(t *testing.T) {
 				},
 			},
 			expected: v1beta1.EnvironmentStageBuildImageFailed,
+			reason:   v1beta1.EnvironmentReasonBuildFailed,
 		},
 	}
 
@@ -126,7 +134,8 @@ func TestBuildImageWait(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			collection.Observed.Job.Status = tt.status
 			bv := NewBuildImageWait(mc, &collection)
-			stage, err := bv.Do(context.TODO(), &v1beta1.EnvironmentStatus{})
+			status := &v1beta1.EnvironmentStatus{}
+			stage, err := bv.Do(context.TODO(), status)
 
 			if tt.expected == v1beta1.EnvironmentStageBuildImageFailed {
 				assert.Error(t, err)
@@ -134,6 +143,10 @@ func TestBuildImageWait(t *testing.T) {
 				assert.NoError(t, err)
 			}
 			assert.Equal(t, tt.expected, stage)
+
+			condition := status.GetCondition(v1beta1.EnvironmentConditionImageBuilt)
+			assert.NotNil(t, condition)
+			assert.Equal(t, tt.reason, condition.Reason)
 		})
 	}
 }
@@ -151,3 +164,139 @@ func TestBuildImageWait_NilJob(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, v1beta1.EnvironmentStageBuildImageFailed, stage)
 }
+
+type fakeLogReader struct {
+	pod       string
+	container string
+}
+
+func (f *fakeLogReader) TailLogs(_ context.Context, _, pod, container string, _, _ int64) (string, error) {
+	f.pod = pod
+	f.container = container
+	return "error building image: COPY failed\n", nil
+}
+
+func TestBuildImageWait_BuildLog(t *testing.T) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-build",
+			Namespace: "default",
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:   batchv1.JobFailed,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	mc := mock.NewClient()
+	for i, name := range []string{"test-build-first", "test-build-second"} {
+		assert.NoError(t, mc.Create(context.TODO(), &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(metav1.Now().Add(time.Duration(i) * time.Minute)),
+				Labels: map[string]string{
+					"job-name": "test-build",
+				},
+			},
+		}))
+	}
+
+	logs := &fakeLogReader{}
+	bv := NewBuildImageWait(mc, &collector.Collection{
+		Observed: &collector.ObservedState{Job: job},
+	}).WithLogs(logs)
+
+	status := &v1beta1.EnvironmentStatus{}
+	stage, err := bv.Do(context.TODO(), status)
+	assert.Error(t, err)
+	assert.Equal(t, v1beta1.EnvironmentStageBuildImageFailed, stage)
+	assert.Equal(t, "test-build-second", logs.pod)
+	assert.Equal(t, "builder", logs.container)
+	assert.Equal(t, "error building image: COPY failed", status.BuildLog)
+}
+
+func TestFailedContainer(t *testing.T) {
+	var tests = []struct {
+		name     string
+		status   corev1.PodStatus
+		expected string
+	}{
+		{
+			name:     "no statuses",
+			status:   corev1.PodStatus{},
+			expected: "builder",
+		},
+		{
+			name: "fetch failed",
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "fetch",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+						},
+					},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "builder",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+						},
+					},
+				},
+			},
+			expected: "fetch",
+		},
+		{
+			name: "fetch failed and restarted",
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "fetch",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+						},
+					},
+				},
+			},
+			expected: "fetch",
+		},
+		{
+			name: "builder failed",
+			status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "fetch",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+						},
+					},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name: "builder",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+						},
+					},
+				},
+			},
+			expected: "builder",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, failedContainer(&corev1.Pod{Status: tt.status}))
+		})
+	}
+}
@@ -40,6 +40,7 @@ func (d *Deploy) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1b
 
 	if op, err := d.createOrUpdate(ctx, d.observed.Deployment, d.desired.Deployment); err != nil {
 		status.Reason = fmt.Sprintf("Unable to %s deployment %s: %s", op, d.desired.Deployment.GetName(), err.Error())
+		status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, status.Reason)
 		return v1beta1.EnvironmentStageDeployFailed, err
 	} else {
 		logger.V(5).Info("deployment", "operation", op)
@@ -49,11 +50,13 @@ func (d *Deploy) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1b
 		err := d.delete(ctx, d.observed.Service)
 		if err != nil {
 			status.Reason = fmt.Sprintf("Unable to delete service %s: %s", d.observed.Service.GetName(), err.Error())
+			status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, status.Reason)
 			return v1beta1.EnvironmentStageDeployFailed, err
 		}
 	} else {
 		if op, err := d.createOrUpdate(ctx, d.observed.Service, d.desired.Service); err != nil {
 			status.Reason = fmt.Sprintf("Unable to %s service %s: %s", op, d.desired.Service.GetName(), err.Error())
+			status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, status.Reason)
 			return v1beta1.EnvironmentStageDeployFailed, err
 		} else {
 			logger.V(5).Info("service", "operation", op)
@@ -64,17 +67,21 @@ func (d *Deploy) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1b
 		err := d.delete(ctx, d.observed.Ingress)
 		if err != nil {
 			status.Reason = fmt.Sprintf("Unable to delete ingress %s: %s", d.observed.Ingress.GetName(), err.Error())
+			status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, status.Reason)
 			return v1beta1.EnvironmentStageDeployFailed, err
 		}
 	} else {
 		if op, err := d.createOrUpdate(ctx, d.observed.Ingress, d.desired.Ingress); err != nil {
 			status.Reason = fmt.Sprintf("Unable to %s ingress %s: %s", op, d.desired.Ingress.GetName(), err.Error())
+			status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, status.Reason)
 			return v1beta1.EnvironmentStageDeployFailed, err
 		} else {
 			logger.V(5).Info("ingress", "operation", op)
 		}
 	}
 
+	status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionUnknown,
+		v1beta1.EnvironmentReasonDeploying, "Waiting for the deployment to become available")
 	return v1beta1.EnvironmentStageDeployVerify, nil
 }
 
@@ -2,12 +2,22 @@ package stage
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ContainerRestartLimit is the number of restarts after which a container that keeps
+// being terminated is considered failed, even if it hasn't reached CrashLoopBackOff.
+const ContainerRestartLimit = 3
+
 type DeployVerify struct {
 	observed *collector.ObservedState
 	desired  *collector.DesiredState
@@ -26,12 +36,156 @@ func (d *DeployVerify) Do(ctx context.Context, status *v1beta1.EnvironmentStatus
 	deploy := d.observed.Deployment
 	env := d.observed.Env
 
+	if reason := progressDeadlineExceeded(deploy); reason != "" {
+		return d.fail(status, reason)
+	}
+
+	reason, err := d.podFailure(ctx)
+	if err != nil {
+		status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionUnknown, v1beta1.EnvironmentReasonError,
+			fmt.Sprintf("Unable to list the pods for deployment %s: %s", deploy.GetName(), err.Error()))
+		return v1beta1.EnvironmentStageDeployVerify, err
+	}
+
+	if reason != "" {
+		return d.fail(status, reason)
+	}
+
 	if deploy.Status.AvailableReplicas < *deploy.Spec.Replicas {
+		status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionUnknown, v1beta1.EnvironmentReasonWaitingForReplicas,
+			fmt.Sprintf("%d of %d replicas are available", deploy.Status.AvailableReplicas, *deploy.Spec.Replicas))
 		return v1beta1.EnvironmentStageDeployVerify, nil
 	}
 
 	status.DeployedRevision = env.GetRevision()
+	updateRevision(status, func(rev *v1beta1.EnvironmentRevision) {
+		rev.Deployed = ptr.To(metav1.Now())
+	})
+	status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionTrue,
+		v1beta1.EnvironmentReasonDeployed, fmt.Sprintf("Revision %s has been deployed", env.GetRevision()))
 	return v1beta1.EnvironmentStageDeployed, nil
 }
 
+func (d *DeployVerify) fail(status *v1beta1.EnvironmentStatus, reason string) (v1beta1.EnvironmentStage, error) {
+	status.Reason = reason
+	status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionFalse, v1beta1.EnvironmentReasonDeployFailed, reason)
+	return v1beta1.EnvironmentStageDeployFailed, nil
+}
+
+// podFailure looks for pods of the current revision that will not recover on their
+// own and returns a description of the first failure found.  Pods from previous
+// revisions are ignored as they are replaced during the rollout.
+func (d *DeployVerify) podFailure(ctx context.Context) (string, error) {
+	env := d.observed.Env
+
+	var pods corev1.PodList
+	if err := d.Client.List(ctx, &pods, client.InNamespace(env.GetNamespace()), client.MatchingLabels{
+		"app":   env.GetName(),
+		"group": "application",
+	}); err != nil {
+		return "", err
+	}
+
+	// The deployment pulls through the node port rather than the registry service, so
+	// only the repository and tag are compared.
+	image := fmt.Sprintf("/%s:%s", env.ImageRepository(), env.GetRevision())
+	for _, pod := range pods.Items {
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		if !podHasImage(&pod, image) {
+			continue
+		}
+
+		statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) //nolint:gocritic
+		for _, cs := range statuses {
+			if reason := containerFailure(cs); reason != "" {
+				return fmt.Sprintf("Container %s in pod %s %s", cs.Name, pod.GetName(), reason), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+func podHasImage(pod *corev1.Pod, suffix string) bool {
+	for _, c := range pod.Spec.Containers {
+		if strings.HasSuffix(c.Image, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containerFailure returns the reason a container is failing, or an empty string if it
+// isn't.  Only states that the kubelet won't recover from without a change are failures:
+// the back-off states and a container that keeps being terminated.  A single pull error
+// or OOM kill is retried by the kubelet and may still succeed.  The last termination
+// message is included when there is one since it's usually the most useful piece of
+// information about why the app is crashing.
+func containerFailure(cs corev1.ContainerStatus) string {
+	if waiting := cs.State.Waiting; waiting != nil {
+		switch waiting.Reason {
+		case "CrashLoopBackOff":
+			last := cs.LastTerminationState.Terminated
+			if last != nil && last.Reason == "OOMKilled" {
+				return "was OOMKilled" + terminationMessage(last)
+			}
+			if last != nil {
+				return fmt.Sprintf("is in CrashLoopBackOff (exit code %d)%s", last.ExitCode, terminationMessage(last))
+			}
+			return "is in CrashLoopBackOff"
+		case "ImagePullBackOff", "InvalidImageName":
+			if waiting.Message != "" {
+				return fmt.Sprintf("is in %s: %s", waiting.Reason, waiting.Message)
+			}
+			return "is in " + waiting.Reason
+		}
+	}
+
+	if cs.RestartCount < ContainerRestartLimit {
+		return ""
+	}
+
+	terminated := cs.State.Terminated
+	if terminated == nil {
+		terminated = cs.LastTerminationState.Terminated
+	}
+
+	switch {
+	case terminated == nil:
+		return ""
+	case terminated.Reason == "OOMKilled":
+		return fmt.Sprintf("was OOMKilled %d times%s", cs.RestartCount, terminationMessage(terminated))
+	case terminated.ExitCode != 0:
+		return fmt.Sprintf("has restarted %d times (exit code %d)%s", cs.RestartCount, terminated.ExitCode, terminationMessage(terminated))
+	default:
+		return ""
+	}
+}
+
+func terminationMessage(state *corev1.ContainerStateTerminated) string {
+	if state.Message == "" {
+		return ""
+	}
+
+	return ": " + state.Message
+}
+
+// progressDeadlineExceeded returns the condition message if the deployment controller
+// has given up on the rollout.
+func progressDeadlineExceeded(deploy *appsv1.Deployment) string {
+	for _, cond := range deploy.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == "ProgressDeadlineExceeded" {
+			return fmt.Sprintf("Deployment %s exceeded its progress deadline: %s", deploy.GetName(), cond.Message)
+		}
+	}
+
+	return ""
+}
+
 var _ Stage = &DeployVerify{}
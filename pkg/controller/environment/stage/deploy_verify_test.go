@@ -6,23 +6,51 @@ import (
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
+	"ctx.sh/seaway/pkg/mock"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DeployVerifyExpected struct {
-	revision string
-	stage    v1beta1.EnvironmentStage
+	revision  string
+	stage     v1beta1.EnvironmentStage
+	condition metav1.ConditionStatus
+	reason    string
+}
+
+func deployVerifyPod(name, image string, status corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"app":   "test",
+				"group": "application",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: image},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{status},
+		},
+	}
 }
 
 func TestDeployVerify(t *testing.T) {
 	var tests = []struct {
-		name      string
-		replicas  int32
-		available int32
-		revision  string
-		expected  DeployVerifyExpected
+		name       string
+		replicas   int32
+		available  int32
+		revision   string
+		conditions []appsv1.DeploymentCondition
+		pods       []*corev1.Pod
+		expected   DeployVerifyExpected
 	}{
 		{
 			name:      "no available replicas",
@@ -30,8 +58,9 @@ func TestDeployVerify(t *testing.T) {
 			available: 0,
 			revision:  "2",
 			expected: DeployVerifyExpected{
-				revision: "",
-				stage:    v1beta1.EnvironmentStageDeployVerify,
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployVerify,
+				condition: metav1.ConditionUnknown,
 			},
 		},
 		{
@@ -40,8 +69,9 @@ func TestDeployVerify(t *testing.T) {
 			available: 1,
 			revision:  "2",
 			expected: DeployVerifyExpected{
-				revision: "2",
-				stage:    v1beta1.EnvironmentStageDeployed,
+				revision:  "2",
+				stage:     v1beta1.EnvironmentStageDeployed,
+				condition: metav1.ConditionTrue,
 			},
 		},
 		{
@@ -50,8 +80,196 @@ func TestDeployVerify(t *testing.T) {
 			available: 1,
 			revision:  "2",
 			expected: DeployVerifyExpected{
-				revision: "",
-				stage:    v1beta1.EnvironmentStageDeployVerify,
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployVerify,
+				condition: metav1.ConditionUnknown,
+			},
+		},
+		{
+			name:      "progress deadline exceeded",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			conditions: []appsv1.DeploymentCondition{
+				{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ProgressDeadlineExceeded",
+					Message: `ReplicaSet "test-abc" has timed out progressing.`,
+				},
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployFailed,
+				condition: metav1.ConditionFalse,
+				reason:    `Deployment test exceeded its progress deadline: ReplicaSet "test-abc" has timed out progressing.`,
+			},
+		},
+		{
+			name:      "container in crash loop",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name: "app",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Reason:   "Error",
+							Message:  "missing DATABASE_URL",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployFailed,
+				condition: metav1.ConditionFalse,
+				reason:    "Container app in pod test-1 is in CrashLoopBackOff (exit code 1): missing DATABASE_URL",
+			},
+		},
+		{
+			name:      "container out of memory",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name: "app",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 137,
+							Reason:   "OOMKilled",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployFailed,
+				condition: metav1.ConditionFalse,
+				reason:    "Container app in pod test-1 was OOMKilled",
+			},
+		},
+		{
+			name:      "image pull failure",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name: "app",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ImagePullBackOff",
+							Message: "Back-off pulling image",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployFailed,
+				condition: metav1.ConditionFalse,
+				reason:    "Container app in pod test-1 is in ImagePullBackOff: Back-off pulling image",
+			},
+		},
+		{
+			name:      "transient image pull error",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name: "app",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{
+							Reason:  "ErrImagePull",
+							Message: "connection refused",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployVerify,
+				condition: metav1.ConditionUnknown,
+			},
+		},
+		{
+			name:      "single out of memory kill",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name:         "app",
+					RestartCount: 1,
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 137,
+							Reason:   "OOMKilled",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployVerify,
+				condition: metav1.ConditionUnknown,
+			},
+		},
+		{
+			name:      "repeated out of memory kills",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:2", corev1.ContainerStatus{
+					Name:         "app",
+					RestartCount: ContainerRestartLimit,
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 137,
+							Reason:   "OOMKilled",
+						},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployFailed,
+				condition: metav1.ConditionFalse,
+				reason:    "Container app in pod test-1 was OOMKilled 3 times",
+			},
+		},
+		{
+			name:      "previous revision in crash loop",
+			replicas:  1,
+			available: 0,
+			revision:  "2",
+			pods: []*corev1.Pod{
+				deployVerifyPod("test-1", "localhost:31555/default/test:1", corev1.ContainerStatus{
+					Name: "app",
+					State: corev1.ContainerState{
+						Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+				}),
+			},
+			expected: DeployVerifyExpected{
+				revision:  "",
+				stage:     v1beta1.EnvironmentStageDeployVerify,
+				condition: metav1.ConditionUnknown,
 			},
 		},
 	}
@@ -70,6 +288,7 @@ func TestDeployVerify(t *testing.T) {
 						},
 						Status: appsv1.DeploymentStatus{
 							AvailableReplicas: tt.available,
+							Conditions:        tt.conditions,
 						},
 					},
 					Env: &v1beta1.Environment{
@@ -88,12 +307,19 @@ func TestDeployVerify(t *testing.T) {
 				},
 			}
 
-			d := NewDeployVerify(nil, &collection)
+			client := mock.NewClient()
+			for _, pod := range tt.pods {
+				assert.NoError(t, client.Create(context.TODO(), pod))
+			}
+
+			d := NewDeployVerify(client, &collection)
 			status := v1beta1.EnvironmentStatus{}
 			stage, err := d.Do(context.TODO(), &status)
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expected.stage, stage)
 			assert.Equal(t, tt.expected.revision, status.DeployedRevision)
+			assert.True(t, meta.IsStatusConditionPresentAndEqual(status.Conditions, v1beta1.EnvironmentConditionDeployed, tt.expected.condition))
+			assert.Equal(t, tt.expected.reason, status.Reason)
 		})
 	}
 }
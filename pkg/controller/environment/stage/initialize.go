@@ -2,14 +2,20 @@ package stage
 
 import (
 	"context"
+	"fmt"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"ctx.sh/seaway/pkg/controller/environment/collector"
+	"ctx.sh/seaway/pkg/registry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type Initialize struct {
 	observed *collector.ObservedState
+	registry registry.API
 	client.Client
 }
 
@@ -20,8 +26,62 @@ func NewInitialize(client client.Client, collection *collector.Collection) *Init
 	}
 }
 
+// WithRegistry sets the registry that is checked for an existing image before
+// a build is started.
+func (i *Initialize) WithRegistry(registry registry.API) *Initialize {
+	i.registry = registry
+	return i
+}
+
 func (i *Initialize) Do(ctx context.Context, status *v1beta1.EnvironmentStatus) (v1beta1.EnvironmentStage, error) {
-	status.ExpectedRevision = i.observed.Env.GetRevision()
+	env := i.observed.Env
+	revision := env.GetRevision()
+	status.ExpectedRevision = revision
+	status.Reason = ""
+	status.BuildLog = ""
+
+	status.RecordRevision(v1beta1.EnvironmentRevision{
+		Revision: revision,
+		Image:    i.observed.ImageReference(),
+		Archive:  i.observed.ArchiveKey(),
+		Rollback: env.IsRollback(),
+		Outcome:  v1beta1.EnvironmentRevisionPending,
+	})
+
+	status.SetCondition(v1beta1.EnvironmentConditionSourceUploaded, metav1.ConditionTrue,
+		v1beta1.EnvironmentReasonRevisionReceived, fmt.Sprintf("Revision %s has been uploaded", revision))
+	status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionUnknown,
+		v1beta1.EnvironmentReasonPending, "Waiting for the build to start")
+	status.SetCondition(v1beta1.EnvironmentConditionDeployed, metav1.ConditionUnknown,
+		v1beta1.EnvironmentReasonPending, "Waiting for the image to be built")
+
+	// Rollbacks reuse the image that is already in the registry, so skip straight
+	// to verifying that it still exists.
+	if env.IsRollback() {
+		status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionUnknown,
+			v1beta1.EnvironmentReasonVerifying, fmt.Sprintf("Rolling back to revision %s", revision))
+		return v1beta1.EnvironmentStageBuildImageVerify, nil
+	}
+
+	// Revisions are derived from the source, so if the image is already in the registry
+	// (forced resyncs, recreated environments, or shared sources) there's nothing to build.
+	if i.registry != nil {
+		ok, err := i.registry.HasTag(env.ImageRepository(), revision)
+		if err != nil {
+			// Not being able to check isn't fatal, we just fall back to building.
+			log.FromContext(ctx).Error(err, "unable to check the registry for an existing image")
+		} else if ok {
+			digest := imageDigest(ctx, i.registry, env)
+			updateRevision(status, func(rev *v1beta1.EnvironmentRevision) {
+				rev.BuildCompleted = ptr.To(metav1.Now())
+				rev.Digest = digest
+			})
+			status.SetCondition(v1beta1.EnvironmentConditionImageBuilt, metav1.ConditionTrue,
+				v1beta1.EnvironmentReasonBuildSkipped, fmt.Sprintf("Image %s:%s already exists in the registry, skipping the build", env.ImageRepository(), revision))
+			return v1beta1.EnvironmentStageDeploy, nil
+		}
+	}
+
 	return v1beta1.EnvironmentStageBuildImage, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"ctx.sh/seaway/pkg/controller/environment/collector"
 	"ctx.sh/seaway/pkg/mock"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,6 +24,7 @@ func TestInitialize(t *testing.T) {
 	var tests = []struct {
 		desc        string
 		environment *v1beta1.Environment
+		hasTag      bool
 		expected    expected
 	}{
 		{
@@ -84,6 +86,52 @@ func TestInitialize(t *testing.T) {
 				Current:  "1",
 			},
 		},
+		{
+			desc: "rollback to a previous revision",
+			environment: &v1beta1.Environment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+					Annotations: map[string]string{
+						v1beta1.RollbackAnnotation: "1",
+					},
+				},
+				Spec: v1beta1.EnvironmentSpec{
+					Revision: "1",
+				},
+				Status: v1beta1.EnvironmentStatus{
+					ExpectedRevision: "2",
+					DeployedRevision: "2",
+					Stage:            v1beta1.EnvironmentStageDeployed,
+				},
+			},
+			expected: expected{
+				Stage:    v1beta1.EnvironmentStageBuildImageVerify,
+				Expected: "1",
+				Current:  "2",
+			},
+		},
+		{
+			desc: "image already exists in the registry",
+			environment: &v1beta1.Environment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: v1beta1.EnvironmentSpec{
+					Revision: "2",
+				},
+				Status: v1beta1.EnvironmentStatus{
+					ExpectedRevision: "2",
+					DeployedRevision: "1",
+					Stage:            v1beta1.EnvironmentStageDeployed,
+				},
+			},
+			hasTag: true,
+			expected: expected{
+				Stage:    v1beta1.EnvironmentStageDeploy,
+				Expected: "2",
+				Current:  "1",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -99,13 +147,27 @@ func TestInitialize(t *testing.T) {
 						},
 					},
 				},
-			})
+			}).WithRegistry(&MockRegistry{HasTagRespose: test.hasTag})
 			status := test.environment.Status.DeepCopy()
 			next, err := stage.Do(context.TODO(), status)
 			assert.NoError(t, err)
 			assert.Equal(t, test.expected.Stage, next)
 			assert.Equal(t, test.expected.Expected, status.ExpectedRevision)
 			assert.Equal(t, test.expected.Current, status.DeployedRevision)
+			assert.Equal(t, test.expected.Expected, status.CurrentRevision().Revision)
+			assert.Equal(t, v1beta1.EnvironmentRevisionPending, status.CurrentRevision().Outcome)
+			assert.True(t, meta.IsStatusConditionTrue(status.Conditions, v1beta1.EnvironmentConditionSourceUploaded))
+			assert.True(t, meta.IsStatusConditionPresentAndEqual(status.Conditions, v1beta1.EnvironmentConditionDeployed, metav1.ConditionUnknown))
+
+			built := meta.FindStatusCondition(status.Conditions, v1beta1.EnvironmentConditionImageBuilt)
+			if test.hasTag {
+				assert.Equal(t, metav1.ConditionTrue, built.Status)
+				assert.Equal(t, v1beta1.EnvironmentReasonBuildSkipped, built.Reason)
+				assert.Equal(t, MockDigest, status.CurrentRevision().Digest)
+			} else {
+				assert.Equal(t, metav1.ConditionUnknown, built.Status)
+				assert.Empty(t, status.CurrentRevision().Digest)
+			}
 		})
 	}
 }
@@ -4,8 +4,31 @@ import (
 	"context"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"ctx.sh/seaway/pkg/registry"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type Stage interface {
 	Do(context.Context, *v1beta1.EnvironmentStatus) (v1beta1.EnvironmentStage, error)
 }
+
+// updateRevision applies fn to the history entry of the revision that is currently
+// being processed.  It's a no-op if the revision hasn't been recorded.
+func updateRevision(status *v1beta1.EnvironmentStatus, fn func(*v1beta1.EnvironmentRevision)) {
+	if rev := status.CurrentRevision(); rev != nil {
+		fn(rev)
+	}
+}
+
+// imageDigest returns the digest of the image built for the environment revision.  The
+// digest is only recorded in the history, so failing to resolve it is logged rather
+// than failing the revision.
+func imageDigest(ctx context.Context, reg registry.API, env *v1beta1.Environment) string {
+	digest, err := reg.Digest(env.ImageRepository(), env.GetRevision())
+	if err != nil {
+		log.FromContext(ctx).Error(err, "unable to resolve the image digest")
+		return ""
+	}
+
+	return digest
+}
@@ -36,6 +36,23 @@ const (
 const (
 	// SeawayServiceUploadProcedure is the fully-qualified name of the SeawayService's Upload RPC.
 	SeawayServiceUploadProcedure = "/seaway.v1beta1.SeawayService/Upload"
+	// SeawayServiceCreateUploadProcedure is the fully-qualified name of the SeawayService's
+	// CreateUpload RPC.
+	SeawayServiceCreateUploadProcedure = "/seaway.v1beta1.SeawayService/CreateUpload"
+	// SeawayServiceUploadPartProcedure is the fully-qualified name of the SeawayService's UploadPart
+	// RPC.
+	SeawayServiceUploadPartProcedure = "/seaway.v1beta1.SeawayService/UploadPart"
+	// SeawayServiceCompleteUploadProcedure is the fully-qualified name of the SeawayService's
+	// CompleteUpload RPC.
+	SeawayServiceCompleteUploadProcedure = "/seaway.v1beta1.SeawayService/CompleteUpload"
+	// SeawayServiceMissingBlobsProcedure is the fully-qualified name of the SeawayService's
+	// MissingBlobs RPC.
+	SeawayServiceMissingBlobsProcedure = "/seaway.v1beta1.SeawayService/MissingBlobs"
+	// SeawayServiceUploadBlobProcedure is the fully-qualified name of the SeawayService's UploadBlob
+	// RPC.
+	SeawayServiceUploadBlobProcedure = "/seaway.v1beta1.SeawayService/UploadBlob"
+	// SeawayServiceAssembleProcedure is the fully-qualified name of the SeawayService's Assemble RPC.
+	SeawayServiceAssembleProcedure = "/seaway.v1beta1.SeawayService/Assemble"
 	// SeawayServiceEnvironmentProcedure is the fully-qualified name of the SeawayService's Environment
 	// RPC.
 	SeawayServiceEnvironmentProcedure = "/seaway.v1beta1.SeawayService/Environment"
@@ -48,6 +65,12 @@ const (
 var (
 	seawayServiceServiceDescriptor                  = v1beta1.File_seaway_v1beta1_seaway_proto.Services().ByName("SeawayService")
 	seawayServiceUploadMethodDescriptor             = seawayServiceServiceDescriptor.Methods().ByName("Upload")
+	seawayServiceCreateUploadMethodDescriptor       = seawayServiceServiceDescriptor.Methods().ByName("CreateUpload")
+	seawayServiceUploadPartMethodDescriptor         = seawayServiceServiceDescriptor.Methods().ByName("UploadPart")
+	seawayServiceCompleteUploadMethodDescriptor     = seawayServiceServiceDescriptor.Methods().ByName("CompleteUpload")
+	seawayServiceMissingBlobsMethodDescriptor       = seawayServiceServiceDescriptor.Methods().ByName("MissingBlobs")
+	seawayServiceUploadBlobMethodDescriptor         = seawayServiceServiceDescriptor.Methods().ByName("UploadBlob")
+	seawayServiceAssembleMethodDescriptor           = seawayServiceServiceDescriptor.Methods().ByName("Assemble")
 	seawayServiceEnvironmentMethodDescriptor        = seawayServiceServiceDescriptor.Methods().ByName("Environment")
 	seawayServiceEnvironmentTrackerMethodDescriptor = seawayServiceServiceDescriptor.Methods().ByName("EnvironmentTracker")
 )
@@ -55,6 +78,18 @@ var (
 // SeawayServiceClient is a client for the seaway.v1beta1.SeawayService service.
 type SeawayServiceClient interface {
 	Upload(context.Context) *connect.ClientStreamForClient[v1beta1.UploadRequest, v1beta1.UploadResponse]
+	// CreateUpload starts or resumes a multipart upload of the build context archive.
+	CreateUpload(context.Context, *connect.Request[v1beta1.CreateUploadRequest]) (*connect.Response[v1beta1.CreateUploadResponse], error)
+	// UploadPart stores a single part.  Parts can be uploaded concurrently.
+	UploadPart(context.Context) *connect.ClientStreamForClient[v1beta1.UploadPartRequest, v1beta1.UploadPartResponse]
+	// CompleteUpload joins the parts and verifies the archive digest.
+	CompleteUpload(context.Context, *connect.Request[v1beta1.CompleteUploadRequest]) (*connect.Response[v1beta1.UploadResponse], error)
+	// MissingBlobs returns the digests that are not in the blob store yet.
+	MissingBlobs(context.Context, *connect.Request[v1beta1.MissingBlobsRequest]) (*connect.Response[v1beta1.MissingBlobsResponse], error)
+	// UploadBlob stores a single file by its digest.
+	UploadBlob(context.Context) *connect.ClientStreamForClient[v1beta1.UploadBlobRequest, v1beta1.UploadBlobResponse]
+	// Assemble builds the build context archive from the stored blobs.
+	Assemble(context.Context, *connect.Request[v1beta1.AssembleRequest]) (*connect.Response[v1beta1.AssembleResponse], error)
 	Environment(context.Context, *connect.Request[v1beta1.EnvironmentRequest]) (*connect.Response[v1beta1.EnvironmentResponse], error)
 	EnvironmentTracker(context.Context, *connect.Request[v1beta1.EnvironmentRequest]) (*connect.ServerStreamForClient[v1beta1.EnvironmentResponse], error)
 }
@@ -75,6 +110,42 @@ func NewSeawayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(seawayServiceUploadMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		createUpload: connect.NewClient[v1beta1.CreateUploadRequest, v1beta1.CreateUploadResponse](
+			httpClient,
+			baseURL+SeawayServiceCreateUploadProcedure,
+			connect.WithSchema(seawayServiceCreateUploadMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		uploadPart: connect.NewClient[v1beta1.UploadPartRequest, v1beta1.UploadPartResponse](
+			httpClient,
+			baseURL+SeawayServiceUploadPartProcedure,
+			connect.WithSchema(seawayServiceUploadPartMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		completeUpload: connect.NewClient[v1beta1.CompleteUploadRequest, v1beta1.UploadResponse](
+			httpClient,
+			baseURL+SeawayServiceCompleteUploadProcedure,
+			connect.WithSchema(seawayServiceCompleteUploadMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		missingBlobs: connect.NewClient[v1beta1.MissingBlobsRequest, v1beta1.MissingBlobsResponse](
+			httpClient,
+			baseURL+SeawayServiceMissingBlobsProcedure,
+			connect.WithSchema(seawayServiceMissingBlobsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		uploadBlob: connect.NewClient[v1beta1.UploadBlobRequest, v1beta1.UploadBlobResponse](
+			httpClient,
+			baseURL+SeawayServiceUploadBlobProcedure,
+			connect.WithSchema(seawayServiceUploadBlobMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		assemble: connect.NewClient[v1beta1.AssembleRequest, v1beta1.AssembleResponse](
+			httpClient,
+			baseURL+SeawayServiceAssembleProcedure,
+			connect.WithSchema(seawayServiceAssembleMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		environment: connect.NewClient[v1beta1.EnvironmentRequest, v1beta1.EnvironmentResponse](
 			httpClient,
 			baseURL+SeawayServiceEnvironmentProcedure,
@@ -93,6 +164,12 @@ func NewSeawayServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 // seawayServiceClient implements SeawayServiceClient.
 type seawayServiceClient struct {
 	upload             *connect.Client[v1beta1.UploadRequest, v1beta1.UploadResponse]
+	createUpload       *connect.Client[v1beta1.CreateUploadRequest, v1beta1.CreateUploadResponse]
+	uploadPart         *connect.Client[v1beta1.UploadPartRequest, v1beta1.UploadPartResponse]
+	completeUpload     *connect.Client[v1beta1.CompleteUploadRequest, v1beta1.UploadResponse]
+	missingBlobs       *connect.Client[v1beta1.MissingBlobsRequest, v1beta1.MissingBlobsResponse]
+	uploadBlob         *connect.Client[v1beta1.UploadBlobRequest, v1beta1.UploadBlobResponse]
+	assemble           *connect.Client[v1beta1.AssembleRequest, v1beta1.AssembleResponse]
 	environment        *connect.Client[v1beta1.EnvironmentRequest, v1beta1.EnvironmentResponse]
 	environmentTracker *connect.Client[v1beta1.EnvironmentRequest, v1beta1.EnvironmentResponse]
 }
@@ -102,6 +179,36 @@ func (c *seawayServiceClient) Upload(ctx context.Context) *connect.ClientStreamF
 	return c.upload.CallClientStream(ctx)
 }
 
+// CreateUpload calls seaway.v1beta1.SeawayService.CreateUpload.
+func (c *seawayServiceClient) CreateUpload(ctx context.Context, req *connect.Request[v1beta1.CreateUploadRequest]) (*connect.Response[v1beta1.CreateUploadResponse], error) {
+	return c.createUpload.CallUnary(ctx, req)
+}
+
+// UploadPart calls seaway.v1beta1.SeawayService.UploadPart.
+func (c *seawayServiceClient) UploadPart(ctx context.Context) *connect.ClientStreamForClient[v1beta1.UploadPartRequest, v1beta1.UploadPartResponse] {
+	return c.uploadPart.CallClientStream(ctx)
+}
+
+// CompleteUpload calls seaway.v1beta1.SeawayService.CompleteUpload.
+func (c *seawayServiceClient) CompleteUpload(ctx context.Context, req *connect.Request[v1beta1.CompleteUploadRequest]) (*connect.Response[v1beta1.UploadResponse], error) {
+	return c.completeUpload.CallUnary(ctx, req)
+}
+
+// MissingBlobs calls seaway.v1beta1.SeawayService.MissingBlobs.
+func (c *seawayServiceClient) MissingBlobs(ctx context.Context, req *connect.Request[v1beta1.MissingBlobsRequest]) (*connect.Response[v1beta1.MissingBlobsResponse], error) {
+	return c.missingBlobs.CallUnary(ctx, req)
+}
+
+// UploadBlob calls seaway.v1beta1.SeawayService.UploadBlob.
+func (c *seawayServiceClient) UploadBlob(ctx context.Context) *connect.ClientStreamForClient[v1beta1.UploadBlobRequest, v1beta1.UploadBlobResponse] {
+	return c.uploadBlob.CallClientStream(ctx)
+}
+
+// Assemble calls seaway.v1beta1.SeawayService.Assemble.
+func (c *seawayServiceClient) Assemble(ctx context.Context, req *connect.Request[v1beta1.AssembleRequest]) (*connect.Response[v1beta1.AssembleResponse], error) {
+	return c.assemble.CallUnary(ctx, req)
+}
+
 // Environment calls seaway.v1beta1.SeawayService.Environment.
 func (c *seawayServiceClient) Environment(ctx context.Context, req *connect.Request[v1beta1.EnvironmentRequest]) (*connect.Response[v1beta1.EnvironmentResponse], error) {
 	return c.environment.CallUnary(ctx, req)
@@ -115,6 +222,18 @@ func (c *seawayServiceClient) EnvironmentTracker(ctx context.Context, req *conne
 // SeawayServiceHandler is an implementation of the seaway.v1beta1.SeawayService service.
 type SeawayServiceHandler interface {
 	Upload(context.Context, *connect.ClientStream[v1beta1.UploadRequest]) (*connect.Response[v1beta1.UploadResponse], error)
+	// CreateUpload starts or resumes a multipart upload of the build context archive.
+	CreateUpload(context.Context, *connect.Request[v1beta1.CreateUploadRequest]) (*connect.Response[v1beta1.CreateUploadResponse], error)
+	// UploadPart stores a single part.  Parts can be uploaded concurrently.
+	UploadPart(context.Context, *connect.ClientStream[v1beta1.UploadPartRequest]) (*connect.Response[v1beta1.UploadPartResponse], error)
+	// CompleteUpload joins the parts and verifies the archive digest.
+	CompleteUpload(context.Context, *connect.Request[v1beta1.CompleteUploadRequest]) (*connect.Response[v1beta1.UploadResponse], error)
+	// MissingBlobs returns the digests that are not in the blob store yet.
+	MissingBlobs(context.Context, *connect.Request[v1beta1.MissingBlobsRequest]) (*connect.Response[v1beta1.MissingBlobsResponse], error)
+	// UploadBlob stores a single file by its digest.
+	UploadBlob(context.Context, *connect.ClientStream[v1beta1.UploadBlobRequest]) (*connect.Response[v1beta1.UploadBlobResponse], error)
+	// Assemble builds the build context archive from the stored blobs.
+	Assemble(context.Context, *connect.Request[v1beta1.AssembleRequest]) (*connect.Response[v1beta1.AssembleResponse], error)
 	Environment(context.Context, *connect.Request[v1beta1.EnvironmentRequest]) (*connect.Response[v1beta1.EnvironmentResponse], error)
 	EnvironmentTracker(context.Context, *connect.Request[v1beta1.EnvironmentRequest], *connect.ServerStream[v1beta1.EnvironmentResponse]) error
 }
@@ -131,6 +250,42 @@ func NewSeawayServiceHandler(svc SeawayServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(seawayServiceUploadMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	seawayServiceCreateUploadHandler := connect.NewUnaryHandler(
+		SeawayServiceCreateUploadProcedure,
+		svc.CreateUpload,
+		connect.WithSchema(seawayServiceCreateUploadMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	seawayServiceUploadPartHandler := connect.NewClientStreamHandler(
+		SeawayServiceUploadPartProcedure,
+		svc.UploadPart,
+		connect.WithSchema(seawayServiceUploadPartMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	seawayServiceCompleteUploadHandler := connect.NewUnaryHandler(
+		SeawayServiceCompleteUploadProcedure,
+		svc.CompleteUpload,
+		connect.WithSchema(seawayServiceCompleteUploadMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	seawayServiceMissingBlobsHandler := connect.NewUnaryHandler(
+		SeawayServiceMissingBlobsProcedure,
+		svc.MissingBlobs,
+		connect.WithSchema(seawayServiceMissingBlobsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	seawayServiceUploadBlobHandler := connect.NewClientStreamHandler(
+		SeawayServiceUploadBlobProcedure,
+		svc.UploadBlob,
+		connect.WithSchema(seawayServiceUploadBlobMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	seawayServiceAssembleHandler := connect.NewUnaryHandler(
+		SeawayServiceAssembleProcedure,
+		svc.Assemble,
+		connect.WithSchema(seawayServiceAssembleMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	seawayServiceEnvironmentHandler := connect.NewUnaryHandler(
 		SeawayServiceEnvironmentProcedure,
 		svc.Environment,
@@ -147,6 +302,18 @@ func NewSeawayServiceHandler(svc SeawayServiceHandler, opts ...connect.HandlerOp
 		switch r.URL.Path {
 		case SeawayServiceUploadProcedure:
 			seawayServiceUploadHandler.ServeHTTP(w, r)
+		case SeawayServiceCreateUploadProcedure:
+			seawayServiceCreateUploadHandler.ServeHTTP(w, r)
+		case SeawayServiceUploadPartProcedure:
+			seawayServiceUploadPartHandler.ServeHTTP(w, r)
+		case SeawayServiceCompleteUploadProcedure:
+			seawayServiceCompleteUploadHandler.ServeHTTP(w, r)
+		case SeawayServiceMissingBlobsProcedure:
+			seawayServiceMissingBlobsHandler.ServeHTTP(w, r)
+		case SeawayServiceUploadBlobProcedure:
+			seawayServiceUploadBlobHandler.ServeHTTP(w, r)
+		case SeawayServiceAssembleProcedure:
+			seawayServiceAssembleHandler.ServeHTTP(w, r)
 		case SeawayServiceEnvironmentProcedure:
 			seawayServiceEnvironmentHandler.ServeHTTP(w, r)
 		case SeawayServiceEnvironmentTrackerProcedure:
@@ -164,6 +331,30 @@ func (UnimplementedSeawayServiceHandler) Upload(context.Context, *connect.Client
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.Upload is not implemented"))
 }
 
+func (UnimplementedSeawayServiceHandler) CreateUpload(context.Context, *connect.Request[v1beta1.CreateUploadRequest]) (*connect.Response[v1beta1.CreateUploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.CreateUpload is not implemented"))
+}
+
+func (UnimplementedSeawayServiceHandler) UploadPart(context.Context, *connect.ClientStream[v1beta1.UploadPartRequest]) (*connect.Response[v1beta1.UploadPartResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.UploadPart is not implemented"))
+}
+
+func (UnimplementedSeawayServiceHandler) CompleteUpload(context.Context, *connect.Request[v1beta1.CompleteUploadRequest]) (*connect.Response[v1beta1.UploadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.CompleteUpload is not implemented"))
+}
+
+func (UnimplementedSeawayServiceHandler) MissingBlobs(context.Context, *connect.Request[v1beta1.MissingBlobsRequest]) (*connect.Response[v1beta1.MissingBlobsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.MissingBlobs is not implemented"))
+}
+
+func (UnimplementedSeawayServiceHandler) UploadBlob(context.Context, *connect.ClientStream[v1beta1.UploadBlobRequest]) (*connect.Response[v1beta1.UploadBlobResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.UploadBlob is not implemented"))
+}
+
+func (UnimplementedSeawayServiceHandler) Assemble(context.Context, *connect.Request[v1beta1.AssembleRequest]) (*connect.Response[v1beta1.AssembleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.Assemble is not implemented"))
+}
+
 func (UnimplementedSeawayServiceHandler) Environment(context.Context, *connect.Request[v1beta1.EnvironmentRequest]) (*connect.Response[v1beta1.EnvironmentResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("seaway.v1beta1.SeawayService.Environment is not implemented"))
 }
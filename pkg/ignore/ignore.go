@@ -0,0 +1,306 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ignore matches paths against ignore files.  Patterns follow the gitignore
+// rules: blank lines and comments are skipped, a leading ! negates the pattern, a
+// trailing / only matches directories, patterns with a / anywhere but the end are
+// anchored to the root, and ** matches any number of directories.  The last
+// matching pattern wins.
+package ignore
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Pattern is a single compiled line of an ignore file.
+type Pattern struct {
+	line     string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+	docker   bool
+	prefix   string
+	re       *regexp.Regexp
+}
+
+// String returns the line the pattern was compiled from.
+func (p *Pattern) String() string {
+	return p.line
+}
+
+// Matcher holds the patterns from any number of ignore files.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// New returns a matcher with the gitignore patterns.
+func New(patterns ...string) (*Matcher, error) {
+	m := &Matcher{}
+	if err := m.Add(patterns...); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Add adds patterns with gitignore semantics.
+func (m *Matcher) Add(lines ...string) error {
+	return m.add(lines, false)
+}
+
+// AddDocker adds patterns with dockerignore semantics, where every pattern is
+// anchored to the root.
+func (m *Matcher) AddDocker(lines ...string) error {
+	return m.add(lines, true)
+}
+
+// AddFile adds the patterns from the ignore file.  A missing file is not an error.
+func (m *Matcher) AddFile(name string, docker bool) error {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	lines, err := readLines(file)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", name, err)
+	}
+
+	if err := m.add(lines, docker); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (m *Matcher) add(lines []string, docker bool) error {
+	for i, line := range lines {
+		p, err := Compile(line, docker)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if p != nil {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+
+	return nil
+}
+
+// Ignored returns true if the slash separated path relative to the root is ignored.
+// Like git, a path inside of a directory ignored by a gitignore pattern can't be
+// re-included.
+func (m *Matcher) Ignored(name string, isDir bool) bool {
+	if ignored, _ := m.match(name, isDir); ignored {
+		return true
+	}
+
+	for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if ignored, p := m.match(dir, true); ignored && !p.docker {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match returns the result of the last pattern that matches the path.
+func (m *Matcher) match(name string, isDir bool) (bool, *Pattern) {
+	var last *Pattern
+	for _, p := range m.patterns {
+		if p.Match(name, isDir) {
+			last = p
+		}
+	}
+
+	return last != nil && !last.negate, last
+}
+
+// Skip returns true if the directory is ignored and nothing inside of it can be
+// re-included.  Git never re-includes files in an ignored directory, docker does for
+// negated patterns that name a path under it.
+func (m *Matcher) Skip(dir string) bool {
+	if !m.Ignored(dir, true) {
+		return false
+	}
+
+	for _, p := range m.patterns {
+		if p.negate && p.docker && strings.HasPrefix(p.prefix, dir+"/") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match returns true if the pattern matches the path, ignoring negation.  Docker
+// patterns also match everything inside of a matching directory.
+func (p *Pattern) Match(name string, isDir bool) bool {
+	if (!p.dirOnly || isDir) && p.re.MatchString(name) {
+		return true
+	}
+
+	if p.docker {
+		for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if p.re.MatchString(dir) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Compile compiles a line of an ignore file.  Blank lines and comments return a nil
+// pattern.
+func Compile(line string, docker bool) (*Pattern, error) {
+	raw := line
+	line = trimTrailingSpace(strings.TrimSuffix(line, "\r"))
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, nil
+	}
+
+	p := &Pattern{line: raw}
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	p.docker = docker
+	p.anchored = docker || strings.Contains(line, "/")
+	line = strings.TrimLeft(line, "/")
+	if docker && line != "" {
+		line = path.Clean(line)
+	}
+	if line == "" {
+		return nil, fmt.Errorf("invalid pattern %q", raw)
+	}
+
+	expr, err := translate(line)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", raw, err)
+	}
+
+	if !p.anchored {
+		expr = "(?:.*/)?" + expr
+	}
+
+	p.re, err = regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", raw, err)
+	}
+
+	p.prefix = literalPrefix(line)
+	return p, nil
+}
+
+// translate converts the glob to a regular expression.
+func translate(glob string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				leading := i == 0 || glob[i-1] == '/'
+				trailing := i+2 == len(glob) || glob[i+2] == '/'
+				if leading && trailing {
+					i++
+					switch {
+					case i+1 == len(glob):
+						// A trailing /** matches everything inside.
+						b.WriteString(".*")
+					default:
+						// **/ matches zero or more directories.
+						b.WriteString("(?:.*/)?")
+						i++
+					}
+					continue
+				}
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", errors.New("unterminated character class")
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 == len(glob) {
+				return "", errors.New("trailing backslash")
+			}
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return b.String(), nil
+}
+
+// literalPrefix returns the part of the glob before the first wildcard.
+func literalPrefix(glob string) string {
+	if i := strings.IndexAny(glob, `*?[\`); i >= 0 {
+		return glob[:i]
+	}
+
+	return glob
+}
+
+// trimTrailingSpace removes trailing spaces unless they're escaped.
+func trimTrailingSpace(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+
+	return line
+}
+
+func readLines(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
@@ -0,0 +1,126 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Ignored(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		ignored  bool
+	}{
+		{"no patterns", nil, "main.go", false, false},
+		{"comment", []string{"# main.go"}, "main.go", false, false},
+		{"escaped hash", []string{`\#main.go`}, "#main.go", false, true},
+		{"escaped bang", []string{`\!main.go`}, "!main.go", false, true},
+		{"basename", []string{"*.log"}, "logs/debug.log", false, true},
+		{"basename root", []string{"*.log"}, "debug.log", false, true},
+		{"star stays in segment", []string{"a/*.log"}, "a/b/debug.log", false, false},
+		{"question mark", []string{"file?.txt"}, "file1.txt", false, true},
+		{"character class", []string{"file[0-9].txt"}, "file5.txt", false, true},
+		{"negated class", []string{"file[!0-9].txt"}, "file5.txt", false, false},
+		{"anchored", []string{"/build"}, "build", true, true},
+		{"anchored nested", []string{"/build"}, "src/build", true, false},
+		{"anchored by slash", []string{"src/build"}, "app/src/build", true, false},
+		{"unanchored nested", []string{"build"}, "src/build", true, true},
+		{"dir only matches dir", []string{"build/"}, "build", true, true},
+		{"dir only skips file", []string{"build/"}, "build", false, false},
+		{"leading doublestar", []string{"**/foo"}, "a/b/foo", false, true},
+		{"leading doublestar root", []string{"**/foo"}, "foo", false, true},
+		{"trailing doublestar", []string{"abc/**"}, "abc/d/e", false, true},
+		{"trailing doublestar dir", []string{"abc/**"}, "abc", true, false},
+		{"middle doublestar", []string{"a/**/b"}, "a/x/y/b", false, true},
+		{"middle doublestar empty", []string{"a/**/b"}, "a/b", false, true},
+		{"doublestar in segment", []string{"a**b"}, "a/x/b", false, false},
+		{"negation", []string{"*.log", "!keep.log"}, "keep.log", false, false},
+		{"last match wins", []string{"!keep.log", "*.log"}, "keep.log", false, true},
+		{"trailing space", []string{"main.go  "}, "main.go", false, true},
+		{"escaped space", []string{`main.go\ `}, "main.go ", false, true},
+		{"literal dot", []string{"a.go"}, "abgo", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.patterns...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ignored, m.Ignored(tt.path, tt.isDir))
+		})
+	}
+}
+
+func TestMatcher_Docker(t *testing.T) {
+	m := &Matcher{}
+	require.NoError(t, m.AddDocker("build", "./tmp/*", "docs", "!docs/README.md"))
+
+	assert.True(t, m.Ignored("build", true))
+	assert.False(t, m.Ignored("src/build", true))
+	assert.True(t, m.Ignored("tmp/cache", false))
+	assert.True(t, m.Ignored("docs", true))
+	assert.False(t, m.Ignored("docs/README.md", false))
+	assert.True(t, m.Ignored("docs/guide.md", false))
+
+	// The negated pattern re-includes a file inside of an ignored directory.
+	assert.False(t, m.Skip("docs"))
+	assert.True(t, m.Skip("build"))
+}
+
+func TestMatcher_Skip(t *testing.T) {
+	m, err := New("build/", "!build/keep", "vendor/*", "!vendor/keep")
+	require.NoError(t, err)
+
+	// Files can't be re-included from an ignored directory.
+	assert.True(t, m.Skip("build"))
+	// The directory isn't ignored, only its contents.
+	assert.False(t, m.Skip("vendor"))
+	assert.False(t, m.Ignored("vendor/keep", false))
+	assert.True(t, m.Ignored("vendor/other", false))
+	assert.False(t, m.Skip("src"))
+}
+
+func TestMatcher_Mixed(t *testing.T) {
+	m, err := New("vendor/")
+	require.NoError(t, err)
+	require.NoError(t, m.AddDocker("!vendor/keep"))
+
+	// The docker negation keeps the walk going but the gitignore pattern still
+	// ignores everything inside of the directory.
+	assert.False(t, m.Skip("vendor"))
+	assert.True(t, m.Ignored("vendor/keep", false))
+	assert.True(t, m.Ignored("vendor/a/b", false))
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := []string{"/", "!", "file[0-9", `file\`}
+	for _, line := range tests {
+		t.Run(line, func(t *testing.T) {
+			_, err := Compile(line, false)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMatcher_AddFile(t *testing.T) {
+	dir := t.TempDir()
+
+	m := &Matcher{}
+	require.NoError(t, m.AddFile(filepath.Join(dir, ".missing"), false))
+
+	name := filepath.Join(dir, ".seawayignore")
+	require.NoError(t, os.WriteFile(name, []byte("# comment\n\n*.log\r\n!keep.log\n"), 0o600))
+	require.NoError(t, m.AddFile(name, false))
+	assert.True(t, m.Ignored("debug.log", false))
+	assert.False(t, m.Ignored("keep.log", false))
+
+	require.NoError(t, os.WriteFile(name, []byte("*.log\nfile[0-9\n"), 0o600))
+	err := m.AddFile(name, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
@@ -15,14 +15,19 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/user"
 	"path/filepath"
 
+	authv1 "k8s.io/api/authentication/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
@@ -99,6 +104,44 @@ func (c *Client) ToRESTConfig() (*rest.Config, error) {
 	return c.config.ClientConfig()
 }
 
+// Username returns the name the API server authenticates the client as.  Servers that
+// don't serve the SelfSubjectReview API fall back to the user name in the kubeconfig.
+func (c *Client) Username(ctx context.Context) (string, error) {
+	config, err := c.ToRESTConfig()
+	if err != nil {
+		return "", err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return "", err
+	}
+
+	review, err := clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	switch {
+	case apierr.IsNotFound(err), apierr.IsMethodNotSupported(err):
+		return c.configUsername()
+	case err != nil:
+		return "", err
+	}
+
+	return review.Status.UserInfo.Username, nil
+}
+
+func (c *Client) configUsername() (string, error) {
+	raw, err := c.config.RawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	kctx, ok := raw.Contexts[raw.CurrentContext]
+	if !ok || kctx.AuthInfo == "" {
+		return "", fmt.Errorf("unable to determine the user for context %q", raw.CurrentContext)
+	}
+
+	return kctx.AuthInfo, nil
+}
+
 // loadConfig loads the kubernetes configuration from the provided kubeconfig file, Borrowed
 // heavily from the controller-runtime loader.
 func loadConfig(kubeconfig, context string) (clientcmd.ClientConfig, error) {
@@ -0,0 +1,207 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodPollInterval is how often the pods are checked while waiting for one to become
+// ready or unavailable.
+const PodPollInterval = 2 * time.Second
+
+// ExecStreams are the streams attached to a command run in a container.  Nil streams
+// aren't attached.  When TTY is set stderr is merged into stdout by the container
+// runtime and must be nil.
+type ExecStreams struct {
+	Stdin             io.Reader
+	Stdout            io.Writer
+	Stderr            io.Writer
+	TTY               bool
+	TerminalSizeQueue remotecommand.TerminalSizeQueue
+}
+
+// PodExec runs commands in the containers of running pods through the exec API.
+type PodExec struct {
+	config    *rest.Config
+	clientset kubernetes.Interface
+}
+
+// NewPodExec creates a new exec client for the provided kube context.
+func NewPodExec(kubeContext string) (*PodExec, error) {
+	c, err := NewClient("", kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := c.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PodExec{
+		config:    config,
+		clientset: clientset,
+	}, nil
+}
+
+// RunningPods returns the pods matching the label selector that are running and
+// aren't being deleted.
+func (e *PodExec) RunningPods(ctx context.Context, ns, labels string) ([]corev1.Pod, error) {
+	list, err := e.clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{
+		LabelSelector: labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+
+	return pods, nil
+}
+
+// ReadyPod returns the newest pod matching the label selector that is running, ready
+// and isn't being deleted, or nil if there isn't one.  During a rollout the newest pod
+// runs the latest revision.
+func (e *PodExec) ReadyPod(ctx context.Context, ns, labels string) (*corev1.Pod, error) {
+	pods, err := e.RunningPods(ctx, ns, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var ready *corev1.Pod
+	for i := range pods {
+		if !IsPodReady(&pods[i]) {
+			continue
+		}
+
+		if ready == nil || ready.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			ready = &pods[i]
+		}
+	}
+
+	return ready, nil
+}
+
+// WaitForReadyPod blocks until a pod matching the label selector is ready and returns
+// it, or until the context is done.
+func (e *PodExec) WaitForReadyPod(ctx context.Context, ns, labels string) (*corev1.Pod, error) {
+	var pod *corev1.Pod
+	err := wait.PollUntilContextCancel(ctx, PodPollInterval, true, func(ctx context.Context) (bool, error) {
+		var err error
+		pod, err = e.ReadyPod(ctx, ns, labels)
+		return pod != nil, err
+	})
+
+	return pod, err
+}
+
+// WaitUntilUnavailable blocks until the pod is deleted, is being deleted or is no
+// longer ready, or until the context is done.  Errors getting the pod are retried.
+func (e *PodExec) WaitUntilUnavailable(ctx context.Context, ns, name string) error {
+	return wait.PollUntilContextCancel(ctx, PodPollInterval, false, func(ctx context.Context) (bool, error) {
+		return e.Unavailable(ctx, ns, name), nil
+	})
+}
+
+// Unavailable returns true if the pod is gone, is being deleted or isn't ready.
+// Transient errors getting the pod are treated as the pod being available.
+func (e *PodExec) Unavailable(ctx context.Context, ns, name string) bool {
+	pod, err := e.clientset.CoreV1().Pods(ns).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierr.IsNotFound(err):
+		return true
+	case err != nil:
+		return false
+	}
+
+	return pod.DeletionTimestamp != nil || !IsPodReady(pod)
+}
+
+// IsPodReady returns true if the pod's Ready condition is true.
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// Exec runs the command in the container and waits for it to exit.  A non-zero exit
+// code is returned as an error.  Websockets are used when the server supports them,
+// otherwise the connection falls back to SPDY like kubectl does.
+func (e *PodExec) Exec(ctx context.Context, ns, pod, container string, command []string, streams ExecStreams) error {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     streams.Stdin != nil,
+			Stdout:    streams.Stdout != nil,
+			Stderr:    streams.Stderr != nil,
+		}, scheme.ParameterCodec)
+
+	spdy, err := remotecommand.NewSPDYExecutor(e.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	ws, err := remotecommand.NewWebSocketExecutor(e.config, "GET", req.URL().String())
+	if err != nil {
+		return err
+	}
+
+	exec, err := remotecommand.NewFallbackExecutor(ws, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return err
+	}
+
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:             streams.Stdin,
+		Stdout:            streams.Stdout,
+		Stderr:            streams.Stderr,
+		Tty:               streams.TTY,
+		TerminalSizeQueue: streams.TerminalSizeQueue,
+	})
+}
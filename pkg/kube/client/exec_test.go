@@ -0,0 +1,117 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testPod(name string, created time.Time, phase corev1.PodPhase, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "dev",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{"app": "app", "group": "application"},
+		},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: status},
+			},
+		},
+	}
+}
+
+func TestReadyPod(t *testing.T) {
+	now := time.Now()
+	deleting := testPod("deleting", now, corev1.PodRunning, true)
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	deleting.Finalizers = []string{"test"}
+
+	tests := []struct {
+		name     string
+		pods     []runtime.Object
+		expected string
+	}{
+		{
+			name: "newest ready pod",
+			pods: []runtime.Object{
+				testPod("old", now.Add(-time.Hour), corev1.PodRunning, true),
+				testPod("new", now.Add(-time.Minute), corev1.PodRunning, true),
+				testPod("starting", now, corev1.PodRunning, false),
+				deleting,
+			},
+			expected: "new",
+		},
+		{
+			name: "no ready pods",
+			pods: []runtime.Object{
+				testPod("pending", now, corev1.PodPending, false),
+				testPod("starting", now, corev1.PodRunning, false),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := &PodExec{clientset: fake.NewSimpleClientset(tt.pods...)}
+
+			pod, err := exec.ReadyPod(context.Background(), "dev", "app=app,group=application")
+			require.NoError(t, err)
+
+			if tt.expected == "" {
+				assert.Nil(t, pod)
+				return
+			}
+
+			require.NotNil(t, pod)
+			assert.Equal(t, tt.expected, pod.Name)
+		})
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	now := time.Now()
+	deleting := testPod("deleting", now, corev1.PodRunning, true)
+	deleting.DeletionTimestamp = &metav1.Time{Time: now}
+	deleting.Finalizers = []string{"test"}
+
+	exec := &PodExec{clientset: fake.NewSimpleClientset(
+		testPod("ready", now, corev1.PodRunning, true),
+		testPod("unready", now, corev1.PodRunning, false),
+		deleting,
+	)}
+
+	ctx := context.Background()
+	assert.False(t, exec.Unavailable(ctx, "dev", "ready"))
+	assert.True(t, exec.Unavailable(ctx, "dev", "unready"))
+	assert.True(t, exec.Unavailable(ctx, "dev", "deleting"))
+	assert.True(t, exec.Unavailable(ctx, "dev", "missing"))
+}
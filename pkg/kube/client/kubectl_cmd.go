@@ -17,10 +17,13 @@ package client
 import (
 	"context"
 	"reflect"
+	"strings"
 	"time"
 
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 )
 
 // KubectlCmd is the kubernetes client that is used by the seactl tool.
@@ -58,6 +61,46 @@ func (c *KubectlCmd) Get(ctx context.Context, obj Object, opts metav1.GetOptions
 	return ConvertFromUnstructured(u, obj)
 }
 
+// Username returns the name the API server authenticates the client as.
+func (c *KubectlCmd) Username(ctx context.Context) (string, error) {
+	return c.client.Username(ctx)
+}
+
+// List gets the objects of the list's kind in the namespace, or in all namespaces if
+// ns is empty.  The list must have its GroupVersionKind set.
+func (c *KubectlCmd) List(ctx context.Context, list ObjectList, ns string, opts metav1.ListOptions) error {
+	gvk := list.GetObjectKind().GroupVersionKind()
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	dyn, err := c.client.Factory().DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	dc, err := c.client.ToDiscoveryClient()
+	if err != nil {
+		return err
+	}
+
+	resource, err := ServerResourcesForGroupVersionKind(dc, gvk, "list")
+	if err != nil {
+		return err
+	}
+
+	nri := dyn.Resource(gvk.GroupVersion().WithResource(resource.Name))
+	var iface dynamic.ResourceInterface = nri
+	if resource.Namespaced && ns != "" {
+		iface = nri.Namespace(ns)
+	}
+
+	u, err := iface.List(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), list)
+}
+
 func (c *KubectlCmd) Delete(ctx context.Context, obj Object, opts metav1.DeleteOptions) error {
 	iface, err := ResourceInterfaceFor(c.client, obj, "delete")
 	if err != nil {
@@ -0,0 +1,88 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards the local ports to the pod until the context is done or the
+// connection to the pod is lost.  Ports use the kubectl format, [local]:remote, and
+// ready is called with the bound ports once the listeners are up.  Errors forwarding
+// individual connections are written to errOut.
+func (e *PodExec) PortForward(
+	ctx context.Context,
+	ns, pod string,
+	ports []string,
+	errOut io.Writer,
+	ready func([]portforward.ForwardedPort),
+) error {
+	req := e.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(pod).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(e.config)
+	if err != nil {
+		return err
+	}
+	spdyDialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	// Like exec, websockets are tried first and SPDY is the fallback.
+	tunnel, err := portforward.NewSPDYOverWebsocketDialer(req.URL(), e.config)
+	if err != nil {
+		return err
+	}
+	dialer := portforward.NewFallbackDialer(tunnel, spdyDialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+
+	stop := make(chan struct{})
+	readyCh := make(chan struct{})
+	fw, err := portforward.New(dialer, ports, stop, readyCh, io.Discard, errOut)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stop)
+		case <-done:
+		}
+	}()
+
+	go func() {
+		select {
+		case <-readyCh:
+			if forwarded, err := fw.GetPorts(); err == nil {
+				ready(forwarded)
+			}
+		case <-done:
+		}
+	}()
+
+	return fw.ForwardPorts()
+}
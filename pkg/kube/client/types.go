@@ -33,6 +33,11 @@ type Object interface {
 	runtime.Object
 }
 
+type ObjectList interface {
+	metav1.ListInterface
+	runtime.Object
+}
+
 type ObjectKey types.NamespacedName
 
 type OperationResult string
@@ -0,0 +1,94 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lister
+
+import (
+	"context"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// EnvironmentConfigLister looks up environment configs.  Configs are only read from
+// a single namespace (the controller namespace) as they reference secrets that the
+// build jobs need access to.
+type EnvironmentConfigLister struct {
+	client    client.Reader
+	namespace string
+}
+
+// NewEnvironmentConfigLister returns a new lister for the configs in the namespace.
+func NewEnvironmentConfigLister(c client.Reader, namespace string) *EnvironmentConfigLister {
+	return &EnvironmentConfigLister{
+		client:    c,
+		namespace: namespace,
+	}
+}
+
+// List returns all of the configs in the namespace.
+func (l *EnvironmentConfigLister) List(ctx context.Context) ([]v1beta1.EnvironmentConfig, error) {
+	var list v1beta1.EnvironmentConfigList
+	if err := l.client.List(ctx, &list, client.InNamespace(l.namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		v1beta1.Defaulted(&list.Items[i])
+	}
+
+	return list.Items, nil
+}
+
+// Get returns the defaulted config with the given name.  If the config does not exist,
+// nil is returned without an error.
+func (l *EnvironmentConfigLister) Get(ctx context.Context, name string) (*v1beta1.EnvironmentConfig, error) {
+	var config v1beta1.EnvironmentConfig
+	if err := l.client.Get(ctx, types.NamespacedName{
+		Namespace: l.namespace,
+		Name:      name,
+	}, &config); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	v1beta1.Defaulted(&config)
+	return &config, nil
+}
+
+// Resolve returns the config with the given name.  If the name is empty or the config
+// does not exist, the default config is returned instead.  Nil is returned when neither
+// of the configs can be found, leaving it up to the caller to decide what to fall back to.
+func (l *EnvironmentConfigLister) Resolve(ctx context.Context, name, defaultName string) (*v1beta1.EnvironmentConfig, error) {
+	logger := log.FromContext(ctx)
+
+	if name != "" {
+		config, err := l.Get(ctx, name)
+		if err != nil || config != nil {
+			return config, err
+		}
+
+		logger.Info("environment config not found, using the default", "config", name, "default", defaultName)
+	}
+
+	if defaultName == "" || defaultName == name {
+		return nil, nil
+	}
+
+	return l.Get(ctx, defaultName)
+}
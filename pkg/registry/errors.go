@@ -15,6 +15,7 @@
 package registry
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 )
@@ -40,6 +41,9 @@ func NewClientError(resp *http.Response) *ClientError {
 
 // Error returns a string representation of the error message.
 func (e ClientError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: %s", e.URL, http.StatusText(e.StatusCode))
+	}
 	return e.Message
 }
 
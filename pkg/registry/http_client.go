@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -30,6 +31,8 @@ type Connector interface {
 	Post(url string, data any, v any) error
 	Get(url string, v any) error
 	Patch(url string, data any, v any) error
+	Head(url string, accept []string) (http.Header, error)
+	Delete(url string) error
 }
 
 // HTTPClient is a simple HTTP client that implements the Connector interface.
@@ -72,6 +75,55 @@ func (h *HTTPClient) Patch(url string, data any, v any) error {
 	return h.Do(http.MethodPatch, url, data, v)
 }
 
+// Head makes a HEAD request to the given URL with the accepted media types and returns
+// the response headers.
+func (h *HTTPClient) Head(url string, accept []string) (http.Header, error) {
+	req, err := http.NewRequest(http.MethodHead, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(accept, ", "))
+
+	resp, err := h.send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return resp.Header, nil
+}
+
+// Delete makes a DELETE request to the given URL.  The response body is discarded.
+func (h *HTTPClient) Delete(url string) error {
+	req, err := http.NewRequest(http.MethodDelete, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// send performs the request and returns a ClientError for any non-2xx response.
+func (h *HTTPClient) send(req *http.Request) (*http.Response, error) {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, NewClientError(resp)
+	}
+
+	return resp, nil
+}
+
 // Do makes an HTTP request to the given URL with the given method and data and decodes the
 // response into the interface that is passed in.
 func (h *HTTPClient) Do(method string, url string, data any, out any) error {
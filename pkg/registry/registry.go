@@ -18,9 +18,21 @@ import (
 	"fmt"
 )
 
+// ManifestMediaTypes are the manifest types that are accepted when resolving the
+// digest of a tag.
+var ManifestMediaTypes = []string{ //nolint:gochecknoglobals
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
 type API interface {
 	WithRegistry(string) API
 	HasTag(string, string) (bool, error)
+	Tags(string) ([]string, error)
+	Digest(string, string) (string, error)
+	DeleteTag(string, string) error
 }
 
 // Client is a client for the registry API.
@@ -60,4 +72,59 @@ func (c *Client) HasTag(name, tag string) (bool, error) {
 	return false, nil
 }
 
+// Tags returns all of the tags for the image.  An image that does not exist in the
+// registry has no tags.
+func (c *Client) Tags(name string) ([]string, error) {
+	url := fmt.Sprintf("%s/v2/%s/tags/list", c.registry, name)
+	var items TagsList
+	err := c.Get(url, &items)
+	if err != nil {
+		if IsNotFound(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	return items.Tags, nil
+}
+
+// Digest returns the digest of the manifest referenced by the tag.  Unlike the tag,
+// the digest always refers to the same image.
+func (c *Client) Digest(name, tag string) (string, error) {
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", c.registry, name, tag)
+	header, err := c.Head(url, ManifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+
+	digest := header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", fmt.Errorf("unable to resolve the digest for %s:%s", name, tag)
+	}
+
+	return digest, nil
+}
+
+// DeleteTag removes the manifest referenced by the tag.  The registry only supports
+// deleting manifests by digest, so the digest is resolved first.  This will remove
+// any other tags that share the manifest as well.  Tags that no longer exist are
+// ignored.  The registry must have deletes enabled.
+func (c *Client) DeleteTag(name, tag string) error {
+	digest, err := c.Digest(name, tag)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v2/%s/manifests/%s", c.registry, name, digest)
+	err = c.Delete(url)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
 var _ API = &Client{}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Tags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/test/tags/list":
+			_, _ = w.Write([]byte(`{"name":"test","tags":["1","2"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewHTTPClient()).WithRegistry(server.URL)
+
+	tags, err := client.Tags("test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, tags)
+
+	tags, err = client.Tags("missing")
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestClient_DeleteTag(t *testing.T) {
+	deleted := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/test/manifests/1":
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.oci.image.manifest.v1+json")
+			w.Header().Set("Docker-Content-Digest", "sha256:abcd")
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/test/manifests/sha256:abcd":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewHTTPClient()).WithRegistry(server.URL)
+
+	assert.NoError(t, client.DeleteTag("test", "1"))
+	assert.Equal(t, []string{"/v2/test/manifests/sha256:abcd"}, deleted)
+
+	// Tags that have already been removed are ignored.
+	assert.NoError(t, client.DeleteTag("test", "2"))
+	assert.Len(t, deleted, 1)
+}
+
+func TestClient_Digest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/test/manifests/1":
+			assert.Contains(t, r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.v2+json")
+			w.Header().Set("Docker-Content-Digest", "sha256:abcd")
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/test/manifests/2":
+			// No digest header.
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(NewHTTPClient()).WithRegistry(server.URL)
+
+	digest, err := client.Digest("test", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sha256:abcd", digest)
+
+	_, err = client.Digest("test", "2")
+	assert.Error(t, err)
+
+	_, err = client.Digest("test", "3")
+	assert.True(t, IsNotFound(err))
+}
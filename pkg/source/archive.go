@@ -0,0 +1,278 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+)
+
+var (
+	ErrInvalidArchive    = errors.New("invalid archive")
+	ErrUnsupportedEntry  = errors.New("unsupported entry")
+	ErrInvalidLink       = errors.New("link target is outside of the build context")
+	ErrTooLarge          = errors.New("build context is too large")
+	ErrTooManyEntries    = errors.New("build context has too many entries")
+	ErrMissingDockerfile = errors.New("dockerfile not found in the build context")
+)
+
+// Limits bounds the build contexts that are accepted.  A zero value disables the
+// limit.
+type Limits struct {
+	// MaxSize is the maximum size of the compressed archive.
+	MaxSize int64
+	// MaxUnpackedSize is the maximum combined size of the files in the context.
+	MaxUnpackedSize int64
+	// MaxEntries is the maximum number of entries in the context.
+	MaxEntries int
+}
+
+// CheckSize returns an error if the compressed archive is larger than the limit.
+func (l Limits) CheckSize(size int64) error {
+	if l.MaxSize > 0 && size > l.MaxSize {
+		return fmt.Errorf("%w: the archive is %d bytes, the limit is %d", ErrTooLarge, size, l.MaxSize)
+	}
+
+	return nil
+}
+
+func (l Limits) checkEntries(entries int) error {
+	if l.MaxEntries > 0 && entries > l.MaxEntries {
+		return fmt.Errorf("%w: the limit is %d", ErrTooManyEntries, l.MaxEntries)
+	}
+
+	return nil
+}
+
+func (l Limits) checkUnpackedSize(size int64) error {
+	if l.MaxUnpackedSize > 0 && size > l.MaxUnpackedSize {
+		return fmt.Errorf("%w: the files add up to more than %d bytes", ErrTooLarge, l.MaxUnpackedSize)
+	}
+
+	return nil
+}
+
+// ValidateArchive reads a gzipped tar archive and checks that it can be used as a
+// build context: every entry is a file, directory or link that stays inside of the
+// context, the limits aren't exceeded, and the dockerfile is present.  An empty
+// dockerfile skips the last check for engines that don't use one.  Errors from the
+// reader are returned as they are so they can be told apart from a bad archive.
+func ValidateArchive(r io.Reader, limits Limits, dockerfile string) error {
+	want, err := dockerfilePath(dockerfile)
+	if err != nil {
+		return err
+	}
+
+	src := &readErr{Reader: r}
+	counter := &counter{Reader: src}
+
+	gr, err := gzip.NewReader(counter)
+	if err != nil {
+		return archiveError(src, err)
+	}
+
+	tr := tar.NewReader(gr)
+
+	var entries int
+	var unpacked int64
+	found := dockerfile == ""
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return archiveError(src, err)
+		}
+
+		if hdr.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		entries++
+		if err := limits.checkEntries(entries); err != nil {
+			return err
+		}
+
+		p, err := checkEntry(hdr)
+		if err != nil {
+			return err
+		}
+
+		unpacked += hdr.Size
+		if err := limits.checkUnpackedSize(unpacked); err != nil {
+			return err
+		}
+
+		if p == want && hdr.Typeflag != tar.TypeDir {
+			found = true
+		}
+
+		if err := limits.CheckSize(counter.n); err != nil {
+			return err
+		}
+	}
+
+	// Reading to the end of the gzip stream verifies its checksum.
+	if _, err := io.Copy(io.Discard, gr); err != nil {
+		return archiveError(src, err)
+	}
+
+	if err := limits.CheckSize(counter.n); err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrMissingDockerfile, dockerfile)
+	}
+
+	return nil
+}
+
+// ValidateManifest applies the same checks as ValidateArchive to a manifest.  The paths
+// are expected to have been checked by Validate.
+func ValidateManifest(files []*seawayv1beta1.FileEntry, limits Limits, dockerfile string) error {
+	want, err := dockerfilePath(dockerfile)
+	if err != nil {
+		return err
+	}
+
+	if err := limits.checkEntries(len(files)); err != nil {
+		return err
+	}
+
+	var unpacked int64
+	found := dockerfile == ""
+	for _, f := range files {
+		unpacked += f.GetSize()
+		if f.GetPath() == want {
+			found = true
+		}
+	}
+
+	if err := limits.checkUnpackedSize(unpacked); err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrMissingDockerfile, dockerfile)
+	}
+
+	return nil
+}
+
+// checkEntry returns the path of the entry inside of the build context.
+func checkEntry(hdr *tar.Header) (string, error) {
+	p, err := entryPath(hdr.Name)
+	if err != nil {
+		return "", err
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeReg, tar.TypeRegA, tar.TypeDir: //nolint:staticcheck
+	case tar.TypeSymlink:
+		// Relative targets are resolved from the directory holding the link.
+		target := hdr.Linkname
+		if target == "" || path.IsAbs(target) || escapes(path.Join(path.Dir(p), target)) {
+			return "", fmt.Errorf("%w: %s -> %s", ErrInvalidLink, hdr.Name, hdr.Linkname)
+		}
+	case tar.TypeLink:
+		// Hard links name another entry in the archive.
+		if _, err := entryPath(hdr.Linkname); err != nil {
+			return "", fmt.Errorf("%w: %s -> %s", ErrInvalidLink, hdr.Name, hdr.Linkname)
+		}
+	default:
+		return "", fmt.Errorf("%w: %s has type %q", ErrUnsupportedEntry, hdr.Name, hdr.Typeflag)
+	}
+
+	return p, nil
+}
+
+// dockerfilePath returns the cleaned path of the dockerfile or an empty string if
+// there isn't one.
+func dockerfilePath(dockerfile string) (string, error) {
+	if dockerfile == "" {
+		return "", nil
+	}
+
+	return entryPath(dockerfile)
+}
+
+// entryPath returns the cleaned path of an archive entry.  Absolute paths and paths
+// that step out of the build context are rejected.
+func entryPath(name string) (string, error) {
+	p := strings.TrimPrefix(name, "./")
+	if name == "" || path.IsAbs(p) || escapes(p) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPath, name)
+	}
+
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPath, name)
+		}
+	}
+
+	return path.Clean(p), nil
+}
+
+// escapes returns true if the relative path resolves to a location outside of the
+// build context.
+func escapes(p string) bool {
+	p = path.Clean(p)
+	return p == ".." || strings.HasPrefix(p, "../")
+}
+
+// archiveError wraps errors from the tar and gzip readers.  If the underlying reader
+// failed its error is returned instead, since the archive itself may be fine.
+func archiveError(src *readErr, err error) error {
+	if src.err != nil {
+		return src.err
+	}
+
+	return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+}
+
+// readErr remembers the first error other than io.EOF returned by the reader.
+type readErr struct {
+	io.Reader
+	err error
+}
+
+func (r *readErr) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// counter counts the bytes read.
+type counter struct {
+	io.Reader
+	n int64
+}
+
+func (c *counter) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,122 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func archive(t *testing.T, headers ...*tar.Header) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, hdr := range headers {
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write(bytes.Repeat([]byte("a"), int(hdr.Size)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
+
+func file(name string, size int64) *tar.Header {
+	return &tar.Header{Typeflag: tar.TypeReg, Name: name, Size: size, Mode: 0o644}
+}
+
+func link(typ byte, name, target string) *tar.Header {
+	return &tar.Header{Typeflag: typ, Name: name, Linkname: target, Mode: 0o777}
+}
+
+func TestValidateArchive(t *testing.T) {
+	dockerfile := file("Dockerfile", 10)
+	valid := archive(t,
+		&tar.Header{Typeflag: tar.TypeDir, Name: "./", Mode: 0o755},
+		file("./Dockerfile", 10),
+		file("cmd/main.go", 20),
+		link(tar.TypeSymlink, "cmd/config", "../config"),
+		link(tar.TypeLink, "cmd/copy.go", "cmd/main.go"),
+	)
+	truncated := valid[:len(valid)-4]
+
+	tests := []struct {
+		name       string
+		data       []byte
+		limits     Limits
+		dockerfile string
+		err        error
+	}{
+		{"valid", valid, Limits{}, "Dockerfile", nil},
+		{"within limits", valid, Limits{MaxSize: int64(len(valid)), MaxUnpackedSize: 30, MaxEntries: 5}, "./Dockerfile", nil},
+		{"not gzip", []byte("plain text"), Limits{}, "", ErrInvalidArchive},
+		{"truncated", truncated, Limits{}, "", ErrInvalidArchive},
+		{"not tar", gzipped(t, "plain text"), Limits{}, "", ErrInvalidArchive},
+		{"absolute", archive(t, file("/etc/passwd", 1)), Limits{}, "", ErrInvalidPath},
+		{"traversal", archive(t, file("a/../../b", 1)), Limits{}, "", ErrInvalidPath},
+		{"dotdot segment", archive(t, file("a/../b", 1)), Limits{}, "", ErrInvalidPath},
+		{"absolute symlink", archive(t, link(tar.TypeSymlink, "etc", "/etc")), Limits{}, "", ErrInvalidLink},
+		{"escaping symlink", archive(t, link(tar.TypeSymlink, "a/b", "../../etc")), Limits{}, "", ErrInvalidLink},
+		{"escaping hardlink", archive(t, link(tar.TypeLink, "passwd", "../etc/passwd")), Limits{}, "", ErrInvalidLink},
+		{"device", archive(t, &tar.Header{Typeflag: tar.TypeChar, Name: "tty", Mode: 0o600}), Limits{}, "", ErrUnsupportedEntry},
+		{"fifo", archive(t, &tar.Header{Typeflag: tar.TypeFifo, Name: "pipe", Mode: 0o600}), Limits{}, "", ErrUnsupportedEntry},
+		{"compressed size", valid, Limits{MaxSize: 10}, "", ErrTooLarge},
+		{"unpacked size", valid, Limits{MaxUnpackedSize: 29}, "", ErrTooLarge},
+		{"entries", valid, Limits{MaxEntries: 4}, "", ErrTooManyEntries},
+		{"missing dockerfile", archive(t, file("main.go", 1)), Limits{}, "Dockerfile", ErrMissingDockerfile},
+		{"dockerfile directory", archive(t, &tar.Header{Typeflag: tar.TypeDir, Name: "Dockerfile/", Mode: 0o755}), Limits{}, "Dockerfile", ErrMissingDockerfile},
+		{"dockerfile outside", archive(t, dockerfile), Limits{}, "../Dockerfile", ErrInvalidPath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateArchive(bytes.NewReader(tt.data), tt.limits, tt.dockerfile)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidateArchive_ReaderError(t *testing.T) {
+	errRead := errors.New("connection reset")
+	err := ValidateArchive(iotest.ErrReader(errRead), Limits{}, "")
+	assert.ErrorIs(t, err, errRead)
+	assert.NotErrorIs(t, err, ErrInvalidArchive)
+}
+
+func TestValidateManifest(t *testing.T) {
+	files := []*seawayv1beta1.FileEntry{
+		entry("Dockerfile", "FROM scratch"),
+		entry("main.go", "package main"),
+	}
+
+	assert.NoError(t, ValidateManifest(files, Limits{}, "Dockerfile"))
+	assert.NoError(t, ValidateManifest(files, Limits{MaxEntries: 2, MaxUnpackedSize: 24}, "./Dockerfile"))
+	assert.ErrorIs(t, ValidateManifest(files, Limits{MaxEntries: 1}, ""), ErrTooManyEntries)
+	assert.ErrorIs(t, ValidateManifest(files, Limits{MaxUnpackedSize: 23}, ""), ErrTooLarge)
+	assert.ErrorIs(t, ValidateManifest(files, Limits{}, "build/Dockerfile"), ErrMissingDockerfile)
+}
+
+func gzipped(t *testing.T, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	return buf.Bytes()
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package source contains the file manifest used for incremental syncs.  The client
+// describes the build context as a list of paths and sha256 digests, uploads the
+// blobs the server doesn't have, and the server assembles the archive from them.
+package source
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+)
+
+const (
+	// DefaultMode is used for files that were sent without a mode.
+	DefaultMode = 0o644
+	// ExecutableMode is used for files with any of the executable bits set.
+	ExecutableMode = 0o755
+)
+
+var (
+	ErrInvalidPath   = errors.New("invalid path")
+	ErrInvalidDigest = errors.New("invalid digest")
+	ErrDuplicatePath = errors.New("duplicate path")
+	ErrSizeMismatch  = errors.New("blob size does not match the manifest")
+)
+
+// Opener returns the contents of the blob with the given digest.
+type Opener func(digest string) (io.ReadCloser, error)
+
+// Sort orders the files by path.  The manifest is always sorted before it is hashed
+// or assembled so the result doesn't depend on the order the files were found in.
+func Sort(files []*seawayv1beta1.FileEntry) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GetPath() < files[j].GetPath()
+	})
+}
+
+// Digest returns the sha256 digest of the manifest.  It's used as the revision of the
+// build context, so two syncs of the same files always produce the same revision.
+func Digest(files []*seawayv1beta1.FileEntry) string {
+	sorted := make([]*seawayv1beta1.FileEntry, len(files))
+	copy(sorted, files)
+	Sort(sorted)
+
+	h := sha256.New()
+	for _, f := range sorted {
+		fmt.Fprintf(h, "%o %s %s\n", f.GetMode()&0o777, f.GetSha256(), f.GetPath())
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Mode returns the mode recorded for a file.  Like git, only the executable bit is
+// kept so the umask of the checkout doesn't change the revision.
+func Mode(mode fs.FileMode) uint32 {
+	if mode.Perm()&0o111 != 0 {
+		return ExecutableMode
+	}
+
+	return DefaultMode
+}
+
+// Digests returns the unique blob digests referenced by the manifest.
+func Digests(files []*seawayv1beta1.FileEntry) []string {
+	seen := make(map[string]struct{}, len(files))
+	digests := make([]string, 0, len(files))
+	for _, f := range files {
+		if _, ok := seen[f.GetSha256()]; ok {
+			continue
+		}
+		seen[f.GetSha256()] = struct{}{}
+		digests = append(digests, f.GetSha256())
+	}
+
+	return digests
+}
+
+// ValidDigest returns true if the digest is a lowercase hex encoded sha256 sum.
+func ValidDigest(digest string) bool {
+	if len(digest) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(digest)
+	return err == nil && strings.ToLower(digest) == digest
+}
+
+// Validate checks that every path is relative and stays inside of the build context,
+// that the digests are well formed, and that no path is listed twice.
+func Validate(files []*seawayv1beta1.FileEntry) error {
+	seen := make(map[string]struct{}, len(files))
+	for _, f := range files {
+		p := f.GetPath()
+		if p == "" || path.IsAbs(p) || path.Clean(p) != p || p == ".." || strings.HasPrefix(p, "../") {
+			return fmt.Errorf("%w: %q", ErrInvalidPath, p)
+		}
+
+		if !ValidDigest(f.GetSha256()) {
+			return fmt.Errorf("%w: %q", ErrInvalidDigest, f.GetSha256())
+		}
+
+		if _, ok := seen[p]; ok {
+			return fmt.Errorf("%w: %q", ErrDuplicatePath, p)
+		}
+		seen[p] = struct{}{}
+	}
+
+	return nil
+}
+
+// WriteArchive writes the files in the manifest to a gzipped tar archive.  File
+// contents are read through the opener.  Entries are sorted, and modification times,
+// ownership and the gzip header are fixed so the archive only depends on the manifest.
+func WriteArchive(w io.Writer, files []*seawayv1beta1.FileEntry, open Opener) error {
+	sorted := make([]*seawayv1beta1.FileEntry, len(files))
+	copy(sorted, files)
+	Sort(sorted)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, f := range sorted {
+		if err := writeFile(tw, f, open); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
+
+func writeFile(tw *tar.Writer, f *seawayv1beta1.FileEntry, open Opener) error {
+	rc, err := open(f.GetSha256())
+	if err != nil {
+		return fmt.Errorf("unable to open blob for %s: %w", f.GetPath(), err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	mode := f.GetMode() & 0o777
+	if mode == 0 {
+		mode = DefaultMode
+	}
+
+	err = tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     f.GetPath(),
+		Size:     f.GetSize(),
+		Mode:     int64(mode),
+		ModTime:  time.Unix(0, 0),
+		Format:   tar.FormatPAX,
+	})
+	if err != nil {
+		return err
+	}
+
+	n, err := io.Copy(tw, io.LimitReader(rc, f.GetSize()+1))
+	if err != nil {
+		if errors.Is(err, tar.ErrWriteTooLong) {
+			return fmt.Errorf("%w: %s", ErrSizeMismatch, f.GetPath())
+		}
+		return err
+	}
+
+	if n != f.GetSize() {
+		return fmt.Errorf("%w: %s", ErrSizeMismatch, f.GetPath())
+	}
+
+	return nil
+}
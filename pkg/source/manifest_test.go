@@ -0,0 +1,141 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	seawayv1beta1 "ctx.sh/seaway/pkg/gen/seaway/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blob(content string) (string, int64) {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:]), int64(len(content))
+}
+
+func entry(p, content string) *seawayv1beta1.FileEntry {
+	digest, size := blob(content)
+	return &seawayv1beta1.FileEntry{Path: p, Sha256: digest, Size: size, Mode: 0o644}
+}
+
+func opener(contents ...string) Opener {
+	blobs := make(map[string]string, len(contents))
+	for _, c := range contents {
+		digest, _ := blob(c)
+		blobs[digest] = c
+	}
+
+	return func(digest string) (io.ReadCloser, error) {
+		c, ok := blobs[digest]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		return io.NopCloser(strings.NewReader(c)), nil
+	}
+}
+
+func TestDigest(t *testing.T) {
+	a := []*seawayv1beta1.FileEntry{entry("Dockerfile", "FROM scratch"), entry("main.go", "package main")}
+	b := []*seawayv1beta1.FileEntry{entry("main.go", "package main"), entry("Dockerfile", "FROM scratch")}
+	assert.Equal(t, Digest(a), Digest(b))
+	assert.True(t, ValidDigest(Digest(a)))
+
+	// The order of the caller's slice is left alone.
+	assert.Equal(t, "main.go", b[0].GetPath())
+
+	c := []*seawayv1beta1.FileEntry{entry("Dockerfile", "FROM scratch"), entry("main.go", "package other")}
+	assert.NotEqual(t, Digest(a), Digest(c))
+
+	d := []*seawayv1beta1.FileEntry{entry("Dockerfile", "FROM scratch"), entry("main.go", "package main")}
+	d[1].Mode = 0o755
+	assert.NotEqual(t, Digest(a), Digest(d))
+}
+
+func TestDigests(t *testing.T) {
+	files := []*seawayv1beta1.FileEntry{entry("a", "same"), entry("b", "same"), entry("c", "other")}
+	assert.Len(t, Digests(files), 2)
+}
+
+func TestValidate(t *testing.T) {
+	valid, _ := blob("x")
+
+	tests := []struct {
+		name  string
+		files []*seawayv1beta1.FileEntry
+		err   error
+	}{
+		{"valid", []*seawayv1beta1.FileEntry{{Path: "a/b.go", Sha256: valid}}, nil},
+		{"empty path", []*seawayv1beta1.FileEntry{{Path: "", Sha256: valid}}, ErrInvalidPath},
+		{"absolute", []*seawayv1beta1.FileEntry{{Path: "/etc/passwd", Sha256: valid}}, ErrInvalidPath},
+		{"traversal", []*seawayv1beta1.FileEntry{{Path: "../x", Sha256: valid}}, ErrInvalidPath},
+		{"unclean", []*seawayv1beta1.FileEntry{{Path: "a/../../x", Sha256: valid}}, ErrInvalidPath},
+		{"short digest", []*seawayv1beta1.FileEntry{{Path: "a", Sha256: "abc"}}, ErrInvalidDigest},
+		{"uppercase digest", []*seawayv1beta1.FileEntry{{Path: "a", Sha256: strings.ToUpper(valid)}}, ErrInvalidDigest},
+		{"duplicate", []*seawayv1beta1.FileEntry{{Path: "a", Sha256: valid}, {Path: "a", Sha256: valid}}, ErrDuplicatePath},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.files)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	files := []*seawayv1beta1.FileEntry{entry("main.go", "package main"), entry("Dockerfile", "FROM scratch")}
+	open := opener("package main", "FROM scratch")
+
+	var first, second bytes.Buffer
+	require.NoError(t, WriteArchive(&first, files, open))
+	require.NoError(t, WriteArchive(&second, files, open))
+	assert.Equal(t, first.Bytes(), second.Bytes())
+
+	gr, err := gzip.NewReader(&first)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), hdr.ModTime.Unix())
+		names = append(names, hdr.Name)
+	}
+	assert.Equal(t, []string{"Dockerfile", "main.go"}, names)
+}
+
+func TestMode(t *testing.T) {
+	assert.Equal(t, uint32(0o644), Mode(0o644))
+	assert.Equal(t, uint32(0o644), Mode(0o664))
+	assert.Equal(t, uint32(0o644), Mode(0o600))
+	assert.Equal(t, uint32(0o755), Mode(0o775))
+	assert.Equal(t, uint32(0o755), Mode(0o700))
+}
+
+func TestWriteArchive_SizeMismatch(t *testing.T) {
+	short := entry("main.go", "package main")
+	short.Size--
+	long := entry("main.go", "package main")
+	long.Size++
+
+	for _, f := range []*seawayv1beta1.FileEntry{short, long} {
+		err := WriteArchive(io.Discard, []*seawayv1beta1.FileEntry{f}, opener("package main"))
+		assert.ErrorIs(t, err, ErrSizeMismatch)
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+)
+
+type AzureOptions struct {
+	// Endpoint is the blob service url of the storage account, for example
+	// https://account.blob.core.windows.net.  For emulators the account is the first
+	// element of the path.
+	Endpoint  string
+	Container string
+	// AccessKey is optional.  If it's not set, the key is pulled from the
+	// AZURE_STORAGE_ACCESS_KEY environment variable.
+	AccessKey string
+}
+
+// Azure is an Azure Blob Storage backend.  Multipart uploads are staged as blocks of
+// the blob and committed together.
+type Azure struct {
+	options *AzureOptions
+}
+
+func NewAzure(options *AzureOptions) *Azure {
+	return &Azure{
+		options: options,
+	}
+}
+
+// Put streams the object to the container.
+func (a *Azure) Put(ctx context.Context, key string, r io.Reader, _ int64) (ObjectInfo, error) {
+	client, err := a.client()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	counter := &countingReader{Reader: r}
+	resp, err := client.UploadStream(ctx, a.options.Container, key, counter, nil)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info := ObjectInfo{Key: key, Size: counter.n}
+	if resp.ETag != nil {
+		info.ETag = string(*resp.ETag)
+	}
+
+	return info, nil
+}
+
+// Get returns the contents of the object.
+func (a *Azure) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	client, err := a.client()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.DownloadStream(ctx, a.options.Container, key, nil)
+	if err != nil {
+		return nil, azureError(err)
+	}
+
+	return resp.Body, nil
+}
+
+// Delete removes the object from the container.
+func (a *Azure) Delete(ctx context.Context, key string) error {
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteBlob(ctx, a.options.Container, key, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns the objects under the prefix.
+func (a *Azure) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	client, err := a.client()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]ObjectInfo, 0)
+	pager := client.NewListBlobsFlatPager(a.options.Container, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, azureError(err)
+		}
+
+		for _, item := range page.Segment.BlobItems {
+			info := ObjectInfo{Key: deref(item.Name)}
+			if props := item.Properties; props != nil {
+				info.Size = deref(props.ContentLength)
+				info.LastModified = deref(props.LastModified)
+				if props.ETag != nil {
+					info.ETag = string(*props.ETag)
+				}
+			}
+			objects = append(objects, info)
+		}
+	}
+
+	return objects, nil
+}
+
+// Exists returns whether the object is in the container.
+func (a *Azure) Exists(ctx context.Context, key string) (bool, error) {
+	blob, err := a.blob(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = blob.GetProperties(ctx, nil)
+	switch {
+	case err == nil:
+		return true, nil
+	case bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// EnsureBucket creates the container if it doesn't exist.
+func (a *Azure) EnsureBucket(ctx context.Context) error {
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CreateContainer(ctx, a.options.Container, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return err
+	}
+
+	return nil
+}
+
+// NewUpload returns a new upload id.  Azure doesn't track uploads, the id is only
+// used to name the blocks that belong to the upload.
+func (a *Azure) NewUpload(_ context.Context, _ string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// PutPart stages the part as an uncommitted block.
+func (a *Azure) PutPart(ctx context.Context, key, uploadID string, number int, data []byte, _ string) (Part, error) {
+	blob, err := a.blob(key)
+	if err != nil {
+		return Part{}, err
+	}
+
+	id := blockID(uploadID, number)
+	_, err = blob.StageBlock(ctx, id, streaming.NopCloser(bytes.NewReader(data)), nil)
+	if err != nil {
+		return Part{}, azureError(err)
+	}
+
+	return Part{Number: number, Size: int64(len(data)), ETag: id}, nil
+}
+
+// Parts returns the uncommitted blocks that belong to the upload.
+func (a *Azure) Parts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	blob, err := a.blob(key)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := blob.GetBlockList(ctx, blockblob.BlockListTypeUncommitted, nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, azureError(err)
+	}
+
+	parts := make([]Part, 0)
+	for _, block := range resp.BlockList.UncommittedBlocks {
+		number, ok := blockNumber(uploadID, deref(block.Name))
+		if !ok {
+			continue
+		}
+		parts = append(parts, Part{Number: number, Size: deref(block.Size), ETag: deref(block.Name)})
+	}
+
+	slices.SortFunc(parts, func(a, b Part) int { return a.Number - b.Number })
+	return parts, nil
+}
+
+// CompleteUpload commits the blocks of the upload as the content of the blob.
+func (a *Azure) CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) (ObjectInfo, error) {
+	blob, err := a.blob(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	ids := make([]string, len(parts))
+	var size int64
+	for i, p := range parts {
+		ids[i] = blockID(uploadID, p.Number)
+		size += p.Size
+	}
+
+	resp, err := blob.CommitBlockList(ctx, ids, nil)
+	if err != nil {
+		return ObjectInfo{}, azureError(err)
+	}
+
+	info := ObjectInfo{Key: key, Size: size}
+	if resp.ETag != nil {
+		info.ETag = string(*resp.ETag)
+	}
+
+	return info, nil
+}
+
+// AbortUpload does nothing.  Uncommitted blocks are discarded by Azure when the blob
+// is committed or after a week.
+func (a *Azure) AbortUpload(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (a *Azure) blob(key string) (*blockblob.Client, error) {
+	client, err := a.client()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.ServiceClient().NewContainerClient(a.options.Container).NewBlockBlobClient(key), nil
+}
+
+func (a *Azure) client() (*azblob.Client, error) {
+	account, err := azureAccount(a.options.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	key := a.options.AccessKey
+	if key == "" {
+		key = os.Getenv(AzureAccessKey)
+	}
+
+	if key == "" {
+		return nil, fmt.Errorf("the azure storage access key is not set")
+	}
+
+	cred, err := azblob.NewSharedKeyCredential(account, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return azblob.NewClientWithSharedKeyCredential(a.options.Endpoint, cred, nil)
+}
+
+// azureAccount returns the storage account name from the blob service url.
+func azureAccount(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	// Emulators like azurite put the account in the path.
+	if account, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/"); account != "" {
+		return account, nil
+	}
+
+	if account, _, _ := strings.Cut(u.Hostname(), "."); account != "" {
+		return account, nil
+	}
+
+	return "", fmt.Errorf("unable to find the storage account in %q", endpoint)
+}
+
+// blockID returns the block id for a part.  Block ids of a blob must all be the same
+// length so the part number is zero padded.
+func blockID(uploadID string, number int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s-%05d", uploadID, number)))
+}
+
+// blockNumber returns the part number from a block id if the block belongs to the
+// upload.
+func blockNumber(uploadID, id string) (int, bool) {
+	raw, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return 0, false
+	}
+
+	n, ok := strings.CutPrefix(string(raw), uploadID+"-")
+	if !ok {
+		return 0, false
+	}
+
+	number, err := strconv.Atoi(n)
+	return number, err == nil
+}
+
+// azureError translates the errors for missing blobs and containers to ErrNotFound.
+func azureError(err error) error {
+	if bloberror.HasCode(err, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotFound, err)
+	}
+
+	return err
+}
+
+// countingReader counts the bytes read so the size of a streamed object is known.
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+
+	return *v
+}
+
+var (
+	_ Storage   = &Azure{}
+	_ Multipart = &Azure{}
+	_ Bucket    = &Azure{}
+)
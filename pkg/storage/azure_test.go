@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureAccount(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		account  string
+	}{
+		{"https://dev.blob.core.windows.net", "dev"},
+		{"https://dev.blob.core.windows.net/", "dev"},
+		{"http://azurite:10000/devstoreaccount1", "devstoreaccount1"},
+	}
+
+	for _, tt := range tests {
+		account, err := azureAccount(tt.endpoint)
+		assert.NoError(t, err, tt.endpoint)
+		assert.Equal(t, tt.account, account, tt.endpoint)
+	}
+}
+
+func TestBlockID(t *testing.T) {
+	id := blockID("abc", 12)
+	assert.Len(t, id, len(blockID("abc", 1)))
+
+	number, ok := blockNumber("abc", id)
+	assert.True(t, ok)
+	assert.Equal(t, 12, number)
+
+	_, ok = blockNumber("other", id)
+	assert.False(t, ok)
+}
@@ -0,0 +1,342 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// uploadsDir holds the parts of multipart uploads that haven't been completed.  It's
+// not a valid key so it never shows up in listings.
+const uploadsDir = ".uploads"
+
+// Filesystem stores objects as files under a root directory.  It's meant for a
+// persistent volume claim that is mounted by both the operator and the build jobs,
+// and for tests that don't have an object store available.
+type Filesystem struct {
+	root string
+}
+
+// NewFilesystem returns a backend that stores objects under the root directory.
+func NewFilesystem(root string) *Filesystem {
+	return &Filesystem{
+		root: root,
+	}
+}
+
+// Put writes the object to a temporary file and moves it into place so readers never
+// see a partial object.
+func (f *Filesystem) Put(_ context.Context, key string, r io.Reader, size int64) (ObjectInfo, error) {
+	name, err := f.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	// The md5 sum is used as the etag to match what S3 reports for single part objects.
+	h := md5.New() //nolint:gosec
+	n, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if size >= 0 && n != size {
+		return ObjectInfo{}, fmt.Errorf("expected %d bytes, got %d", size, n)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{Key: key, Size: n, ETag: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Get opens the object.
+func (f *Filesystem) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	name, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fsError(err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the object.
+func (f *Filesystem) Delete(_ context.Context, key string) error {
+	name, err := f.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List walks the root and returns the objects with keys that start with the prefix.
+func (f *Filesystem) List(_ context.Context, prefix string) ([]ObjectInfo, error) {
+	objects := make([]ObjectInfo, 0)
+	err := filepath.WalkDir(f.root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == uploadsDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(f.root, name)
+		if err != nil {
+			return err
+		}
+
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// Exists returns whether the object exists.
+func (f *Filesystem) Exists(_ context.Context, key string) (bool, error) {
+	name, err := f.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(name)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// EnsureBucket creates the root directory.
+func (f *Filesystem) EnsureBucket(_ context.Context) error {
+	return os.MkdirAll(f.root, 0o755)
+}
+
+// NewUpload creates a directory for the parts of the upload.
+func (f *Filesystem) NewUpload(_ context.Context, key string) (string, error) {
+	if _, err := f.path(key); err != nil {
+		return "", err
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	id := hex.EncodeToString(b)
+	if err := os.MkdirAll(f.uploadPath(id), 0o755); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// PutPart writes the part into the upload directory.  The digest isn't checked again
+// as the service has already verified it.
+func (f *Filesystem) PutPart(ctx context.Context, _, uploadID string, number int, data []byte, _ string) (Part, error) {
+	dir, err := f.upload(uploadID)
+	if err != nil {
+		return Part{}, err
+	}
+
+	sub := NewFilesystem(dir)
+	info, err := sub.Put(ctx, strconv.Itoa(number), bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Part{}, err
+	}
+
+	return Part{Number: number, Size: info.Size, ETag: info.ETag}, nil
+}
+
+// Parts returns the parts that have been written to the upload directory.
+func (f *Filesystem) Parts(_ context.Context, _, uploadID string) ([]Part, error) {
+	dir, err := f.upload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fsError(err)
+	}
+
+	parts := make([]Part, 0, len(entries))
+	for _, e := range entries {
+		number, err := strconv.Atoi(e.Name())
+		if err != nil || e.IsDir() {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, Part{Number: number, Size: info.Size()})
+	}
+
+	slices.SortFunc(parts, func(a, b Part) int { return a.Number - b.Number })
+	return parts, nil
+}
+
+// CompleteUpload concatenates the parts into the object and removes the upload.
+func (f *Filesystem) CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) (ObjectInfo, error) {
+	dir, err := f.upload(uploadID)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	files := make([]*os.File, 0, len(parts))
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+
+	readers := make([]io.Reader, 0, len(parts))
+	for _, p := range parts {
+		file, err := os.Open(filepath.Join(dir, strconv.Itoa(p.Number)))
+		if err != nil {
+			return ObjectInfo{}, fsError(err)
+		}
+		files = append(files, file)
+		readers = append(readers, file)
+	}
+
+	info, err := f.Put(ctx, key, io.MultiReader(readers...), -1)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return info, os.RemoveAll(dir)
+}
+
+// AbortUpload removes the upload directory.
+func (f *Filesystem) AbortUpload(_ context.Context, _, uploadID string) error {
+	dir, err := f.upload(uploadID)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// path returns the file for the key.  Keys are always relative to the root, anything
+// that would escape it is rejected.
+func (f *Filesystem) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean[1:] != key || strings.HasPrefix(key, uploadsDir+"/") {
+		return "", fmt.Errorf("invalid key %q", key)
+	}
+
+	return filepath.Join(f.root, filepath.FromSlash(key)), nil
+}
+
+func (f *Filesystem) uploadPath(id string) string {
+	return filepath.Join(f.root, uploadsDir, id)
+}
+
+// upload returns the directory of an existing upload.
+func (f *Filesystem) upload(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("%w: upload %q", ErrNotFound, id)
+	}
+
+	dir := f.uploadPath(id)
+	if _, err := os.Stat(dir); err != nil {
+		return "", fsError(err)
+	}
+
+	return dir, nil
+}
+
+// fsError translates missing files to ErrNotFound.
+func fsError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, err)
+	}
+
+	return err
+}
+
+var (
+	_ Storage   = &Filesystem{}
+	_ Multipart = &Filesystem{}
+	_ Bucket    = &Filesystem{}
+)
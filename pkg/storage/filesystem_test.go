@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystem(t *testing.T) {
+	ctx := context.Background()
+	fs := NewFilesystem(t.TempDir())
+	require.NoError(t, fs.EnsureBucket(ctx))
+
+	info, err := fs.Put(ctx, "artifacts/default-test.tar.gz", strings.NewReader("hello"), 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), info.Size)
+	// md5 of hello
+	assert.Equal(t, "5d41402abc4b2a76b9719d911017c592", info.ETag)
+
+	ok, err := fs.Exists(ctx, "artifacts/default-test.tar.gz")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	rc, err := fs.Get(ctx, "artifacts/default-test.tar.gz")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "hello", string(data))
+
+	_, err = fs.Put(ctx, "blobs/abc", strings.NewReader("blob"), -1)
+	require.NoError(t, err)
+
+	objects, err := fs.List(ctx, "artifacts/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "artifacts/default-test.tar.gz", objects[0].Key)
+	assert.Equal(t, int64(5), objects[0].Size)
+	assert.False(t, objects[0].LastModified.IsZero())
+
+	require.NoError(t, fs.Delete(ctx, "artifacts/default-test.tar.gz"))
+	require.NoError(t, fs.Delete(ctx, "artifacts/default-test.tar.gz"))
+
+	ok, err = fs.Exists(ctx, "artifacts/default-test.tar.gz")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = fs.Get(ctx, "artifacts/default-test.tar.gz")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFilesystem_PutSizeMismatch(t *testing.T) {
+	ctx := context.Background()
+	fs := NewFilesystem(t.TempDir())
+
+	_, err := fs.Put(ctx, "key", strings.NewReader("hello"), 4)
+	assert.ErrorContains(t, err, "expected 4 bytes, got 5")
+
+	ok, err := fs.Exists(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestFilesystem_InvalidKey(t *testing.T) {
+	fs := NewFilesystem(t.TempDir())
+
+	for _, key := range []string{"", "/abs", "../escape", "a/../../b", "a//b", ".uploads/x"} {
+		_, err := fs.Put(context.Background(), key, strings.NewReader("x"), 1)
+		assert.ErrorContains(t, err, "invalid key", key)
+	}
+}
+
+func TestFilesystem_Multipart(t *testing.T) {
+	ctx := context.Background()
+	fs := NewFilesystem(t.TempDir())
+
+	id, err := fs.NewUpload(ctx, "archive")
+	require.NoError(t, err)
+
+	_, err = fs.PutPart(ctx, "archive", id, 2, []byte("world"), "")
+	require.NoError(t, err)
+	_, err = fs.PutPart(ctx, "archive", id, 1, []byte("hello "), "")
+	require.NoError(t, err)
+
+	parts, err := fs.Parts(ctx, "archive", id)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.Equal(t, 1, parts[0].Number)
+	assert.Equal(t, int64(6), parts[0].Size)
+	assert.Equal(t, 2, parts[1].Number)
+
+	objects, err := fs.List(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, objects, "parts should not be listed")
+
+	info, err := fs.CompleteUpload(ctx, "archive", id, parts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), info.Size)
+
+	rc, err := fs.Get(ctx, "archive")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "hello world", string(data))
+
+	_, err = fs.Parts(ctx, "archive", id)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = fs.PutPart(ctx, "archive", "../escape", 1, []byte("x"), "")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	gcs "cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+type GCSOptions struct {
+	// Endpoint overrides the storage API endpoint.  It's only needed for emulators.
+	Endpoint string
+	Bucket   string
+	// CredentialsJSON is an optional service account key.  If it's not set, the
+	// application default credentials are used.
+	CredentialsJSON []byte
+}
+
+// GCS is a Google Cloud Storage backend.  It doesn't support multipart uploads so
+// archives are always sent in a single stream.
+type GCS struct {
+	options *GCSOptions
+}
+
+func NewGCS(options *GCSOptions) *GCS {
+	return &GCS{
+		options: options,
+	}
+}
+
+// Put streams the object to the bucket.  Closing the writer finalizes the object, so
+// if the reader fails the write is aborted by canceling its context instead, which
+// leaves any existing object in place.
+func (g *GCS) Put(ctx context.Context, key string, r io.Reader, _ int64) (ObjectInfo, error) {
+	client, err := g.client(ctx)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer client.Close()
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := client.Bucket(g.options.Bucket).Object(key).NewWriter(wctx)
+	if _, err := io.Copy(w, r); err != nil {
+		cancel()
+		// Close only releases the writer once the context is canceled.
+		_ = w.Close()
+		return ObjectInfo{}, err
+	}
+
+	if err := w.Close(); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	attrs := w.Attrs()
+	return ObjectInfo{Key: key, Size: attrs.Size, ETag: hex.EncodeToString(attrs.MD5)}, nil
+}
+
+// Get returns the contents of the object.
+func (g *GCS) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	client, err := g.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := client.Bucket(g.options.Bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		_ = client.Close()
+		return nil, gcsError(err)
+	}
+
+	return &gcsReader{Reader: r, client: client}, nil
+}
+
+// Delete removes the object from the bucket.
+func (g *GCS) Delete(ctx context.Context, key string) error {
+	client, err := g.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.Bucket(g.options.Bucket).Object(key).Delete(ctx)
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) && !errors.Is(err, gcs.ErrBucketNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns the objects under the prefix.
+func (g *GCS) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	client, err := g.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	objects := make([]ObjectInfo, 0)
+	it := client.Bucket(g.options.Bucket).Objects(ctx, &gcs.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, gcsError(err)
+		}
+
+		objects = append(objects, ObjectInfo{Key: attrs.Name, Size: attrs.Size, ETag: attrs.Etag, LastModified: attrs.Updated})
+	}
+}
+
+// Exists returns whether the object is in the bucket.
+func (g *GCS) Exists(ctx context.Context, key string) (bool, error) {
+	client, err := g.client(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	_, err = client.Bucket(g.options.Bucket).Object(key).Attrs(ctx)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, gcs.ErrObjectNotExist):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (g *GCS) client(ctx context.Context) (*gcs.Client, error) {
+	opts := make([]option.ClientOption, 0)
+	if g.options.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(g.options.Endpoint))
+	}
+
+	if len(g.options.CredentialsJSON) > 0 {
+		opts = append(opts, option.WithAuthCredentialsJSON(option.ServiceAccount, g.options.CredentialsJSON))
+	}
+
+	return gcs.NewClient(ctx, opts...)
+}
+
+// gcsReader closes the client along with the reader.
+type gcsReader struct {
+	*gcs.Reader
+	client *gcs.Client
+}
+
+func (r *gcsReader) Close() error {
+	err := r.Reader.Close()
+	_ = r.client.Close()
+	return err
+}
+
+// gcsError translates the errors for missing objects and buckets to ErrNotFound.
+func gcsError(err error) error {
+	if errors.Is(err, gcs.ErrObjectNotExist) || errors.Is(err, gcs.ErrBucketNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, err)
+	}
+
+	return err
+}
+
+var _ Storage = &GCS{}
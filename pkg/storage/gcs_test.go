@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingReader returns the data and then fails instead of reaching the end.
+type failingReader struct {
+	r io.Reader
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, errors.New("stream interrupted")
+	}
+	return n, err
+}
+
+func TestGCS_PutAborted(t *testing.T) {
+	var mu sync.Mutex
+	stored := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only uploads that are sent in full create an object.
+		if _, err := io.ReadAll(r.Body); err != nil {
+			return
+		}
+		if r.Method == http.MethodPost && strings.Contains(r.URL.Path, "/upload/") {
+			mu.Lock()
+			stored++
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"key","bucket":"seaway","size":"4"}`))
+	}))
+	defer server.Close()
+
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return stored
+	}
+
+	t.Setenv("STORAGE_EMULATOR_HOST", server.URL)
+	backend := NewGCS(&GCSOptions{Bucket: "seaway"})
+
+	_, err := backend.Put(context.Background(), "key", &failingReader{r: strings.NewReader("data")}, -1)
+	assert.ErrorContains(t, err, "stream interrupted")
+
+	assert.Equal(t, 0, count())
+
+	// A complete stream is stored.
+	_, err = backend.Put(context.Background(), "key", strings.NewReader("data"), -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count())
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
+)
+
+const (
+	// S3AccessKey and S3SecretKey are the keys in the credentials secret used by the
+	// s3 backend.
+	S3AccessKey = "AWS_ACCESS_KEY_ID"
+	S3SecretKey = "AWS_SECRET_ACCESS_KEY"
+	// AzureAccessKey is the key in the credentials secret with the storage account key.
+	AzureAccessKey = "AZURE_STORAGE_ACCESS_KEY"
+	// GCSCredentials is the key in the credentials secret with the service account key.
+	GCSCredentials = "credentials.json"
+)
+
+// New returns the backend for the storage config.  The credentials are the data from
+// the credentials secret.  When they are nil the backends fall back to the
+// credentials available in the environment.
+func New(config v1beta1.EnvironmentConfigStorage, credentials map[string][]byte) (Storage, error) {
+	switch config.Type {
+	case v1beta1.EnvironmentStorageTypeS3, "":
+		return NewS3(&S3Options{
+			Endpoint:       config.Endpoint,
+			Region:         config.Region,
+			Bucket:         config.Bucket,
+			ForcePathStyle: config.ForcePathStyle,
+			AccessKey:      string(credentials[S3AccessKey]),
+			SecretKey:      string(credentials[S3SecretKey]),
+		}), nil
+	case v1beta1.EnvironmentStorageTypeGCS:
+		return NewGCS(&GCSOptions{
+			Endpoint:        config.Endpoint,
+			Bucket:          config.Bucket,
+			CredentialsJSON: credentials[GCSCredentials],
+		}), nil
+	case v1beta1.EnvironmentStorageTypeAzure:
+		return NewAzure(&AzureOptions{
+			Endpoint:  config.Endpoint,
+			Container: config.Bucket,
+			AccessKey: string(credentials[AzureAccessKey]),
+		}), nil
+	case v1beta1.EnvironmentStorageTypeFilesystem:
+		if config.Path == "" {
+			return nil, fmt.Errorf("the filesystem storage requires a path")
+		}
+		return NewFilesystem(filepath.Join(config.Path, config.Bucket)), nil
+	default:
+		return nil, fmt.Errorf("unknown storage type %q", config.Type)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+type S3Options struct {
+	Endpoint       string
+	Region         string
+	Bucket         string
+	ForcePathStyle bool
+	// AccessKey and SecretKey are optional.  If they are not set, the credentials
+	// are pulled from the environment.
+	AccessKey string
+	SecretKey string
+}
+
+// S3 is an S3 compatible object storage backend.
+type S3 struct {
+	options *S3Options
+}
+
+func NewS3(options *S3Options) *S3 {
+	return &S3{
+		options: options,
+	}
+}
+
+// Put streams the object to the bucket.
+func (s *S3) Put(ctx context.Context, key string, r io.Reader, size int64) (ObjectInfo, error) {
+	client, err := s.client()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	info, err := client.PutObject(ctx, s.options.Bucket, key, r, size, minio.PutObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return ObjectInfo{Key: info.Key, Size: info.Size, ETag: info.ETag}, nil
+}
+
+// Get returns the contents of the object.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.GetObject(ctx, s.options.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	// GetObject is lazy, stat so a missing object is reported before it's read.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, s3Error(err)
+	}
+
+	return obj, nil
+}
+
+// Delete removes the object from the bucket.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+
+	err = client.RemoveObject(ctx, s.options.Bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		resp := minio.ToErrorResponse(err)
+		if resp.Code == minio.NoSuchKey || resp.Code == minio.NoSuchBucket {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// List returns the objects under the prefix.
+func (s *S3) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]ObjectInfo, 0)
+	for obj := range client.ListObjects(ctx, s.options.Bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if obj.Err != nil {
+			return nil, s3Error(obj.Err)
+		}
+		objects = append(objects, ObjectInfo{Key: obj.Key, Size: obj.Size, ETag: obj.ETag, LastModified: obj.LastModified})
+	}
+
+	return objects, nil
+}
+
+// Exists returns whether the object is in the bucket.
+func (s *S3) Exists(ctx context.Context, key string) (bool, error) {
+	client, err := s.client()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.StatObject(ctx, s.options.Bucket, key, minio.StatObjectOptions{})
+	switch err = s3Error(err); {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// EnsureBucket creates the bucket if it doesn't exist.
+func (s *S3) EnsureBucket(ctx context.Context) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+
+	ok, err := client.BucketExists(ctx, s.options.Bucket)
+	if err != nil || ok {
+		return err
+	}
+
+	return client.MakeBucket(ctx, s.options.Bucket, minio.MakeBucketOptions{
+		Region: s.options.Region,
+	})
+}
+
+// NewUpload starts a multipart upload for the key.
+func (s *S3) NewUpload(ctx context.Context, key string) (string, error) {
+	core, err := s.core()
+	if err != nil {
+		return "", err
+	}
+
+	return core.NewMultipartUpload(ctx, s.options.Bucket, key, minio.PutObjectOptions{})
+}
+
+// PutPart stores a single part.  The digest is sent along so the object store
+// verifies it as well.
+func (s *S3) PutPart(ctx context.Context, key, uploadID string, number int, data []byte, digest string) (Part, error) {
+	core, err := s.core()
+	if err != nil {
+		return Part{}, err
+	}
+
+	part, err := core.PutObjectPart(ctx, s.options.Bucket, key, uploadID, number, bytes.NewReader(data), int64(len(data)), minio.PutObjectPartOptions{
+		Sha256Hex: digest,
+	})
+	if err != nil {
+		return Part{}, s3Error(err)
+	}
+
+	return Part{Number: part.PartNumber, Size: part.Size, ETag: part.ETag}, nil
+}
+
+// Parts returns the parts of the upload that have been stored so far.
+func (s *S3) Parts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	core, err := s.core()
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]Part, 0)
+	marker := 0
+	for {
+		result, err := core.ListObjectParts(ctx, s.options.Bucket, key, uploadID, marker, 0)
+		if err != nil {
+			return nil, s3Error(err)
+		}
+
+		for _, p := range result.ObjectParts {
+			parts = append(parts, Part{Number: p.PartNumber, Size: p.Size, ETag: p.ETag})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteUpload joins the parts into the final object.
+func (s *S3) CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) (ObjectInfo, error) {
+	core, err := s.core()
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	complete := make([]minio.CompletePart, len(parts))
+	for i, p := range parts {
+		complete[i] = minio.CompletePart{
+			PartNumber: p.Number,
+			ETag:       p.ETag,
+		}
+	}
+
+	info, err := core.CompleteMultipartUpload(ctx, s.options.Bucket, key, uploadID, complete, minio.PutObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, s3Error(err)
+	}
+
+	return ObjectInfo{Key: info.Key, Size: info.Size, ETag: info.ETag}, nil
+}
+
+// AbortUpload discards the upload and its parts.
+func (s *S3) AbortUpload(ctx context.Context, key, uploadID string) error {
+	core, err := s.core()
+	if err != nil {
+		return err
+	}
+
+	return s3Error(core.AbortMultipartUpload(ctx, s.options.Bucket, key, uploadID))
+}
+
+func (s *S3) core() (*minio.Core, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+
+	return &minio.Core{Client: client}, nil
+}
+
+func (s *S3) client() (*minio.Client, error) {
+	endpoint, err := url.Parse(s.options.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []credentials.Provider{}
+	if s.options.AccessKey != "" {
+		providers = append(providers, &credentials.Static{
+			Value: credentials.Value{
+				AccessKeyID:     s.options.AccessKey,
+				SecretAccessKey: s.options.SecretKey,
+				SignerType:      credentials.SignatureV4,
+			},
+		})
+	}
+
+	providers = append(providers,
+		// Requires MINIO_ACCESS_KEY and MINIO_SECRET_KEY.
+		&credentials.EnvMinio{},
+		// Requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+		&credentials.EnvAWS{},
+	)
+
+	lookup := minio.BucketLookupAuto
+	if s.options.ForcePathStyle {
+		lookup = minio.BucketLookupPath
+	}
+
+	return minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewChainCredentials(providers),
+		Secure:       endpoint.Scheme == "https",
+		Region:       s.options.Region,
+		BucketLookup: lookup,
+	})
+}
+
+// s3Error translates the errors for missing objects and uploads to ErrNotFound.
+func s3Error(err error) error {
+	var resp minio.ErrorResponse
+	if !errors.As(err, &resp) {
+		return err
+	}
+
+	switch resp.Code {
+	case minio.NoSuchKey, minio.NoSuchUpload, "NotFound":
+		return fmt.Errorf("%w: %s", ErrNotFound, resp.Message)
+	}
+
+	return err
+}
+
+var (
+	_ Storage   = &S3{}
+	_ Multipart = &S3{}
+	_ Bucket    = &S3{}
+)
@@ -0,0 +1,102 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when an object or multipart upload doesn't exist.
+	ErrNotFound = errors.New("not found")
+	// ErrNotSupported is returned when the backend doesn't support an operation.
+	ErrNotSupported = errors.New("not supported by the storage backend")
+)
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	// Key is the key of the object.
+	Key string
+	// Size is the size of the object in bytes.
+	Size int64
+	// ETag is the entity tag reported by the backend.  Its format depends on the
+	// backend and shouldn't be relied on as a digest.
+	ETag string
+	// LastModified is the time the object was last written.  It's only set by List.
+	LastModified time.Time
+}
+
+// Storage is the object storage that holds the source archives.
+type Storage interface {
+	// Put stores the contents of the reader under the key, replacing any existing
+	// object.  Size is -1 if it isn't known ahead of time.
+	Put(ctx context.Context, key string, r io.Reader, size int64) (ObjectInfo, error)
+	// Get returns the contents of the object.  ErrNotFound is returned if the object
+	// doesn't exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object with the given key.  Deleting an object that
+	// does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns the objects with keys that start with the prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// Exists returns whether the object exists.
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// Part is a part of a multipart upload that has been stored.
+type Part struct {
+	// Number is the part number starting at 1.
+	Number int
+	// Size is the size of the part in bytes.
+	Size int64
+	// ETag is the backend's identifier for the part content.
+	ETag string
+}
+
+// Multipart is implemented by backends that can assemble an object from parts that
+// are uploaded independently.  ErrNotFound is returned for uploads that don't exist.
+type Multipart interface {
+	// NewUpload starts a multipart upload for the key and returns its id.
+	NewUpload(ctx context.Context, key string) (string, error)
+	// PutPart stores a single part.  The sha256 digest of the data is passed along
+	// for backends that can verify it on their end.
+	PutPart(ctx context.Context, key, uploadID string, number int, data []byte, digest string) (Part, error)
+	// Parts returns the parts that have been stored so far ordered by number.
+	Parts(ctx context.Context, key, uploadID string) ([]Part, error)
+	// CompleteUpload joins the parts into the final object.
+	CompleteUpload(ctx context.Context, key, uploadID string, parts []Part) (ObjectInfo, error)
+	// AbortUpload discards the upload and any parts that were stored.
+	AbortUpload(ctx context.Context, key, uploadID string) error
+}
+
+// Bucket is implemented by backends that can create the bucket or container the
+// objects are stored in.
+type Bucket interface {
+	// EnsureBucket creates the bucket if it doesn't exist.
+	EnsureBucket(ctx context.Context) error
+}
+
+// EnsureBucket creates the bucket if the backend supports it.  Backends that can't
+// create buckets expect them to exist already.
+func EnsureBucket(ctx context.Context, s Storage) error {
+	if b, ok := s.(Bucket); ok {
+		return b.EnsureBucket(ctx)
+	}
+
+	return nil
+}
@@ -2,98 +2,342 @@ package tracker
 
 import (
 	"context"
+	"errors"
 	"sync"
 
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/tools/record"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
-	Tracking      = "Tracking"
-	Transitioning = "Transitioning"
+	// HistorySize is the number of transitions kept for each environment so that
+	// subscribers can resume from a sequence number after reconnecting.
+	HistorySize = 64
+	// SubscriberBufferSize is the number of transitions that can be queued for a
+	// subscriber.  Subscribers that fall further behind are closed and are expected
+	// to resubscribe from the last sequence they received.
+	SubscriberBufferSize = 64
+)
+
+var (
+	// ErrNotFound is returned when subscribing to an environment that doesn't exist.
+	ErrNotFound = errors.New("environment not found")
+	// ErrDeleted is the reason a subscription is closed when the environment is deleted.
+	ErrDeleted = errors.New("environment was deleted")
+	// ErrFellBehind is the reason a subscription is closed when the subscriber can't
+	// keep up.  It can resubscribe from the last sequence it received.
+	ErrFellBehind = errors.New("subscriber fell behind")
 )
 
 type TrackingInfo struct {
+	// Sequence is the stage sequence from the environment status.  It's stored with
+	// the environment so it's the same on every replica and across restarts.
+	Sequence  uint64
+	Revision  string
 	Status    string
 	Stage     string
 	LastStage string
+	// Log is the tail of the build log when the build has failed.
+	Log string
+	// Resync is set when the current state is sent to a subscriber in place of the
+	// transitions that it asked for, either because it didn't give a sequence or
+	// because they are no longer in the history.  It may describe an earlier revision
+	// than the one the subscriber is waiting for.
+	Resync bool
 }
 
+// IsDone returns true if the environment has reached a terminal state.
+func (i TrackingInfo) IsDone() bool {
+	return i.Status == "deployed" || i.Status == "failed"
+}
+
+// resync returns a copy of the state flagged as a resync.
+func (i TrackingInfo) resync() TrackingInfo {
+	i.Resync = true
+	return i
+}
+
+// Subscription delivers the transitions of an environment to a subscriber.
+type Subscription struct {
+	ch      chan TrackingInfo
+	err     error
+	tracker *Tracker
+	entry   *entry
+	once    sync.Once
+}
+
+// Events returns the channel that receives the transitions.  It's closed when the
+// subscription is closed, the subscriber can't keep up, or the environment is deleted.
+func (s *Subscription) Events() <-chan TrackingInfo {
+	return s.ch
+}
+
+// Err returns why the events channel was closed: ErrDeleted, ErrFellBehind, or nil if
+// the subscription was closed by the subscriber.  It's only set once the channel has
+// been closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close unsubscribes from the environment.  It's safe to call more than once and
+// after the channel has been closed.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.tracker.Lock()
+		defer s.tracker.Unlock()
+
+		if _, ok := s.entry.subscribers[s]; ok {
+			s.close(nil)
+		}
+	})
+}
+
+// close removes the subscription from the environment and closes the channel.  The
+// tracker must be locked.
+func (s *Subscription) close(err error) {
+	delete(s.entry.subscribers, s)
+	s.err = err
+	close(s.ch)
+}
+
+type entry struct {
+	current     TrackingInfo
+	history     []TrackingInfo
+	subscribers map[*Subscription]struct{}
+}
+
+// Tracker follows the environments through their stages.  It's fed by a watch on the
+// environments through the informer cache rather than the reconciler, so every replica
+// has the same view of the environments whether or not it's the leader.
 type Tracker struct {
-	envs     map[types.NamespacedName]*TrackingInfo
-	recorder record.EventRecorder
+	cache cache.Cache
+	envs  map[types.NamespacedName]*entry
 	sync.Mutex
 }
 
-func New(recorder record.EventRecorder) *Tracker {
+func New(c cache.Cache) *Tracker {
 	return &Tracker{
-		envs:     make(map[types.NamespacedName]*TrackingInfo),
-		recorder: recorder,
+		cache: c,
+		envs:  make(map[types.NamespacedName]*entry),
+	}
+}
+
+// Start registers the tracker with the environment informer and blocks until the
+// context is done.
+func (t *Tracker) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	informer, err := t.cache.GetInformer(ctx, &v1beta1.Environment{})
+	if err != nil {
+		return err
+	}
+
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if env, ok := obj.(*v1beta1.Environment); ok {
+				t.Track(ctx, env)
+			}
+		},
+		UpdateFunc: func(_, obj any) {
+			if env, ok := obj.(*v1beta1.Environment); ok {
+				t.Track(ctx, env)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if env, ok := obj.(*v1beta1.Environment); ok {
+				t.Forget(env.GetNamespace(), env.GetName())
+			}
+		},
+	})
+	if err != nil {
+		return err
 	}
+
+	logger.V(1).Info("tracking environments")
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.  The tracker serves
+// the API on every replica, so it needs to run on all of them.
+func (t *Tracker) NeedLeaderElection() bool {
+	return false
 }
 
-func (t *Tracker) Track(ctx context.Context, env *v1beta1.Environment) {
+// Track records the current state of the environment and publishes it to all of the
+// subscribers.  States that are not newer than the tracked state are ignored.
+func (t *Tracker) Track(_ context.Context, env *v1beta1.Environment) {
 	t.Lock()
 	defer t.Unlock()
 
+	t.track(env)
+}
+
+func (t *Tracker) track(env *v1beta1.Environment) {
 	nn := types.NamespacedName{
 		Namespace: env.Namespace,
 		Name:      env.Name,
 	}
-	if _, ok := t.envs[nn]; !ok {
-		t.envs[nn] = &TrackingInfo{
-			Status:    env.GetStatusString(),
-			Stage:     env.GetStageString(),
-			LastStage: env.GetStageString(),
+
+	info := TrackingInfo{
+		Sequence: uint64(env.Status.Sequence), //nolint:gosec
+		Revision: revision(env),
+		Status:   env.GetStatusString(),
+		Stage:    env.GetStageString(),
+		Log:      env.Status.BuildLog,
+	}
+
+	e, ok := t.envs[nn]
+	if !ok {
+		e = &entry{
+			subscribers: make(map[*Subscription]struct{}),
 		}
+		t.envs[nn] = e
+	} else if info.Sequence <= e.current.Sequence {
+		return
+	}
 
-		t.recorder.Event(env, corev1.EventTypeNormal, Tracking, t.envs[nn].Stage)
+	info.LastStage = info.Stage
+	if ok {
+		info.LastStage = e.current.Stage
 	}
 
-	t.envs[nn].Status = env.GetStatusString()
-	t.envs[nn].LastStage = t.envs[nn].Stage
-	t.envs[nn].Stage = env.GetStageString()
+	e.current = info
+	e.history = append(e.history, info)
+	if len(e.history) > HistorySize {
+		e.history = e.history[len(e.history)-HistorySize:]
+	}
 
-	if t.envs[nn].Stage != t.envs[nn].LastStage {
-		t.recorder.Event(env, corev1.EventTypeNormal, Transitioning, t.envs[nn].Stage)
+	for sub := range e.subscribers {
+		select {
+		case sub.ch <- info:
+		default:
+			// The subscriber isn't keeping up.  Close it rather than block the
+			// informer, it can resume from the last sequence it received.
+			sub.close(ErrFellBehind)
+		}
 	}
 }
 
-func (t *Tracker) Get(namespace, name string) (TrackingInfo, bool) {
+// revision returns the revision that the status describes.  The spec revision changes
+// as soon as a new revision is uploaded, well before the controller has started on it,
+// so it can't be used to describe the current stage.
+func revision(env *v1beta1.Environment) string {
+	if env.IsDeployed() {
+		return env.Status.DeployedRevision
+	}
+	return env.Status.ExpectedRevision
+}
+
+// Forget removes the environment and closes its subscriptions with ErrDeleted.
+func (t *Tracker) Forget(namespace, name string) {
 	t.Lock()
 	defer t.Unlock()
 
-	if info, ok := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
-		return *info, true
+	nn := types.NamespacedName{Namespace: namespace, Name: name}
+	e, ok := t.envs[nn]
+	if !ok {
+		return
 	}
 
-	return TrackingInfo{}, false
+	for sub := range e.subscribers {
+		sub.close(ErrDeleted)
+	}
+	delete(t.envs, nn)
 }
 
-func (t *Tracker) HasChanged(namespace, name string) bool {
+// load tracks the environment from the cache if it hasn't been seen yet.  This covers
+// requests that come in before the informer has delivered the environment.
+func (t *Tracker) load(ctx context.Context, namespace, name string) {
+	if t.cache == nil {
+		return
+	}
+
+	t.Lock()
+	_, tracked := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]
+	t.Unlock()
+
+	if tracked {
+		return
+	}
+
+	var env v1beta1.Environment
+	if err := t.cache.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &env); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).Error(err, "unable to get environment", "namespace", namespace, "name", name)
+		}
+		return
+	}
+
+	t.Track(ctx, &env)
+}
+
+// Get returns the current state of the environment.
+func (t *Tracker) Get(ctx context.Context, namespace, name string) (TrackingInfo, bool) {
+	t.load(ctx, namespace, name)
+
 	t.Lock()
 	defer t.Unlock()
 
-	// Do I need to return a notfound error?
-	info, ok := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]
-	if !ok {
-		return false
+	if e, ok := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]; ok {
+		return e.current, true
 	}
 
-	return info.Stage != info.LastStage
+	return TrackingInfo{}, false
 }
 
-func (t *Tracker) IsDeployed(namespace, name string) bool {
+// Subscribe returns a subscription that receives every transition of the environment
+// in order.  If after is zero, it starts with the current state.  Otherwise the
+// retained transitions with a greater sequence are replayed first.  If some of them
+// are no longer retained, or were never seen by this replica, the current state is
+// sent instead.  States that are sent in place of transitions are flagged with Resync.
+// ErrNotFound is returned if the environment doesn't exist.  The subscription must be
+// closed when it's no longer needed.
+func (t *Tracker) Subscribe(ctx context.Context, namespace, name string, after uint64) (*Subscription, error) {
+	t.load(ctx, namespace, name)
+
 	t.Lock()
 	defer t.Unlock()
 
-	info, ok := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]
+	e, ok := t.envs[types.NamespacedName{Namespace: namespace, Name: name}]
 	if !ok {
-		return false
+		return nil, ErrNotFound
+	}
+
+	sub := &Subscription{
+		ch:      make(chan TrackingInfo, SubscriberBufferSize+HistorySize),
+		tracker: t,
+		entry:   e,
+	}
+
+	switch {
+	case after == 0:
+		sub.ch <- e.current.resync()
+	case e.current.Sequence <= after:
+		// The subscriber is up to date.
+	case e.history[0].Sequence > after+1:
+		// The transitions after the sequence have been dropped from the history.
+		sub.ch <- e.current.resync()
+	default:
+		for _, info := range e.history {
+			if info.Sequence > after {
+				sub.ch <- info
+			}
+		}
 	}
 
-	return info.Status == "deployed"
+	e.subscribers[sub] = struct{}{}
+
+	return sub, nil
 }
+
+var _ manager.LeaderElectionRunnable = &Tracker{}
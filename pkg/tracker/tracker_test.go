@@ -4,117 +4,283 @@ import (
 	"context"
 	"testing"
 
-	"k8s.io/client-go/tools/record"
-
 	"ctx.sh/seaway/pkg/apis/seaway.ctx.sh/v1beta1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
-func TestTracker_Track(t *testing.T) {
-	tracker := New(record.NewFakeRecorder(1000))
-	assert.Empty(t, tracker.envs)
-
-	// Track new environment
-	env := &v1beta1.Environment{
+func trackStage(tracker *Tracker, name string, seq int64, stage v1beta1.EnvironmentStage) {
+	tracker.Track(context.TODO(), &v1beta1.Environment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test",
+			Name:      name,
 			Namespace: "default",
 		},
+		Spec: v1beta1.EnvironmentSpec{
+			Revision: "fake",
+		},
 		Status: v1beta1.EnvironmentStatus{
-			Stage:            v1beta1.EnvironmentStageInitialize,
-			DeployedRevision: "fake",
+			Sequence:         seq,
+			Stage:            stage,
+			ExpectedRevision: "fake",
+			DeployedRevision: "previous",
 		},
-	}
+	})
+}
 
-	tracker.Track(context.TODO(), env)
+func current(tracker *Tracker, name string) TrackingInfo {
+	return tracker.envs[types.NamespacedName{
+		Name:      name,
+		Namespace: "default",
+	}].current
+}
+
+func TestTracker_Track(t *testing.T) {
+	tracker := New(nil)
+	assert.Empty(t, tracker.envs)
+
+	// Track new environment
+	trackStage(tracker, "test", 0, v1beta1.EnvironmentStageInitialize)
 	assert.Len(t, tracker.envs, 1)
-	assert.Equal(t, &TrackingInfo{
+	assert.Equal(t, TrackingInfo{
+		Sequence:  0,
+		Revision:  "fake",
 		Stage:     "Initializing",
 		LastStage: "Initializing",
 		Status:    "initializing",
-	}, tracker.envs[types.NamespacedName{
-		Name:      "test",
-		Namespace: "default",
-	}])
+	}, current(tracker, "test"))
 
 	// Track an environment update
-	env = &v1beta1.Environment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test",
-			Namespace: "default",
-		},
-		Status: v1beta1.EnvironmentStatus{
-			Stage:            v1beta1.EnvironmentStageDeploy,
-			DeployedRevision: "fake",
-		},
-	}
-
-	tracker.Track(context.TODO(), env)
+	trackStage(tracker, "test", 1, v1beta1.EnvironmentStageDeploy)
 	assert.Len(t, tracker.envs, 1)
-	assert.Equal(t, &TrackingInfo{
+	assert.Equal(t, TrackingInfo{
+		Sequence:  1,
+		Revision:  "fake",
 		Stage:     "Deploying the revision",
 		LastStage: "Initializing",
 		Status:    "deploying",
-	}, tracker.envs[types.NamespacedName{
-		Name:      "test",
-		Namespace: "default",
-	}])
+	}, current(tracker, "test"))
+
+	// Stale updates are ignored
+	trackStage(tracker, "test", 0, v1beta1.EnvironmentStageInitialize)
+	assert.Equal(t, uint64(1), current(tracker, "test").Sequence)
 
-	// Track updated environment
-	env = &v1beta1.Environment{
+	// Track another environment
+	trackStage(tracker, "another-test", 3, v1beta1.EnvironmentStageBuildImage)
+	assert.Len(t, tracker.envs, 2)
+	assert.Equal(t, uint64(3), current(tracker, "another-test").Sequence)
+	assert.Equal(t, uint64(1), current(tracker, "test").Sequence)
+
+	info, ok := tracker.Get(context.TODO(), "default", "another-test")
+	assert.True(t, ok)
+	assert.Equal(t, string(v1beta1.EnvironmentStageBuildImage), info.Stage)
+
+	_, ok = tracker.Get(context.TODO(), "default", "missing")
+	assert.False(t, ok)
+}
+
+func TestTracker_Revision(t *testing.T) {
+	tracker := New(nil)
+
+	env := &v1beta1.Environment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
 			Namespace: "default",
 		},
 		Spec: v1beta1.EnvironmentSpec{
-			Revision: "fake",
+			Revision: "1",
 		},
 		Status: v1beta1.EnvironmentStatus{
+			Sequence:         4,
 			Stage:            v1beta1.EnvironmentStageDeployed,
-			DeployedRevision: "fake",
+			ExpectedRevision: "1",
+			DeployedRevision: "1",
 		},
 	}
+	tracker.Track(context.TODO(), env)
+	assert.Equal(t, "1", current(tracker, "test").Revision)
+	assert.Equal(t, "deployed", current(tracker, "test").Status)
 
+	// A new revision has been uploaded, but the controller hasn't picked it up yet so
+	// the status still describes the last one.
+	env.Spec.Revision = "2"
+	env.Status.Sequence = 5
 	tracker.Track(context.TODO(), env)
-	assert.Len(t, tracker.envs, 1)
-	assert.Equal(t, &TrackingInfo{
-		Stage:     "Revision deployed",
-		LastStage: "Deploying the revision",
-		Status:    "deployed",
-	}, tracker.envs[types.NamespacedName{
-		Name:      "test",
-		Namespace: "default",
-	}])
+	assert.Equal(t, "1", current(tracker, "test").Revision)
 
-	env = &v1beta1.Environment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "another-test",
-			Namespace: "default",
-		},
-		Status: v1beta1.EnvironmentStatus{
-			Stage:            v1beta1.EnvironmentStageInitialize,
-			DeployedRevision: "fake",
-		},
+	env.Status.Sequence = 6
+	env.Status.Stage = v1beta1.EnvironmentStageBuildImage
+	env.Status.ExpectedRevision = "2"
+	tracker.Track(context.TODO(), env)
+	assert.Equal(t, "2", current(tracker, "test").Revision)
+	assert.Equal(t, "deploying", current(tracker, "test").Status)
+}
+
+func TestTracker_Subscribe(t *testing.T) {
+	tracker := New(nil)
+
+	trackStage(tracker, "test", 1, v1beta1.EnvironmentStageInitialize)
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// Every transition is delivered in order, even when they happen back to back.
+	trackStage(tracker, "test", 2, v1beta1.EnvironmentStageBuildImage)
+	trackStage(tracker, "test", 2, v1beta1.EnvironmentStageBuildImage)
+	trackStage(tracker, "test", 3, v1beta1.EnvironmentStageBuildImageWait)
+
+	for i, stage := range []string{string(v1beta1.EnvironmentStageBuildImage), string(v1beta1.EnvironmentStageBuildImageWait)} {
+		info := <-sub.Events()
+		assert.Equal(t, uint64(i+2), info.Sequence)
+		assert.Equal(t, stage, info.Stage)
 	}
 
-	tracker.Track(context.TODO(), env)
-	assert.Len(t, tracker.envs, 2)
-	assert.Equal(t, &TrackingInfo{
-		Stage:     "Initializing",
-		LastStage: "Initializing",
-		Status:    "initializing",
-	}, tracker.envs[types.NamespacedName{
-		Name:      "another-test",
-		Namespace: "default",
-	}])
-	assert.Equal(t, &TrackingInfo{
-		Stage:     "Revision deployed",
-		LastStage: "Deploying the revision",
-		Status:    "deployed",
-	}, tracker.envs[types.NamespacedName{
-		Name:      "test",
-		Namespace: "default",
-	}])
+	assert.Empty(t, sub.Events())
+}
+
+func TestTracker_SubscribeNotFound(t *testing.T) {
+	tracker := New(nil)
+
+	sub, err := tracker.Subscribe(context.TODO(), "default", "missing", 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Nil(t, sub)
+
+	// Subscribing doesn't leave anything behind for environments that don't exist.
+	assert.Empty(t, tracker.envs)
+}
+
+func TestTracker_SubscribeResume(t *testing.T) {
+	tracker := New(nil)
+
+	trackStage(tracker, "test", 1, v1beta1.EnvironmentStageInitialize)
+	trackStage(tracker, "test", 2, v1beta1.EnvironmentStageBuildImage)
+	trackStage(tracker, "test", 3, v1beta1.EnvironmentStageBuildImageWait)
+
+	// Without a sequence only the current state is sent.
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 0)
+	require.NoError(t, err)
+	info := <-sub.Events()
+	assert.Equal(t, uint64(3), info.Sequence)
+	assert.True(t, info.Resync)
+	assert.Empty(t, sub.Events())
+	sub.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+
+	// Resuming replays everything after the sequence.
+	sub, err = tracker.Subscribe(context.TODO(), "default", "test", 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	for _, seq := range []uint64{2, 3} {
+		info := <-sub.Events()
+		assert.Equal(t, seq, info.Sequence)
+		assert.False(t, info.Resync)
+	}
+	assert.Empty(t, sub.Events())
+}
+
+func TestTracker_SubscribeResumeWithoutHistory(t *testing.T) {
+	tracker := New(nil)
+
+	// A replica that started after the transitions only knows the current state.
+	trackStage(tracker, "test", 5, v1beta1.EnvironmentStageDeploy)
+
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 2)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	info := <-sub.Events()
+	assert.Equal(t, uint64(5), info.Sequence)
+	assert.True(t, info.Resync)
+	assert.Empty(t, sub.Events())
+
+	// Nothing is sent if the client is already up to date.
+	uptodate, err := tracker.Subscribe(context.TODO(), "default", "test", 5)
+	require.NoError(t, err)
+	defer uptodate.Close()
+	assert.Empty(t, uptodate.Events())
+}
+
+func TestTracker_SubscribeResumeAfterHistory(t *testing.T) {
+	tracker := New(nil)
+
+	stages := []v1beta1.EnvironmentStage{v1beta1.EnvironmentStageBuildImage, v1beta1.EnvironmentStageBuildImageWait}
+	for i := 0; i < HistorySize+2; i++ {
+		trackStage(tracker, "test", int64(i+1), stages[i%2])
+	}
+
+	// The transition after the sequence has been dropped from the history, so only
+	// the current state is sent.
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	info := <-sub.Events()
+	assert.Equal(t, uint64(HistorySize+2), info.Sequence)
+	assert.True(t, info.Resync)
+	assert.Empty(t, sub.Events())
+
+	// The oldest retained transition is the next one, so everything is replayed.
+	replayed, err := tracker.Subscribe(context.TODO(), "default", "test", 2)
+	require.NoError(t, err)
+	defer replayed.Close()
+
+	assert.Len(t, replayed.Events(), HistorySize)
+	assert.False(t, (<-replayed.Events()).Resync)
+}
+
+func TestTracker_SubscribeSlowConsumer(t *testing.T) {
+	tracker := New(nil)
+
+	trackStage(tracker, "test", 1, v1beta1.EnvironmentStageInitialize)
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 1)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	stages := []v1beta1.EnvironmentStage{v1beta1.EnvironmentStageBuildImage, v1beta1.EnvironmentStageBuildImageWait}
+	for i := 0; i <= SubscriberBufferSize+HistorySize; i++ {
+		trackStage(tracker, "test", int64(i+2), stages[i%2])
+	}
+
+	count := 0
+	for range sub.Events() {
+		count++
+	}
+
+	// The subscriber is closed once its buffer fills up.
+	assert.Equal(t, SubscriberBufferSize+HistorySize, count)
+	assert.ErrorIs(t, sub.Err(), ErrFellBehind)
+
+	// The environment is still tracked, so the subscriber can resume.
+	_, ok := tracker.Get(context.TODO(), "default", "test")
+	assert.True(t, ok)
+}
+
+func TestTracker_Forget(t *testing.T) {
+	tracker := New(nil)
+
+	trackStage(tracker, "test", 1, v1beta1.EnvironmentStageInitialize)
+	sub, err := tracker.Subscribe(context.TODO(), "default", "test", 0)
+	require.NoError(t, err)
+	<-sub.Events()
+
+	tracker.Forget("default", "test")
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), ErrDeleted)
+
+	// Closing the subscription after the environment is gone is safe.
+	sub.Close()
+	assert.ErrorIs(t, sub.Err(), ErrDeleted)
+
+	_, ok = tracker.Get(context.TODO(), "default", "test")
+	assert.False(t, ok)
+
+	// Resubscribing after the environment is gone reports it as not found.
+	_, err = tracker.Subscribe(context.TODO(), "default", "test", 1)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
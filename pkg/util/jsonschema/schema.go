@@ -0,0 +1,149 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema holds the subset of JSON Schema that is generated for the manifest,
+// and checks YAML documents against it so problems can be reported with their position
+// in the file.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// Draft is the version of JSON Schema that the schemas are written in.
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+	// DefsPrefix is the prefix of references to the definitions in the schema.
+	DefsPrefix = "#/$defs/"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is a JSON schema.  Only the keywords used by the manifest schema are
+// supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// never is set for the false schema that nothing is valid against.
+	never bool
+}
+
+// False returns the schema that nothing is valid against.  As additional properties
+// it disallows any property that isn't listed.
+func False() *Schema {
+	return &Schema{never: true}
+}
+
+// Ref returns a schema that refers to the named definition.
+func Ref(name string) *Schema {
+	return &Schema{Ref: DefsPrefix + name}
+}
+
+// Parse reads a schema.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.never {
+		return []byte("false"), nil
+	}
+
+	type plain Schema
+	return json.Marshal((*plain)(s))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.  Boolean schemas are
+// accepted, true is the same as an empty schema.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = Schema{}
+		return nil
+	case "false":
+		*s = Schema{never: true}
+		return nil
+	}
+
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// IsFalse returns true for the schema that nothing is valid against.
+func (s *Schema) IsFalse() bool {
+	return s.never
+}
+
+// resolve follows the reference to a definition in the root schema.
+func (s *Schema) resolve(root *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = root.Defs[strings.TrimPrefix(s.Ref, DefsPrefix)]
+	}
+
+	return s
+}
+
+// Types is the list of types a value may have.  A single type is written as a string.
+type Types []string
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*t = list
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testSchema = `{
+  "$ref": "#/$defs/App",
+  "$defs": {
+    "App": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string"},
+        "replicas": {"type": "integer"},
+        "ratio": {"type": "number"},
+        "debug": {"type": "boolean"},
+        "timeout": {"type": ["string", "integer"]},
+        "engine": {"type": "string", "enum": ["kaniko", "buildkit"]},
+        "ports": {"type": "array", "items": {"$ref": "#/$defs/Port"}},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+        "extra": {"type": "object"}
+      },
+      "required": ["name"],
+      "additionalProperties": false
+    },
+    "Port": {
+      "type": "object",
+      "properties": {
+        "containerPort": {"type": "integer"}
+      },
+      "additionalProperties": false
+    }
+  }
+}`
+
+func TestSchemaJSON(t *testing.T) {
+	s, err := Parse([]byte(testSchema))
+	require.NoError(t, err)
+
+	app := s.Defs["App"]
+	require.NotNil(t, app)
+	assert.True(t, app.AdditionalProperties.IsFalse())
+	assert.False(t, app.Properties["labels"].AdditionalProperties.IsFalse())
+	assert.Equal(t, Types{TypeString, TypeInteger}, app.Properties["timeout"].Type)
+
+	out, err := json.Marshal(&Schema{
+		Type:                 Types{TypeObject},
+		Properties:           map[string]*Schema{"items": {Type: Types{TypeArray}, Items: Ref("Item")}},
+		AdditionalProperties: False(),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {"items": {"type": "array", "items": {"$ref": "#/$defs/Item"}}},
+		"additionalProperties": false
+	}`, string(out))
+
+	var b Schema
+	require.NoError(t, json.Unmarshal([]byte(`true`), &b))
+	assert.False(t, b.IsFalse())
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(testSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		doc      string
+		expected []string
+	}{
+		{
+			name: "valid",
+			doc: `name: app
+replicas: 2
+ratio: 1
+debug: true
+timeout: 30s
+engine: kaniko
+ports:
+  - containerPort: 8080
+labels:
+  team: core
+extra:
+  anything: [1, 2]
+`,
+		},
+		{
+			name:     "nulls",
+			doc:      "name: app\nreplicas:\nports: ~\n",
+			expected: nil,
+		},
+		{
+			name: "unknown fields",
+			doc:  "name: app\nreplica: 2\nports:\n  - containerport: 80\n",
+			expected: []string{
+				`2:1: unknown field "replica"`,
+				`4:5: unknown field "containerport", did you mean "containerPort"?`,
+			},
+		},
+		{
+			name: "types",
+			doc:  "name: 12\nreplicas: \"2\"\nratio: 1.5\ndebug: yes\ntimeout: [1]\nlabels: [a]\nports: {}\n",
+			expected: []string{
+				`2:11: expected integer, found string`,
+				`4:8: expected boolean, found string`,
+				`5:10: expected string or integer, found array`,
+				`6:9: expected object, found array`,
+				`7:8: expected array, found object`,
+			},
+		},
+		{
+			name:     "enum",
+			doc:      "name: app\nengine: docker\n",
+			expected: []string{`2:9: "docker" is not one of kaniko, buildkit`},
+		},
+		{
+			name:     "required",
+			doc:      "replicas: 1\n",
+			expected: []string{`1:1: missing required field "name"`},
+		},
+		{
+			name:     "anchors",
+			doc:      "name: app\nlabels: &labels\n  team: core\nextra:\n  labels: *labels\nports:\n  - &port {containerPort: 80}\n  - *port\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.doc), &doc))
+
+			var errs []string
+			for _, e := range s.Validate(&doc) {
+				errs = append(errs, e.Error())
+			}
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 Seaway Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a value in the document that doesn't match the schema.
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// Validate checks the YAML document against the schema and returns the errors in the
+// order they appear in the document.  Null values are accepted anywhere since they
+// decode to the zero value.
+func (s *Schema) Validate(node *yaml.Node) []Error {
+	v := &validator{root: s}
+	v.validate(s, node)
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		if v.errors[i].Line != v.errors[j].Line {
+			return v.errors[i].Line < v.errors[j].Line
+		}
+		return v.errors[i].Column < v.errors[j].Column
+	})
+
+	return v.errors
+}
+
+type validator struct {
+	root   *Schema
+	errors []Error
+}
+
+func (v *validator) errorf(node *yaml.Node, format string, a ...any) {
+	v.errors = append(v.errors, Error{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (v *validator) validate(s *Schema, node *yaml.Node) {
+	s = s.resolve(v.root)
+	if s == nil {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			v.validate(s, n)
+		}
+		return
+	case yaml.AliasNode:
+		v.validate(s, node.Alias)
+		return
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	kind := kindOf(node)
+	if len(s.Type) > 0 && !matches(s.Type, kind) {
+		v.errorf(node, "expected %s, found %s", strings.Join(s.Type, " or "), kind)
+		return
+	}
+
+	if len(s.Enum) > 0 && node.Kind == yaml.ScalarNode && !slices.Contains(s.Enum, node.Value) {
+		v.errorf(node, "%q is not one of %s", node.Value, strings.Join(s.Enum, ", "))
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateObject(s, node)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for _, n := range node.Content {
+				v.validate(s.Items, n)
+			}
+		}
+	}
+}
+
+func (v *validator) validateObject(s *Schema, node *yaml.Node) {
+	seen := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "<<" {
+			// Merge keys are checked where their anchor is defined.
+			continue
+		}
+		seen[key.Value] = true
+
+		if prop, ok := s.Properties[key.Value]; ok {
+			v.validate(prop, value)
+			continue
+		}
+
+		switch {
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.IsFalse():
+			if similar := s.similar(key.Value); similar != "" {
+				v.errorf(key, "unknown field %q, did you mean %q?", key.Value, similar)
+			} else {
+				v.errorf(key, "unknown field %q", key.Value)
+			}
+		default:
+			v.validate(s.AdditionalProperties, value)
+		}
+	}
+
+	for _, name := range s.Required {
+		if !seen[name] {
+			v.errorf(node, "missing required field %q", name)
+		}
+	}
+}
+
+// similar returns the property that only differs from the name in case.
+func (s *Schema) similar(name string) string {
+	for prop := range s.Properties {
+		if strings.EqualFold(prop, name) {
+			return prop
+		}
+	}
+
+	return ""
+}
+
+// kindOf returns the JSON type of the node.
+func kindOf(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return TypeObject
+	case yaml.SequenceNode:
+		return TypeArray
+	}
+
+	switch node.Tag {
+	case "!!int":
+		return TypeInteger
+	case "!!float":
+		return TypeNumber
+	case "!!bool":
+		return TypeBoolean
+	}
+
+	return TypeString
+}
+
+// matches returns true if a value of the kind is one of the types.  Integers are
+// numbers, and any scalar is accepted as a string because that's how it's decoded.
+func matches(types Types, kind string) bool {
+	for _, t := range types {
+		switch {
+		case t == kind:
+			return true
+		case t == TypeNumber && kind == TypeInteger:
+			return true
+		case t == TypeString && kind != TypeObject && kind != TypeArray:
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,8 +1,75 @@
 package util
 
-import "strings"
+import (
+	"path"
+	"strings"
+)
 
-func ArchiveKey(prefix, namespace, name string) string {
-	nsn := namespace + "-" + name + ".tar.gz"
-	return strings.Join([]string{prefix, nsn}, "/")
+const (
+	archiveExt = ".tar.gz"
+	uploadExt  = ".json"
+)
+
+// ArchiveKey returns the key of the environment's source archive for the revision.
+// Archives are never overwritten, the build job for a revision always reads the
+// archive that was uploaded for it.
+func ArchiveKey(prefix, namespace, name, revision string) string {
+	return ArchivePrefix(prefix, namespace, name) + revision + archiveExt
+}
+
+// ArchivePrefix returns the prefix shared by all of the environment's archives.
+func ArchivePrefix(prefix, namespace, name string) string {
+	return strings.Join([]string{prefix, "archives", namespace, name, ""}, "/")
+}
+
+// ArchiveRevision returns the revision of an archive key or an empty string if the
+// key isn't an archive.
+func ArchiveRevision(key string) string {
+	base := path.Base(key)
+	if !strings.HasSuffix(base, archiveExt) {
+		return ""
+	}
+
+	return strings.TrimSuffix(base, archiveExt)
+}
+
+// BlobKey returns the key of a source file blob.  Blobs are addressed by their sha256
+// digest so they are shared between all of the environments using the same prefix.
+func BlobKey(prefix, digest string) string {
+	return strings.Join([]string{prefix, "blobs", "sha256", digest}, "/")
+}
+
+// BlobUploadKey returns the key that a blob is written to while it's being uploaded.
+// The blob is only moved to its shared key once the digest has been verified.
+func BlobUploadKey(prefix, digest, uploadID string) string {
+	return strings.Join([]string{prefix, "blobs", "uploads", digest + "-" + uploadID}, "/")
+}
+
+// ArchiveUploadKey returns the key that an archive is written to while it's being
+// uploaded.  It's only moved to its revision key once it has been verified, so a
+// rejected upload never replaces or removes an archive that a build depends on.
+func ArchiveUploadKey(prefix, namespace, name, uploadID string) string {
+	return UploadPrefix(prefix, namespace, name) + uploadID + archiveExt
+}
+
+// UploadKey returns the key of the state that is kept for a multipart upload of the
+// environment's archive while it's in progress.
+func UploadKey(prefix, namespace, name, uploadID string) string {
+	return UploadPrefix(prefix, namespace, name) + uploadID + uploadExt
+}
+
+// UploadPrefix returns the prefix shared by the environment's unfinished uploads.
+func UploadPrefix(prefix, namespace, name string) string {
+	return strings.Join([]string{prefix, "uploads", namespace, name, ""}, "/")
+}
+
+// UploadID returns the upload id of a multipart upload state key or an empty string
+// if the key isn't an upload state.
+func UploadID(key string) string {
+	base := path.Base(key)
+	if !strings.HasSuffix(base, uploadExt) {
+		return ""
+	}
+
+	return strings.TrimSuffix(base, uploadExt)
 }